@@ -9,6 +9,7 @@ import (
 	"news-feed/internal/db"
 	"news-feed/internal/repository"
 	"news-feed/internal/service"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/config/newsfeed"
 	"news-feed/pkg/logger"
 )
@@ -33,14 +34,19 @@ func main() {
 	newsFeedHandler := handler.NewNewsfeedHandler(newsFeedService)
 
 	// Set up gRPC server
-	grpcServer := grpc.NewServer()
+	// The caller is trusted from metadata, so anything that can connect must be the webapp
+	if cfg.InternalServiceToken == "" && !auth.IsLoopbackHost(cfg.AppHost) {
+		logger.LogError(fmt.Sprintf("INTERNAL_SERVICE_TOKEN is required to listen on %s", cfg.AppHost))
+		return
+	}
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(cfg.InternalServiceToken))) // Restore the caller forwarded by the webapp
 	newsfeedpb.RegisterNewsfeedServiceServer(grpcServer, newsFeedHandler)
 
 	// Start listening on the configured port
 	port := cfg.AppPort
-	lis, err := net.Listen("tcp", ":"+port)
+	lis, err := net.Listen("tcp", net.JoinHostPort(cfg.AppHost, port))
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Failed to listen on port %s: %v", port, err))
+		logger.LogError(fmt.Sprintf("Failed to listen on %s:%s: %v", cfg.AppHost, port, err))
 		return
 	}
 	if err := grpcServer.Serve(lis); err != nil {
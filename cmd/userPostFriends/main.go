@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"google.golang.org/grpc"
 	"net"
@@ -12,8 +13,10 @@ import (
 	"news-feed/internal/repository"
 	"news-feed/internal/service"
 	"news-feed/internal/storage"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/config/userPostFriends"
 	"news-feed/pkg/logger"
+	"news-feed/pkg/middleware"
 	"time"
 )
 
@@ -43,23 +46,31 @@ func main() {
 
 	// Initialize repositories and services
 	userRepo := repositoryFactory.CreateUserRepository(mySQLDB)
-	userService := serviceFactory.CreateUserService(userRepo)
+	userService := serviceFactory.CreateUserService(userRepo, minioStorage, serviceFactory.CreateMailer())
 	userHandler := handler.GRPCUserHandler{
 		UserService: userService,
 	}
 	postRepo := repositoryFactory.CreatePostRepository(mySQLDB)
-	postService := serviceFactory.CreatePostService(postRepo, minioStorage, userService)
+	friendRepo := repositoryFactory.CreateFriendRepository(mySQLDB)
+	linkPreviewWorker := serviceFactory.CreateLinkPreviewWorker(postRepo)
+	linkPreviewWorker.Start(context.Background())
+	postService := serviceFactory.CreatePostService(postRepo, friendRepo, minioStorage, userService, linkPreviewWorker)
 	postHandler := handler.GRPCPostHandler{
 		PostService: postService,
 	}
-	friendRepo := repositoryFactory.CreateFriendRepository(mySQLDB)
-	friendService := serviceFactory.CreateFriendsService(friendRepo, postRepo, userRepo)
+	friendService := serviceFactory.CreateFriendsService(friendRepo, postRepo, userRepo, minioStorage)
 	friendsHandler := handler.GRPCFriendsHandler{
 		FriendsService: friendService,
 	}
 
 	go userService.PeriodicallyRefreshBloomFilter(1 * time.Hour)
 
+	// Exit on a bad authentication configuration now rather than on the first request
+	middleware.Init()
+
+	// Pick up rotated JWT signing keys
+	go middleware.PeriodicallyReloadKeys(1 * time.Minute)
+
 	// Populate the Bloom filter
 	err = userService.InitializeBloomFilter()
 	if err != nil {
@@ -68,7 +79,12 @@ func main() {
 	}
 
 	// Set up gRPC server
-	grpcServer := grpc.NewServer()
+	// The caller is trusted from metadata, so anything that can connect must be the webapp
+	if cfg.InternalServiceToken == "" && !auth.IsLoopbackHost(cfg.AppHost) {
+		logger.LogError(fmt.Sprintf("INTERNAL_SERVICE_TOKEN is required to listen on %s", cfg.AppHost))
+		return
+	}
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(cfg.InternalServiceToken))) // Restore the caller forwarded by the webapp
 	// Assuming `RegisterUserServiceServer` is generated by protobuf for your `UserService`
 	userpb.RegisterUserServiceServer(grpcServer, &userHandler)
 	postpb.RegisterPostServiceServer(grpcServer, &postHandler)
@@ -76,9 +92,9 @@ func main() {
 
 	// Start listening on the configured port
 	port := cfg.AppPort
-	lis, err := net.Listen("tcp", ":"+port)
+	lis, err := net.Listen("tcp", net.JoinHostPort(cfg.AppHost, port))
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Failed to listen on port %s: %v", port, err))
+		logger.LogError(fmt.Sprintf("Failed to listen on %s:%s: %v", cfg.AppHost, port, err))
 		return
 	}
 	if err := grpcServer.Serve(lis); err != nil {
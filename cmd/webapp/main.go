@@ -13,9 +13,11 @@ import (
 	"news-feed/internal/api/generated/news-feed/postpb"
 	"news-feed/internal/api/generated/news-feed/userpb"
 	"news-feed/internal/api/handler"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/config/webApp"
 	"news-feed/pkg/logger"
 	"news-feed/pkg/middleware"
+	"time"
 )
 
 // @title News Feed API
@@ -43,6 +45,7 @@ func main() {
 	conn, err := grpc.Dial(
 		"127.0.0.1:"+cfg.PostUserFriendsPort,
 		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor(cfg.InternalServiceToken)), // Forward the caller to the service
 	) // Use secure connection in production
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Failed to connect to post user service server: %v", err))
@@ -62,7 +65,11 @@ func main() {
 		}
 	}(conn)
 
-	newsfeedConn, err := grpc.Dial("127.0.0.1:"+cfg.NewsfeedAppPort, grpc.WithInsecure())
+	newsfeedConn, err := grpc.Dial(
+		"127.0.0.1:"+cfg.NewsfeedAppPort,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor(cfg.InternalServiceToken)),
+	)
 	newsfeedService := newsfeedpb.NewNewsfeedServiceClient(newsfeedConn)
 	newsfeedHandler := handlerFactory.CreateNewsFeedHandler(newsfeedService)
 	if err != nil {
@@ -86,9 +93,23 @@ func main() {
 		logger.LogInfo(fmt.Sprintf("Starting pprof server on :6060"))
 	}()
 
+	// Exit on a bad authentication configuration now rather than on the first request
+	middleware.Init()
+
+	// Pick up rotated JWT keys
+	go middleware.PeriodicallyReloadKeys(1 * time.Minute)
+
 	// Prometheus metrics
 	http.Handle("/metrics", promhttp.Handler())
 
+	// @Summary JSON Web Key Set
+	// @Description Public keys access tokens are verified with.
+	// @Tags Auth
+	// @Produce  json
+	// @Success 200 {object} middleware.JWKSet
+	// @Router /.well-known/jwks.json [get]
+	http.HandleFunc("/.well-known/jwks.json", handler.JWKSHandler())
+
 	// Routes
 
 	// @Summary User login
@@ -102,6 +123,105 @@ func main() {
 	// @Router /v1/users/login [post]
 	http.HandleFunc("/v1/users/login", userHandler.Login())
 
+	// @Summary Refresh tokens
+	// @Description Exchange a refresh token for a new access and refresh token pair.
+	// @Tags Auth
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.RefreshTokenRequest  true  "Refresh token"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/auth/refresh [post]
+	http.HandleFunc("/v1/auth/refresh", userHandler.RefreshToken())
+
+	// @Summary Verify two-factor code
+	// @Description Exchange the two-factor token returned by login and a TOTP or recovery code for tokens.
+	// @Tags Auth
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.VerifyTwoFactorRequest  true  "Two-factor token and code"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/auth/2fa/verify [post]
+	http.HandleFunc("/v1/auth/2fa/verify", userHandler.VerifyTwoFactor())
+
+	// @Summary Sign in with identity provider
+	// @Description Start the OpenID Connect authorization code flow, the provider redirects back to the callback.
+	// @Tags Auth
+	// @Produce  json
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/auth/oidc/callback [get]
+	http.HandleFunc("/v1/auth/oidc/login", userHandler.OIDCLogin())
+	http.HandleFunc("/v1/auth/oidc/callback", userHandler.OIDCCallback())
+
+	// @Summary Set up TOTP
+	// @Description Enroll an authenticator app and activate two-factor authentication with one of its codes.
+	// @Tags Users
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.ActivateTOTPRequest  true  "Authenticator code"
+	// @Success 200 {object} userpb.ActivateTOTPResponse
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/me/2fa/totp/activate [post]
+	http.HandleFunc("/v1/me/2fa/totp/enroll", middleware.JWTAuthMiddleware(userHandler.EnrollTOTP()).ServeHTTP)
+	http.HandleFunc("/v1/me/2fa/totp/activate", middleware.JWTAuthMiddleware(userHandler.ActivateTOTP()).ServeHTTP)
+
+	// @Summary Logout
+	// @Description End the session the request was made with.
+	// @Tags Users
+	// @Produce  json
+	// @Success 200 {object} map[string]string
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/users/logout [post]
+	http.HandleFunc("/v1/users/logout", middleware.JWTAuthMiddleware(userHandler.Logout()).ServeHTTP)
+
+	// @Summary Manage sessions
+	// @Description List the caller's active sessions or revoke one of them.
+	// @Tags Users
+	// @Produce  json
+	// @Success 200 {object} userpb.ListSessionsResponse
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/me/sessions [get]
+	http.HandleFunc("/v1/me/sessions", userHandler.SessionsHandler)
+	http.HandleFunc("/v1/me/sessions/", userHandler.SessionsHandler)
+
+	// @Summary Manage API keys
+	// @Description Create, list or revoke personal API keys, sent in the X-API-Key header by tools and bots.
+	// @Tags Users
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.CreateAPIKeyRequest  true  "Name, scope and lifetime of the key"
+	// @Success 201 {object} userpb.CreateAPIKeyResponse
+	// @Failure 401 {object} handler.ErrorResponse
+	// @Router /v1/me/api-keys [post]
+	http.HandleFunc("/v1/me/api-keys", userHandler.APIKeysHandler)
+	http.HandleFunc("/v1/me/api-keys/", userHandler.APIKeysHandler)
+
+	// @Summary Reset password
+	// @Description Request a password reset email and set a new password with the emailed token.
+	// @Tags Users
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.ResetPasswordRequest  true  "Reset token and new password"
+	// @Success 200 {object} map[string]string
+	// @Failure 400 {object} handler.ErrorResponse
+	// @Router /v1/users/password-reset/confirm [post]
+	http.HandleFunc("/v1/users/password-reset", userHandler.RequestPasswordReset())
+	http.HandleFunc("/v1/users/password-reset/confirm", userHandler.ResetPassword())
+
+	// @Summary Verify email
+	// @Description Verify an email with the emailed token, or resend the verification email.
+	// @Tags Users
+	// @Accept  json
+	// @Produce  json
+	// @Param   request  body      model.VerifyEmailRequest  true  "Verification token"
+	// @Success 200 {object} map[string]string
+	// @Failure 400 {object} handler.ErrorResponse
+	// @Router /v1/users/verify-email [post]
+	http.HandleFunc("/v1/users/verify-email", userHandler.VerifyEmail())
+	http.HandleFunc("/v1/users/verify-email/resend", middleware.JWTAuthMiddleware(userHandler.ResendVerification()).ServeHTTP)
+
 	// @Summary Get user information
 	// @Description Retrieve user information by ID.
 	// @Tags Users
@@ -112,6 +232,16 @@ func main() {
 	// @Router /v1/users [get]
 	http.HandleFunc("/v1/users", userHandler.UserHandler)
 
+	// @Summary Get user profile
+	// @Description Retrieve the public profile of a user by ID or username, or block and unblock the user.
+	// @Tags Users
+	// @Produce  json
+	// @Param   id     path     int     true  "User ID"
+	// @Success 200 {object} userpb.GetProfileResponse
+	// @Failure 404 {object} handler.ErrorResponse
+	// @Router /v1/users/{id} [get]
+	http.HandleFunc("/v1/users/", userHandler.ProfileHandler)
+
 	// @Summary Get news feed
 	// @Description Get the latest posts from user's friends.
 	// @Tags NewsFeed
@@ -132,7 +262,7 @@ func main() {
 	// @Success 201 {object} entity.Post
 	// @Failure 400 {object} handler.ErrorResponse
 	// @Router /v1/posts [post]
-	http.HandleFunc("/v1/posts", middleware.JWTAuthMiddleware(postHandler.CreatePost()).ServeHTTP)
+	http.HandleFunc("/v1/posts", middleware.APIKeyScopeMiddleware(auth.ScopePost, postHandler.CreatePost()).ServeHTTP)
 
 	// @Summary Get post by ID
 	// @Description Retrieve post details by post ID.
@@ -144,6 +274,47 @@ func main() {
 	// @Router /v1/posts/{id} [get]
 	http.HandleFunc("/v1/posts/", postHandler.PostHandler)
 
+	// @Summary Manage bookmarks
+	// @Description Save, unsave and list the current user's bookmarked posts.
+	// @Tags Bookmarks
+	// @Produce  json
+	// @Success 200 {object} postpb.ListSavedPostsResponse
+	// @Failure 404 {object} handler.ErrorResponse
+	// @Router /v1/me/bookmarks [get]
+	http.HandleFunc("/v1/me/bookmarks", postHandler.BookmarksHandler)
+	http.HandleFunc("/v1/me/bookmarks/", postHandler.BookmarksHandler)
+
+	// @Summary Upload profile images
+	// @Description Request a presigned upload URL for an avatar or cover image and confirm the upload.
+	// @Tags Users
+	// @Produce  json
+	// @Param   kind   path      string  true  "avatar or cover"
+	// @Success 200 {object} userpb.ConfirmProfileImageResponse
+	// @Failure 400 {object} handler.ErrorResponse
+	// @Router /v1/me/{kind}/confirm [post]
+	http.HandleFunc("/v1/me/avatar/", userHandler.ProfileImageHandler)
+	http.HandleFunc("/v1/me/cover/", userHandler.ProfileImageHandler)
+
+	// @Summary Search posts
+	// @Description Full-text search over posts.
+	// @Tags Search
+	// @Produce  json
+	// @Param   q   query      string  true  "Search text"
+	// @Success 200 {object} postpb.SearchPostsResponse
+	// @Failure 400 {object} handler.ErrorResponse
+	// @Router /v1/search/posts [get]
+	http.HandleFunc("/v1/search/posts", middleware.APIKeyScopeMiddleware(auth.ScopeRead, postHandler.SearchPosts()).ServeHTTP)
+
+	// @Summary Search users
+	// @Description Prefix search over usernames and names for typeahead.
+	// @Tags Search
+	// @Produce  json
+	// @Param   q   query      string  true  "Name prefix"
+	// @Success 200 {object} userpb.SearchUsersResponse
+	// @Failure 400 {object} handler.ErrorResponse
+	// @Router /v1/search/users [get]
+	http.HandleFunc("/v1/search/users", middleware.APIKeyScopeMiddleware(auth.ScopeRead, userHandler.SearchUsers()).ServeHTTP)
+
 	// @Summary Manage friends
 	// @Description Manage friend relationships.
 	// @Tags Friends
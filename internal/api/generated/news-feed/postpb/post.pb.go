@@ -21,13 +21,115 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Who is allowed to comment on a post
+type CommentPermission int32
+
+const (
+	CommentPermission_COMMENT_PERMISSION_EVERYONE  CommentPermission = 0 // Any user may comment
+	CommentPermission_COMMENT_PERMISSION_FOLLOWERS CommentPermission = 1 // Only users following the post author may comment
+	CommentPermission_COMMENT_PERMISSION_FOLLOWING CommentPermission = 2 // Only users the post author follows may comment
+	CommentPermission_COMMENT_PERMISSION_DISABLED  CommentPermission = 3 // Comments are turned off
+)
+
+// Enum value maps for CommentPermission.
+var (
+	CommentPermission_name = map[int32]string{
+		0: "COMMENT_PERMISSION_EVERYONE",
+		1: "COMMENT_PERMISSION_FOLLOWERS",
+		2: "COMMENT_PERMISSION_FOLLOWING",
+		3: "COMMENT_PERMISSION_DISABLED",
+	}
+	CommentPermission_value = map[string]int32{
+		"COMMENT_PERMISSION_EVERYONE":  0,
+		"COMMENT_PERMISSION_FOLLOWERS": 1,
+		"COMMENT_PERMISSION_FOLLOWING": 2,
+		"COMMENT_PERMISSION_DISABLED":  3,
+	}
+)
+
+func (x CommentPermission) Enum() *CommentPermission {
+	p := new(CommentPermission)
+	*p = x
+	return p
+}
+
+func (x CommentPermission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentPermission) Descriptor() protoreflect.EnumDescriptor {
+	return file_post_proto_enumTypes[0].Descriptor()
+}
+
+func (CommentPermission) Type() protoreflect.EnumType {
+	return &file_post_proto_enumTypes[0]
+}
+
+func (x CommentPermission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentPermission.Descriptor instead.
+func (CommentPermission) EnumDescriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{0}
+}
+
+// Ordering of the comments returned by GetComments
+type CommentSort int32
+
+const (
+	CommentSort_COMMENT_SORT_CHRONOLOGICAL CommentSort = 0 // Oldest first, paginated by comment ID
+	CommentSort_COMMENT_SORT_TOP           CommentSort = 1 // Most liked first, paginated by top_cursor
+)
+
+// Enum value maps for CommentSort.
+var (
+	CommentSort_name = map[int32]string{
+		0: "COMMENT_SORT_CHRONOLOGICAL",
+		1: "COMMENT_SORT_TOP",
+	}
+	CommentSort_value = map[string]int32{
+		"COMMENT_SORT_CHRONOLOGICAL": 0,
+		"COMMENT_SORT_TOP":           1,
+	}
+)
+
+func (x CommentSort) Enum() *CommentSort {
+	p := new(CommentSort)
+	*p = x
+	return p
+}
+
+func (x CommentSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_post_proto_enumTypes[1].Descriptor()
+}
+
+func (CommentSort) Type() protoreflect.EnumType {
+	return &file_post_proto_enumTypes[1]
+}
+
+func (x CommentSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentSort.Descriptor instead.
+func (CommentSort) EnumDescriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{1}
+}
+
 type CreatePostRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Text     string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	HasImage bool   `protobuf:"varint,2,opt,name=hasImage,proto3" json:"hasImage,omitempty"`
+	Text              string            `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	HasImage          bool              `protobuf:"varint,2,opt,name=hasImage,proto3" json:"hasImage,omitempty"`
+	CommentPermission CommentPermission `protobuf:"varint,3,opt,name=comment_permission,json=commentPermission,proto3,enum=postpb.CommentPermission" json:"comment_permission,omitempty"`
+	Poll              *PollInput        `protobuf:"bytes,4,opt,name=poll,proto3" json:"poll,omitempty"` // Optional poll to attach to the post
 }
 
 func (x *CreatePostRequest) Reset() {
@@ -76,6 +178,84 @@ func (x *CreatePostRequest) GetHasImage() bool {
 	return false
 }
 
+func (x *CreatePostRequest) GetCommentPermission() CommentPermission {
+	if x != nil {
+		return x.CommentPermission
+	}
+	return CommentPermission_COMMENT_PERMISSION_EVERYONE
+}
+
+func (x *CreatePostRequest) GetPoll() *PollInput {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+// Poll attached to a new post
+type PollInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options        []string `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`                                      // Option texts, 2 to 4 options
+	ClosesAt       string   `protobuf:"bytes,2,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`                    // RFC3339 closing time, must be in the future
+	MultipleChoice bool     `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"` // Whether voters may pick more than one option
+}
+
+func (x *PollInput) Reset() {
+	*x = PollInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PollInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollInput) ProtoMessage() {}
+
+func (x *PollInput) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollInput.ProtoReflect.Descriptor instead.
+func (*PollInput) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PollInput) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PollInput) GetClosesAt() string {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return ""
+}
+
+func (x *PollInput) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
 type CreatePostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -87,7 +267,7 @@ type CreatePostResponse struct {
 func (x *CreatePostResponse) Reset() {
 	*x = CreatePostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[1]
+		mi := &file_post_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +280,7 @@ func (x *CreatePostResponse) String() string {
 func (*CreatePostResponse) ProtoMessage() {}
 
 func (x *CreatePostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[1]
+	mi := &file_post_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,7 +293,7 @@ func (x *CreatePostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostResponse.ProtoReflect.Descriptor instead.
 func (*CreatePostResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{1}
+	return file_post_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreatePostResponse) GetPreSignedURL() string {
@@ -128,13 +308,14 @@ type GetPostRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32 `protobuf:"varint,1,opt,name=postId,proto3" json:"postId,omitempty"` // Add post ID to request
+	PostId   int32 `protobuf:"varint,1,opt,name=postId,proto3" json:"postId,omitempty"`                     // Add post ID to request
+	ViewerId int32 `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // ID of the user viewing the post, used to reveal poll results
 }
 
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[2]
+		mi := &file_post_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -147,7 +328,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[2]
+	mi := &file_post_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +341,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{2}
+	return file_post_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetPostRequest) GetPostId() int32 {
@@ -170,22 +351,33 @@ func (x *GetPostRequest) GetPostId() int32 {
 	return 0
 }
 
+func (x *GetPostRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type GetPostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                            // Post ID
-	UserId           int32  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`                    // User ID who created the post
-	ContentText      string `protobuf:"bytes,3,opt,name=contentText,proto3" json:"contentText,omitempty"`           // Post content text
-	ContentImagePath string `protobuf:"bytes,4,opt,name=contentImagePath,proto3" json:"contentImagePath,omitempty"` // URL or path to the image
-	CreatedAt        string `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`               // Created at timestamp as string
+	Id                int32             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                      // Post ID
+	UserId            int32             `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`                                                                              // User ID who created the post
+	ContentText       string            `protobuf:"bytes,3,opt,name=contentText,proto3" json:"contentText,omitempty"`                                                                     // Post content text
+	ContentImagePath  string            `protobuf:"bytes,4,opt,name=contentImagePath,proto3" json:"contentImagePath,omitempty"`                                                           // URL or path to the image
+	CreatedAt         string            `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`                                                                         // Created at timestamp as string
+	CommentPermission CommentPermission `protobuf:"varint,6,opt,name=comment_permission,json=commentPermission,proto3,enum=postpb.CommentPermission" json:"comment_permission,omitempty"` // Who is allowed to comment on the post
+	Poll              *Poll             `protobuf:"bytes,7,opt,name=poll,proto3" json:"poll,omitempty"`                                                                                   // Poll attached to the post, unset when the post has none
+	LinkPreview       *LinkPreview      `protobuf:"bytes,8,opt,name=link_preview,json=linkPreview,proto3" json:"link_preview,omitempty"`                                                  // Preview of the first link in the post, unset until fetched
+	Author            *User             `protobuf:"bytes,9,opt,name=author,proto3" json:"author,omitempty"`                                                                               // Summary of the user who created the post
 }
 
 func (x *GetPostResponse) Reset() {
 	*x = GetPostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[3]
+		mi := &file_post_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -198,7 +390,7 @@ func (x *GetPostResponse) String() string {
 func (*GetPostResponse) ProtoMessage() {}
 
 func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[3]
+	mi := &file_post_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +403,7 @@ func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostResponse.ProtoReflect.Descriptor instead.
 func (*GetPostResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{3}
+	return file_post_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetPostResponse) GetId() int32 {
@@ -249,81 +441,48 @@ func (x *GetPostResponse) GetCreatedAt() string {
 	return ""
 }
 
-// Message for the EditPost request
-type EditPostRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	PostId      int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`               // ID of the post to edit
-	ContentText string `protobuf:"bytes,2,opt,name=content_text,json=contentText,proto3" json:"content_text,omitempty"` // New content for the post
-	HasImage    bool   `protobuf:"varint,3,opt,name=has_image,json=hasImage,proto3" json:"has_image,omitempty"`         // Indicates if the post has an image
-}
-
-func (x *EditPostRequest) Reset() {
-	*x = EditPostRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *EditPostRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*EditPostRequest) ProtoMessage() {}
-
-func (x *EditPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetPostResponse) GetCommentPermission() CommentPermission {
+	if x != nil {
+		return x.CommentPermission
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use EditPostRequest.ProtoReflect.Descriptor instead.
-func (*EditPostRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{4}
+	return CommentPermission_COMMENT_PERMISSION_EVERYONE
 }
 
-func (x *EditPostRequest) GetPostId() int32 {
+func (x *GetPostResponse) GetPoll() *Poll {
 	if x != nil {
-		return x.PostId
+		return x.Poll
 	}
-	return 0
+	return nil
 }
 
-func (x *EditPostRequest) GetContentText() string {
+func (x *GetPostResponse) GetLinkPreview() *LinkPreview {
 	if x != nil {
-		return x.ContentText
+		return x.LinkPreview
 	}
-	return ""
+	return nil
 }
 
-func (x *EditPostRequest) GetHasImage() bool {
+func (x *GetPostResponse) GetAuthor() *User {
 	if x != nil {
-		return x.HasImage
+		return x.Author
 	}
-	return false
+	return nil
 }
 
-// Message for the EditPost response
-type EditPostResponse struct {
+// Message definition for LinkPreview
+type LinkPreview struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PreSignedUrl string `protobuf:"bytes,1,opt,name=pre_signed_url,json=preSignedUrl,proto3" json:"pre_signed_url,omitempty"` // Pre-signed URL for the image (if any)
+	Url         string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                           // URL the preview was fetched from
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                       // OpenGraph title
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`           // OpenGraph description
+	ImageUrl    string `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"` // OpenGraph image
 }
 
-func (x *EditPostResponse) Reset() {
-	*x = EditPostResponse{}
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -331,13 +490,13 @@ func (x *EditPostResponse) Reset() {
 	}
 }
 
-func (x *EditPostResponse) String() string {
+func (x *LinkPreview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditPostResponse) ProtoMessage() {}
+func (*LinkPreview) ProtoMessage() {}
 
-func (x *EditPostResponse) ProtoReflect() protoreflect.Message {
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -349,30 +508,53 @@ func (x *EditPostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditPostResponse.ProtoReflect.Descriptor instead.
-func (*EditPostResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *EditPostResponse) GetPreSignedUrl() string {
+func (x *LinkPreview) GetUrl() string {
 	if x != nil {
-		return x.PreSignedUrl
+		return x.Url
 	}
 	return ""
 }
 
-// Message for the DeletePost request
-type DeletePostRequest struct {
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+// Message definition for a poll option
+type PollOption struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to delete
-	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user requesting the deletion
+	Id        int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                // ID of the option
+	Text      string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                             // Option text
+	VoteCount int32  `protobuf:"varint,3,opt,name=vote_count,json=voteCount,proto3" json:"vote_count,omitempty"` // Number of votes, 0 while results are hidden
+	Voted     bool   `protobuf:"varint,4,opt,name=voted,proto3" json:"voted,omitempty"`                          // Whether the viewer voted for the option
 }
 
-func (x *DeletePostRequest) Reset() {
-	*x = DeletePostRequest{}
+func (x *PollOption) Reset() {
+	*x = PollOption{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -380,13 +562,13 @@ func (x *DeletePostRequest) Reset() {
 	}
 }
 
-func (x *DeletePostRequest) String() string {
+func (x *PollOption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePostRequest) ProtoMessage() {}
+func (*PollOption) ProtoMessage() {}
 
-func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+func (x *PollOption) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,36 +580,55 @@ func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
-func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DeletePostRequest) GetPostId() int32 {
+func (x *PollOption) GetId() int32 {
 	if x != nil {
-		return x.PostId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *DeletePostRequest) GetUserId() int32 {
+func (x *PollOption) GetText() string {
 	if x != nil {
-		return x.UserId
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PollOption) GetVoteCount() int32 {
+	if x != nil {
+		return x.VoteCount
 	}
 	return 0
 }
 
-// Message for the DeletePost response
-type DeletePostResponse struct {
+func (x *PollOption) GetVoted() bool {
+	if x != nil {
+		return x.Voted
+	}
+	return false
+}
+
+// Message definition for Poll
+type Poll struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"` // Confirmation message for deletion
+	Id             int32         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                               // ID of the poll
+	Options        []*PollOption `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`                                      // Options in their original order
+	ClosesAt       string        `protobuf:"bytes,3,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`                    // RFC3339 closing time
+	MultipleChoice bool          `protobuf:"varint,4,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"` // Whether voters may pick more than one option
+	Closed         bool          `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`                                       // Whether the poll no longer accepts votes
+	ResultsVisible bool          `protobuf:"varint,6,opt,name=results_visible,json=resultsVisible,proto3" json:"results_visible,omitempty"` // Whether tallies are shown, after voting or once closed
 }
 
-func (x *DeletePostResponse) Reset() {
-	*x = DeletePostResponse{}
+func (x *Poll) Reset() {
+	*x = Poll{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -435,13 +636,13 @@ func (x *DeletePostResponse) Reset() {
 	}
 }
 
-func (x *DeletePostResponse) String() string {
+func (x *Poll) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePostResponse) ProtoMessage() {}
+func (*Poll) ProtoMessage() {}
 
-func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
+func (x *Poll) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -453,44 +654,81 @@ func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePostResponse.ProtoReflect.Descriptor instead.
-func (*DeletePostResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeletePostResponse) GetMsg() string {
+func (x *Poll) GetId() int32 {
 	if x != nil {
-		return x.Msg
+		return x.Id
 	}
-	return ""
-}
-
-type CommentOnPostRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	PostId int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to comment on
-	UserId int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user commenting
-	Text   string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                    // The comment text
+	return 0
 }
 
-func (x *CommentOnPostRequest) Reset() {
-	*x = CommentOnPostRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
 	}
+	return nil
 }
 
-func (x *CommentOnPostRequest) String() string {
+func (x *Poll) GetClosesAt() string {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return ""
+}
+
+func (x *Poll) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetResultsVisible() bool {
+	if x != nil {
+		return x.ResultsVisible
+	}
+	return false
+}
+
+// Message for the EditPost request
+type EditPostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId            int32              `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                                                                      // ID of the post to edit
+	ContentText       string             `protobuf:"bytes,2,opt,name=content_text,json=contentText,proto3" json:"content_text,omitempty"`                                                        // New content for the post
+	HasImage          bool               `protobuf:"varint,3,opt,name=has_image,json=hasImage,proto3" json:"has_image,omitempty"`                                                                // Indicates if the post has an image
+	CommentPermission *CommentPermission `protobuf:"varint,4,opt,name=comment_permission,json=commentPermission,proto3,enum=postpb.CommentPermission,oneof" json:"comment_permission,omitempty"` // New comment permission, unchanged when not set
+}
+
+func (x *EditPostRequest) Reset() {
+	*x = EditPostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditPostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommentOnPostRequest) ProtoMessage() {}
+func (*EditPostRequest) ProtoMessage() {}
 
-func (x *CommentOnPostRequest) ProtoReflect() protoreflect.Message {
+func (x *EditPostRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -502,46 +740,50 @@ func (x *CommentOnPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommentOnPostRequest.ProtoReflect.Descriptor instead.
-func (*CommentOnPostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditPostRequest.ProtoReflect.Descriptor instead.
+func (*EditPostRequest) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CommentOnPostRequest) GetPostId() int32 {
+func (x *EditPostRequest) GetPostId() int32 {
 	if x != nil {
 		return x.PostId
 	}
 	return 0
 }
 
-func (x *CommentOnPostRequest) GetUserId() int32 {
+func (x *EditPostRequest) GetContentText() string {
 	if x != nil {
-		return x.UserId
+		return x.ContentText
 	}
-	return 0
+	return ""
 }
 
-func (x *CommentOnPostRequest) GetText() string {
+func (x *EditPostRequest) GetHasImage() bool {
 	if x != nil {
-		return x.Text
+		return x.HasImage
 	}
-	return ""
+	return false
 }
 
-// Message for the CommentOnPost response
-type CommentOnPostResponse struct {
+func (x *EditPostRequest) GetCommentPermission() CommentPermission {
+	if x != nil && x.CommentPermission != nil {
+		return *x.CommentPermission
+	}
+	return CommentPermission_COMMENT_PERMISSION_EVERYONE
+}
+
+// Message for the EditPost response
+type EditPostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CommentId int32  `protobuf:"varint,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // ID of the created comment
-	Text      string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                             // The comment text
-	UserId    int32  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // ID of the user who made the comment
-	CreatedAt string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`  // Timestamp of when the comment was created
+	PreSignedUrl string `protobuf:"bytes,1,opt,name=pre_signed_url,json=preSignedUrl,proto3" json:"pre_signed_url,omitempty"` // Pre-signed URL for the image (if any)
 }
 
-func (x *CommentOnPostResponse) Reset() {
-	*x = CommentOnPostResponse{}
+func (x *EditPostResponse) Reset() {
+	*x = EditPostResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -549,13 +791,13 @@ func (x *CommentOnPostResponse) Reset() {
 	}
 }
 
-func (x *CommentOnPostResponse) String() string {
+func (x *EditPostResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommentOnPostResponse) ProtoMessage() {}
+func (*EditPostResponse) ProtoMessage() {}
 
-func (x *CommentOnPostResponse) ProtoReflect() protoreflect.Message {
+func (x *EditPostResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -567,51 +809,30 @@ func (x *CommentOnPostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommentOnPostResponse.ProtoReflect.Descriptor instead.
-func (*CommentOnPostResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditPostResponse.ProtoReflect.Descriptor instead.
+func (*EditPostResponse) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CommentOnPostResponse) GetCommentId() int32 {
-	if x != nil {
-		return x.CommentId
-	}
-	return 0
-}
-
-func (x *CommentOnPostResponse) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
-}
-
-func (x *CommentOnPostResponse) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *CommentOnPostResponse) GetCreatedAt() string {
+func (x *EditPostResponse) GetPreSignedUrl() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.PreSignedUrl
 	}
 	return ""
 }
 
-// Message for the LikePost request
-type LikePostRequest struct {
+// Message for the DeletePost request
+type DeletePostRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to like
-	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user liking the post
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to delete
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user requesting the deletion
 }
 
-func (x *LikePostRequest) Reset() {
-	*x = LikePostRequest{}
+func (x *DeletePostRequest) Reset() {
+	*x = DeletePostRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,13 +840,13 @@ func (x *LikePostRequest) Reset() {
 	}
 }
 
-func (x *LikePostRequest) String() string {
+func (x *DeletePostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikePostRequest) ProtoMessage() {}
+func (*DeletePostRequest) ProtoMessage() {}
 
-func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -637,36 +858,36 @@ func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikePostRequest.ProtoReflect.Descriptor instead.
-func (*LikePostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *LikePostRequest) GetPostId() int32 {
+func (x *DeletePostRequest) GetPostId() int32 {
 	if x != nil {
 		return x.PostId
 	}
 	return 0
 }
 
-func (x *LikePostRequest) GetUserId() int32 {
+func (x *DeletePostRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-// Message for the LikePost response
-type LikePostResponse struct {
+// Message for the DeletePost response
+type DeletePostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"` // Confirmation message for deletion
 }
 
-func (x *LikePostResponse) Reset() {
-	*x = LikePostResponse{}
+func (x *DeletePostResponse) Reset() {
+	*x = DeletePostResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -674,13 +895,13 @@ func (x *LikePostResponse) Reset() {
 	}
 }
 
-func (x *LikePostResponse) String() string {
+func (x *DeletePostResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikePostResponse) ProtoMessage() {}
+func (*DeletePostResponse) ProtoMessage() {}
 
-func (x *LikePostResponse) ProtoReflect() protoreflect.Message {
+func (x *DeletePostResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -692,31 +913,31 @@ func (x *LikePostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikePostResponse.ProtoReflect.Descriptor instead.
-func (*LikePostResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeletePostResponse.ProtoReflect.Descriptor instead.
+func (*DeletePostResponse) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *LikePostResponse) GetMessage() string {
+func (x *DeletePostResponse) GetMsg() string {
 	if x != nil {
-		return x.Message
+		return x.Msg
 	}
 	return ""
 }
 
-// Message for the GetComments request
-type GetCommentsRequest struct {
+type CommentOnPostRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to get comments for
-	Cursor int32 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`               // Cursor for pagination
-	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                 // Limit of comments to retrieve
+	PostId          int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                              // ID of the post to comment on
+	UserId          int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // ID of the user commenting
+	Text            string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                                                 // The comment text
+	ParentCommentId int32  `protobuf:"varint,4,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"` // ID of the comment being replied to (0 for a top-level comment)
 }
 
-func (x *GetCommentsRequest) Reset() {
-	*x = GetCommentsRequest{}
+func (x *CommentOnPostRequest) Reset() {
+	*x = CommentOnPostRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -724,13 +945,13 @@ func (x *GetCommentsRequest) Reset() {
 	}
 }
 
-func (x *GetCommentsRequest) String() string {
+func (x *CommentOnPostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsRequest) ProtoMessage() {}
+func (*CommentOnPostRequest) ProtoMessage() {}
 
-func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+func (x *CommentOnPostRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -742,44 +963,54 @@ func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
-func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommentOnPostRequest.ProtoReflect.Descriptor instead.
+func (*CommentOnPostRequest) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetCommentsRequest) GetPostId() int32 {
+func (x *CommentOnPostRequest) GetPostId() int32 {
 	if x != nil {
 		return x.PostId
 	}
 	return 0
 }
 
-func (x *GetCommentsRequest) GetCursor() int32 {
+func (x *CommentOnPostRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Cursor
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *GetCommentsRequest) GetLimit() int32 {
+func (x *CommentOnPostRequest) GetText() string {
 	if x != nil {
-		return x.Limit
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CommentOnPostRequest) GetParentCommentId() int32 {
+	if x != nil {
+		return x.ParentCommentId
 	}
 	return 0
 }
 
-// Message for the GetComments response
-type GetCommentsResponse struct {
+// Message for the CommentOnPost response
+type CommentOnPostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Comments   []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`                        // List of comments
-	NextCursor int32      `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Next cursor for pagination
+	CommentId       int32  `protobuf:"varint,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`                     // ID of the created comment
+	Text            string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                                 // The comment text
+	UserId          int32  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // ID of the user who made the comment
+	CreatedAt       string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                      // Timestamp of when the comment was created
+	ParentCommentId int32  `protobuf:"varint,5,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"` // ID of the parent comment (0 for a top-level comment)
 }
 
-func (x *GetCommentsResponse) Reset() {
-	*x = GetCommentsResponse{}
+func (x *CommentOnPostResponse) Reset() {
+	*x = CommentOnPostResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -787,13 +1018,13 @@ func (x *GetCommentsResponse) Reset() {
 	}
 }
 
-func (x *GetCommentsResponse) String() string {
+func (x *CommentOnPostResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsResponse) ProtoMessage() {}
+func (*CommentOnPostResponse) ProtoMessage() {}
 
-func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+func (x *CommentOnPostResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -805,39 +1036,60 @@ func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
-func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommentOnPostResponse.ProtoReflect.Descriptor instead.
+func (*CommentOnPostResponse) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetCommentsResponse) GetComments() []*Comment {
+func (x *CommentOnPostResponse) GetCommentId() int32 {
 	if x != nil {
-		return x.Comments
+		return x.CommentId
 	}
-	return nil
+	return 0
 }
 
-func (x *GetCommentsResponse) GetNextCursor() int32 {
+func (x *CommentOnPostResponse) GetText() string {
 	if x != nil {
-		return x.NextCursor
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CommentOnPostResponse) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
 	}
 	return 0
 }
 
-// Message definition for Comment
-type Comment struct {
+func (x *CommentOnPostResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CommentOnPostResponse) GetParentCommentId() int32 {
+	if x != nil {
+		return x.ParentCommentId
+	}
+	return 0
+}
+
+// Message for the EditComment request
+type EditCommentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                               // ID of the comment
-	UserId    int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`         // ID of the user who made the comment
-	Text      string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                            // Comment text
-	CreatedAt string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Creation timestamp
+	PostId    int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`          // ID of the post the comment belongs to
+	CommentId int32  `protobuf:"varint,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // ID of the comment to edit
+	UserId    int32  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // ID of the user requesting the edit
+	Text      string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`                             // The new comment text
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
+func (x *EditCommentRequest) Reset() {
+	*x = EditCommentRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -845,13 +1097,13 @@ func (x *Comment) Reset() {
 	}
 }
 
-func (x *Comment) String() string {
+func (x *EditCommentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*EditCommentRequest) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
+func (x *EditCommentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -863,51 +1115,50 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditCommentRequest.ProtoReflect.Descriptor instead.
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Comment) GetId() int32 {
+func (x *EditCommentRequest) GetPostId() int32 {
 	if x != nil {
-		return x.Id
+		return x.PostId
 	}
 	return 0
 }
 
-func (x *Comment) GetUserId() int32 {
+func (x *EditCommentRequest) GetCommentId() int32 {
 	if x != nil {
-		return x.UserId
+		return x.CommentId
 	}
 	return 0
 }
 
-func (x *Comment) GetText() string {
+func (x *EditCommentRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Text
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *Comment) GetCreatedAt() string {
+func (x *EditCommentRequest) GetText() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Text
 	}
 	return ""
 }
 
-type GetLikesRequest struct {
+// Message for the EditComment response
+type EditCommentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // RFC3339 formatted string
+	Comment *Comment `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"` // The updated comment
 }
 
-func (x *GetLikesRequest) Reset() {
-	*x = GetLikesRequest{}
+func (x *EditCommentResponse) Reset() {
+	*x = EditCommentResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -915,13 +1166,13 @@ func (x *GetLikesRequest) Reset() {
 	}
 }
 
-func (x *GetLikesRequest) String() string {
+func (x *EditCommentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLikesRequest) ProtoMessage() {}
+func (*EditCommentResponse) ProtoMessage() {}
 
-func (x *GetLikesRequest) ProtoReflect() protoreflect.Message {
+func (x *EditCommentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,43 +1184,31 @@ func (x *GetLikesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLikesRequest.ProtoReflect.Descriptor instead.
-func (*GetLikesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EditCommentResponse.ProtoReflect.Descriptor instead.
+func (*EditCommentResponse) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetLikesRequest) GetPostId() int32 {
-	if x != nil {
-		return x.PostId
-	}
-	return 0
-}
-
-func (x *GetLikesRequest) GetLimit() int32 {
+func (x *EditCommentResponse) GetComment() *Comment {
 	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *GetLikesRequest) GetCursor() string {
-	if x != nil {
-		return x.Cursor
+		return x.Comment
 	}
-	return ""
+	return nil
 }
 
-type GetLikesResponse struct {
+// Message for the DeleteComment request
+type DeleteCommentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Users      []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	NextCursor string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Optional, use empty string if nil
+	PostId    int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`          // ID of the post the comment belongs to
+	CommentId int32 `protobuf:"varint,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // ID of the comment to delete
+	UserId    int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // ID of the user requesting the deletion
 }
 
-func (x *GetLikesResponse) Reset() {
-	*x = GetLikesResponse{}
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -977,13 +1216,13 @@ func (x *GetLikesResponse) Reset() {
 	}
 }
 
-func (x *GetLikesResponse) String() string {
+func (x *DeleteCommentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLikesResponse) ProtoMessage() {}
+func (*DeleteCommentRequest) ProtoMessage() {}
 
-func (x *GetLikesResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -995,43 +1234,43 @@ func (x *GetLikesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLikesResponse.ProtoReflect.Descriptor instead.
-func (*GetLikesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetLikesResponse) GetUsers() []*User {
+func (x *DeleteCommentRequest) GetPostId() int32 {
 	if x != nil {
-		return x.Users
+		return x.PostId
 	}
-	return nil
+	return 0
 }
 
-func (x *GetLikesResponse) GetNextCursor() string {
+func (x *DeleteCommentRequest) GetCommentId() int32 {
 	if x != nil {
-		return x.NextCursor
+		return x.CommentId
 	}
-	return ""
+	return 0
 }
 
-// User message definition
-type User struct {
+func (x *DeleteCommentRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Message for the DeleteComment response
+type DeleteCommentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	HashedPassword string                 `protobuf:"bytes,2,opt,name=hashed_password,json=hashedPassword,proto3" json:"hashed_password,omitempty"`
-	Salt           string                 `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
-	FirstName      string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName       string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Birthday       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=birthday,proto3" json:"birthday,omitempty"` // Use Timestamp for time.Time
-	Email          string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
-	Username       string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"` // This matches the column `user_name`
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"` // Confirmation message for deletion
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_post_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1039,13 +1278,13 @@ func (x *User) Reset() {
 	}
 }
 
-func (x *User) String() string {
+func (x *DeleteCommentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*DeleteCommentResponse) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1057,92 +1296,100 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
 	return file_post_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *User) GetId() int32 {
+func (x *DeleteCommentResponse) GetMsg() string {
 	if x != nil {
-		return x.Id
+		return x.Msg
 	}
-	return 0
+	return ""
 }
 
-func (x *User) GetHashedPassword() string {
-	if x != nil {
-		return x.HashedPassword
-	}
-	return ""
+// Message for the LikePost request
+type LikePostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to like
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user liking the post
 }
 
-func (x *User) GetSalt() string {
-	if x != nil {
-		return x.Salt
+func (x *LikePostRequest) Reset() {
+	*x = LikePostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *User) GetFirstName() string {
-	if x != nil {
-		return x.FirstName
-	}
-	return ""
+func (x *LikePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *User) GetLastName() string {
-	if x != nil {
-		return x.LastName
+func (*LikePostRequest) ProtoMessage() {}
+
+func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetBirthday() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Birthday
-	}
-	return nil
+// Deprecated: Use LikePostRequest.ProtoReflect.Descriptor instead.
+func (*LikePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *User) GetEmail() string {
+func (x *LikePostRequest) GetPostId() int32 {
 	if x != nil {
-		return x.Email
+		return x.PostId
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetUsername() string {
+func (x *LikePostRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Username
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-type GetLikesCountRequest struct {
+// Message for the LikePost response
+type LikePostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // The ID of the post
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
 }
 
-func (x *GetLikesCountRequest) Reset() {
-	*x = GetLikesCountRequest{}
+func (x *LikePostResponse) Reset() {
+	*x = LikePostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[18]
+		mi := &file_post_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetLikesCountRequest) String() string {
+func (x *LikePostResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLikesCountRequest) ProtoMessage() {}
+func (*LikePostResponse) ProtoMessage() {}
 
-func (x *GetLikesCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[18]
+func (x *LikePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1153,43 +1400,46 @@ func (x *GetLikesCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLikesCountRequest.ProtoReflect.Descriptor instead.
-func (*GetLikesCountRequest) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use LikePostResponse.ProtoReflect.Descriptor instead.
+func (*LikePostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetLikesCountRequest) GetPostId() int32 {
+func (x *LikePostResponse) GetMessage() string {
 	if x != nil {
-		return x.PostId
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-type GetLikesCountResponse struct {
+// Message for the LikeComment request
+type LikeCommentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LikeCount int32 `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"` // The count of likes for the post
+	PostId    int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`          // ID of the post the comment belongs to
+	CommentId int32 `protobuf:"varint,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // ID of the comment to like
+	UserId    int32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // ID of the user liking the comment
 }
 
-func (x *GetLikesCountResponse) Reset() {
-	*x = GetLikesCountResponse{}
+func (x *LikeCommentRequest) Reset() {
+	*x = LikeCommentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_post_proto_msgTypes[19]
+		mi := &file_post_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetLikesCountResponse) String() string {
+func (x *LikeCommentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLikesCountResponse) ProtoMessage() {}
+func (*LikeCommentRequest) ProtoMessage() {}
 
-func (x *GetLikesCountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_proto_msgTypes[19]
+func (x *LikeCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,265 +1450,2437 @@ func (x *GetLikesCountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLikesCountResponse.ProtoReflect.Descriptor instead.
-func (*GetLikesCountResponse) Descriptor() ([]byte, []int) {
-	return file_post_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use LikeCommentRequest.ProtoReflect.Descriptor instead.
+func (*LikeCommentRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetLikesCountResponse) GetLikeCount() int32 {
+func (x *LikeCommentRequest) GetPostId() int32 {
 	if x != nil {
-		return x.LikeCount
+		return x.PostId
 	}
 	return 0
 }
 
-var File_post_proto protoreflect.FileDescriptor
+func (x *LikeCommentRequest) GetCommentId() int32 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
 
-var file_post_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x6f,
-	0x73, 0x74, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x43, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x38, 0x0a, 0x12, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x55, 0x52, 0x4c, 0x22, 0x28, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0xa5,
-	0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2a, 0x0a, 0x10,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6a, 0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f,
-	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74,
-	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x69, 0x6d, 0x61,
-	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x22, 0x38, 0x0a, 0x10, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x5f, 0x73, 0x69,
-	0x67, 0x6e, 0x65, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x72, 0x6c, 0x22, 0x45, 0x0a, 0x11,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x26, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x5c, 0x0a, 0x14, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x82, 0x01, 0x0a, 0x15, 0x43, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x43,
-	0x0a, 0x0f, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x5b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x63,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72,
-	0x73, 0x6f, 0x72, 0x22, 0x65, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x58, 0x0a, 0x0f, 0x47, 0x65,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
-	0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75,
-	0x72, 0x73, 0x6f, 0x72, 0x22, 0x57, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
-	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xf9, 0x01,
-	0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64,
-	0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73,
-	0x61, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x36, 0x0a, 0x08, 0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x62,
-	0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x14, 0x47, 0x65, 0x74,
-	0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x15, 0x47, 0x65,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x32, 0xf4, 0x04, 0x0a, 0x0b, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
-	0x12, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x6f,
-	0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f,
-	0x73, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x6f, 0x73,
-	0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x12,
-	0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
-	0x62, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
-	0x12, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x6f,
-	0x73, 0x74, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
-	0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e,
-	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73,
-	0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08,
-	0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
-	0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69,
-	0x6b, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x47,
-	0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70,
-	0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6f, 0x73,
-	0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x6e, 0x65, 0x77,
-	0x73, 0x2d, 0x66, 0x65, 0x65, 0x64, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *LikeCommentRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-var (
-	file_post_proto_rawDescOnce sync.Once
-	file_post_proto_rawDescData = file_post_proto_rawDesc
-)
+// Message for the LikeComment response
+type LikeCommentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_post_proto_rawDescGZIP() []byte {
-	file_post_proto_rawDescOnce.Do(func() {
-		file_post_proto_rawDescData = protoimpl.X.CompressGZIP(file_post_proto_rawDescData)
-	})
-	return file_post_proto_rawDescData
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
 }
 
-var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
-var file_post_proto_goTypes = []any{
-	(*CreatePostRequest)(nil),     // 0: postpb.CreatePostRequest
-	(*CreatePostResponse)(nil),    // 1: postpb.CreatePostResponse
-	(*GetPostRequest)(nil),        // 2: postpb.GetPostRequest
-	(*GetPostResponse)(nil),       // 3: postpb.GetPostResponse
-	(*EditPostRequest)(nil),       // 4: postpb.EditPostRequest
-	(*EditPostResponse)(nil),      // 5: postpb.EditPostResponse
-	(*DeletePostRequest)(nil),     // 6: postpb.DeletePostRequest
-	(*DeletePostResponse)(nil),    // 7: postpb.DeletePostResponse
-	(*CommentOnPostRequest)(nil),  // 8: postpb.CommentOnPostRequest
-	(*CommentOnPostResponse)(nil), // 9: postpb.CommentOnPostResponse
-	(*LikePostRequest)(nil),       // 10: postpb.LikePostRequest
-	(*LikePostResponse)(nil),      // 11: postpb.LikePostResponse
-	(*GetCommentsRequest)(nil),    // 12: postpb.GetCommentsRequest
-	(*GetCommentsResponse)(nil),   // 13: postpb.GetCommentsResponse
-	(*Comment)(nil),               // 14: postpb.Comment
-	(*GetLikesRequest)(nil),       // 15: postpb.GetLikesRequest
-	(*GetLikesResponse)(nil),      // 16: postpb.GetLikesResponse
-	(*User)(nil),                  // 17: postpb.User
-	(*GetLikesCountRequest)(nil),  // 18: postpb.GetLikesCountRequest
-	(*GetLikesCountResponse)(nil), // 19: postpb.GetLikesCountResponse
-	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+func (x *LikeCommentResponse) Reset() {
+	*x = LikeCommentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_post_proto_depIdxs = []int32{
-	14, // 0: postpb.GetCommentsResponse.comments:type_name -> postpb.Comment
-	17, // 1: postpb.GetLikesResponse.users:type_name -> postpb.User
-	20, // 2: postpb.User.birthday:type_name -> google.protobuf.Timestamp
-	0,  // 3: postpb.PostService.CreatePost:input_type -> postpb.CreatePostRequest
-	2,  // 4: postpb.PostService.GetPost:input_type -> postpb.GetPostRequest
-	4,  // 5: postpb.PostService.EditPost:input_type -> postpb.EditPostRequest
-	6,  // 6: postpb.PostService.DeletePost:input_type -> postpb.DeletePostRequest
-	8,  // 7: postpb.PostService.CommentOnPost:input_type -> postpb.CommentOnPostRequest
-	10, // 8: postpb.PostService.LikePost:input_type -> postpb.LikePostRequest
-	12, // 9: postpb.PostService.GetComments:input_type -> postpb.GetCommentsRequest
-	15, // 10: postpb.PostService.GetLikes:input_type -> postpb.GetLikesRequest
-	18, // 11: postpb.PostService.GetLikesCount:input_type -> postpb.GetLikesCountRequest
-	1,  // 12: postpb.PostService.CreatePost:output_type -> postpb.CreatePostResponse
-	3,  // 13: postpb.PostService.GetPost:output_type -> postpb.GetPostResponse
-	5,  // 14: postpb.PostService.EditPost:output_type -> postpb.EditPostResponse
-	7,  // 15: postpb.PostService.DeletePost:output_type -> postpb.DeletePostResponse
-	9,  // 16: postpb.PostService.CommentOnPost:output_type -> postpb.CommentOnPostResponse
-	11, // 17: postpb.PostService.LikePost:output_type -> postpb.LikePostResponse
-	13, // 18: postpb.PostService.GetComments:output_type -> postpb.GetCommentsResponse
-	16, // 19: postpb.PostService.GetLikes:output_type -> postpb.GetLikesResponse
-	19, // 20: postpb.PostService.GetLikesCount:output_type -> postpb.GetLikesCountResponse
-	12, // [12:21] is the sub-list for method output_type
-	3,  // [3:12] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+
+func (x *LikeCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_post_proto_init() }
-func file_post_proto_init() {
-	if File_post_proto != nil {
-		return
+func (*LikeCommentResponse) ProtoMessage() {}
+
+func (x *LikeCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_post_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*CreatePostRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeCommentResponse.ProtoReflect.Descriptor instead.
+func (*LikeCommentResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LikeCommentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Message for the GetComments request
+type GetCommentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId    int32       `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`         // ID of the post to get comments for
+	Cursor    int32       `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                       // Cursor for pagination
+	Limit     int32       `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // Limit of comments to retrieve
+	Sort      CommentSort `protobuf:"varint,4,opt,name=sort,proto3,enum=postpb.CommentSort" json:"sort,omitempty"`   // Ordering of the comments
+	TopCursor string      `protobuf:"bytes,5,opt,name=top_cursor,json=topCursor,proto3" json:"top_cursor,omitempty"` // Cursor for pagination when sorting by top
+}
+
+func (x *GetCommentsRequest) Reset() {
+	*x = GetCommentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsRequest) ProtoMessage() {}
+
+func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCommentsRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *GetCommentsRequest) GetCursor() int32 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetCommentsRequest) GetSort() CommentSort {
+	if x != nil {
+		return x.Sort
+	}
+	return CommentSort_COMMENT_SORT_CHRONOLOGICAL
+}
+
+func (x *GetCommentsRequest) GetTopCursor() string {
+	if x != nil {
+		return x.TopCursor
+	}
+	return ""
+}
+
+// Message for the GetComments response
+type GetCommentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Comments      []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`                                  // List of comments
+	NextCursor    int32      `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`           // Next cursor for pagination
+	NextTopCursor string     `protobuf:"bytes,3,opt,name=next_top_cursor,json=nextTopCursor,proto3" json:"next_top_cursor,omitempty"` // Next cursor for pagination when sorting by top
+}
+
+func (x *GetCommentsResponse) Reset() {
+	*x = GetCommentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsResponse) ProtoMessage() {}
+
+func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *GetCommentsResponse) GetNextCursor() int32 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+func (x *GetCommentsResponse) GetNextTopCursor() string {
+	if x != nil {
+		return x.NextTopCursor
+	}
+	return ""
+}
+
+// Message definition for Comment
+type Comment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                    // ID of the comment
+	UserId          int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // ID of the user who made the comment
+	Text            string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                                                 // Comment text
+	CreatedAt       string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                      // Creation timestamp
+	ParentCommentId int32  `protobuf:"varint,5,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"` // ID of the parent comment (0 for a top-level comment)
+	ReplyCount      int32  `protobuf:"varint,6,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`                  // Number of direct replies to the comment
+	LikeCount       int32  `protobuf:"varint,7,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`                     // Number of likes on the comment
+	Author          *User  `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"`                                             // Summary of the user who made the comment
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Comment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Comment) GetParentCommentId() int32 {
+	if x != nil {
+		return x.ParentCommentId
+	}
+	return 0
+}
+
+func (x *Comment) GetReplyCount() int32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *Comment) GetLikeCount() int32 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *Comment) GetAuthor() *User {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+// Message for the GetCommentReplies request
+type GetCommentRepliesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentId int32 `protobuf:"varint,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // ID of the comment to get replies for
+	Cursor    int32 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                        // Cursor for pagination
+	Limit     int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                          // Limit of replies to retrieve
+}
+
+func (x *GetCommentRepliesRequest) Reset() {
+	*x = GetCommentRepliesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCommentRepliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentRepliesRequest) ProtoMessage() {}
+
+func (x *GetCommentRepliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentRepliesRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentRepliesRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetCommentRepliesRequest) GetCommentId() int32 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *GetCommentRepliesRequest) GetCursor() int32 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetCommentRepliesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Message for the GetCommentReplies response
+type GetCommentRepliesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Replies    []*Comment `protobuf:"bytes,1,rep,name=replies,proto3" json:"replies,omitempty"`                          // List of replies
+	NextCursor int32      `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Next cursor for pagination
+}
+
+func (x *GetCommentRepliesResponse) Reset() {
+	*x = GetCommentRepliesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCommentRepliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentRepliesResponse) ProtoMessage() {}
+
+func (x *GetCommentRepliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentRepliesResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentRepliesResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetCommentRepliesResponse) GetReplies() []*Comment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *GetCommentRepliesResponse) GetNextCursor() int32 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+type GetLikesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // RFC3339 formatted string
+}
+
+func (x *GetLikesRequest) Reset() {
+	*x = GetLikesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesRequest) ProtoMessage() {}
+
+func (x *GetLikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesRequest.ProtoReflect.Descriptor instead.
+func (*GetLikesRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetLikesRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *GetLikesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLikesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetLikesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users      []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Optional, use empty string if nil
+}
+
+func (x *GetLikesResponse) Reset() {
+	*x = GetLikesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesResponse) ProtoMessage() {}
+
+func (x *GetLikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesResponse.ProtoReflect.Descriptor instead.
+func (*GetLikesResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetLikesResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetLikesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// User message definition
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	HashedPassword string                 `protobuf:"bytes,2,opt,name=hashed_password,json=hashedPassword,proto3" json:"hashed_password,omitempty"`
+	Salt           string                 `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	FirstName      string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName       string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Birthday       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=birthday,proto3" json:"birthday,omitempty"` // Use Timestamp for time.Time
+	Email          string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Username       string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                    // This matches the column `user_name`
+	AvatarUrl      string                 `protobuf:"bytes,9,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"` // Empty when the user has no avatar
+	CoverUrl       string                 `protobuf:"bytes,10,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`   // Empty when the user has no cover image
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *User) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetHashedPassword() string {
+	if x != nil {
+		return x.HashedPassword
+	}
+	return ""
+}
+
+func (x *User) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetBirthday() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Birthday
+	}
+	return nil
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *User) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+type GetLikesCountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // The ID of the post
+}
+
+func (x *GetLikesCountRequest) Reset() {
+	*x = GetLikesCountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLikesCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesCountRequest) ProtoMessage() {}
+
+func (x *GetLikesCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesCountRequest.ProtoReflect.Descriptor instead.
+func (*GetLikesCountRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetLikesCountRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+type GetLikesCountResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LikeCount int32 `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"` // The count of likes for the post
+}
+
+func (x *GetLikesCountResponse) Reset() {
+	*x = GetLikesCountResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLikesCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesCountResponse) ProtoMessage() {}
+
+func (x *GetLikesCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesCountResponse.ProtoReflect.Descriptor instead.
+func (*GetLikesCountResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetLikesCountResponse) GetLikeCount() int32 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+// Message definition for Post
+type Post struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                int32             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                      // Post ID
+	UserId            int32             `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                                // User ID who created the post
+	ContentText       string            `protobuf:"bytes,3,opt,name=content_text,json=contentText,proto3" json:"content_text,omitempty"`                                                  // Post content text
+	ContentImagePath  string            `protobuf:"bytes,4,opt,name=content_image_path,json=contentImagePath,proto3" json:"content_image_path,omitempty"`                                 // URL or path to the image
+	CreatedAt         string            `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                        // Creation timestamp
+	CommentPermission CommentPermission `protobuf:"varint,6,opt,name=comment_permission,json=commentPermission,proto3,enum=postpb.CommentPermission" json:"comment_permission,omitempty"` // Who is allowed to comment on the post
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Post) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Post) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Post) GetContentText() string {
+	if x != nil {
+		return x.ContentText
+	}
+	return ""
+}
+
+func (x *Post) GetContentImagePath() string {
+	if x != nil {
+		return x.ContentImagePath
+	}
+	return ""
+}
+
+func (x *Post) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Post) GetCommentPermission() CommentPermission {
+	if x != nil {
+		return x.CommentPermission
+	}
+	return CommentPermission_COMMENT_PERMISSION_EVERYONE
+}
+
+// Message for the SavePost request
+type SavePostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId     int32  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to bookmark
+	UserId     int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user saving the post
+	Collection string `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`        // Optional name of the collection to file the bookmark under
+}
+
+func (x *SavePostRequest) Reset() {
+	*x = SavePostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePostRequest) ProtoMessage() {}
+
+func (x *SavePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePostRequest.ProtoReflect.Descriptor instead.
+func (*SavePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SavePostRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *SavePostRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SavePostRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+// Message for the SavePost response
+type SavePostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
+}
+
+func (x *SavePostResponse) Reset() {
+	*x = SavePostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePostResponse) ProtoMessage() {}
+
+func (x *SavePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePostResponse.ProtoReflect.Descriptor instead.
+func (*SavePostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SavePostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Message for the UnsavePost request
+type UnsavePostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to remove from bookmarks
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user removing the bookmark
+}
+
+func (x *UnsavePostRequest) Reset() {
+	*x = UnsavePostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsavePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsavePostRequest) ProtoMessage() {}
+
+func (x *UnsavePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsavePostRequest.ProtoReflect.Descriptor instead.
+func (*UnsavePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UnsavePostRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *UnsavePostRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Message for the UnsavePost response
+type UnsavePostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
+}
+
+func (x *UnsavePostResponse) Reset() {
+	*x = UnsavePostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsavePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsavePostResponse) ProtoMessage() {}
+
+func (x *UnsavePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsavePostResponse.ProtoReflect.Descriptor instead.
+func (*UnsavePostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UnsavePostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Message for the ListSavedPosts request
+type ListSavedPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     int32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user whose bookmarks are listed
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`        // Optional collection to list, all bookmarks when empty
+	Cursor     int32  `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`               // Cursor for pagination, 0 for the newest bookmarks
+	Limit      int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                 // Limit of bookmarks to retrieve
+}
+
+func (x *ListSavedPostsRequest) Reset() {
+	*x = ListSavedPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSavedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedPostsRequest) ProtoMessage() {}
+
+func (x *ListSavedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListSavedPostsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSavedPostsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ListSavedPostsRequest) GetCursor() int32 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListSavedPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Message definition for a bookmarked post
+type SavedPost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookmarkId int32  `protobuf:"varint,1,opt,name=bookmark_id,json=bookmarkId,proto3" json:"bookmark_id,omitempty"` // ID of the bookmark
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`                    // Collection the bookmark is filed under, empty when none
+	SavedAt    string `protobuf:"bytes,3,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"`           // When the post was saved
+	Post       *Post  `protobuf:"bytes,4,opt,name=post,proto3" json:"post,omitempty"`                                // The saved post
+}
+
+func (x *SavedPost) Reset() {
+	*x = SavedPost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedPost) ProtoMessage() {}
+
+func (x *SavedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedPost.ProtoReflect.Descriptor instead.
+func (*SavedPost) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SavedPost) GetBookmarkId() int32 {
+	if x != nil {
+		return x.BookmarkId
+	}
+	return 0
+}
+
+func (x *SavedPost) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *SavedPost) GetSavedAt() string {
+	if x != nil {
+		return x.SavedAt
+	}
+	return ""
+}
+
+func (x *SavedPost) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// Message for the ListSavedPosts response
+type ListSavedPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SavedPosts []*SavedPost `protobuf:"bytes,1,rep,name=saved_posts,json=savedPosts,proto3" json:"saved_posts,omitempty"`  // List of saved posts, newest first
+	NextCursor int32        `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Next cursor for pagination
+}
+
+func (x *ListSavedPostsResponse) Reset() {
+	*x = ListSavedPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSavedPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedPostsResponse) ProtoMessage() {}
+
+func (x *ListSavedPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedPostsResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListSavedPostsResponse) GetSavedPosts() []*SavedPost {
+	if x != nil {
+		return x.SavedPosts
+	}
+	return nil
+}
+
+func (x *ListSavedPostsResponse) GetNextCursor() int32 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+// Message for the PinPost request
+type PinPostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to pin
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user pinning the post, must be the post author
+}
+
+func (x *PinPostRequest) Reset() {
+	*x = PinPostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinPostRequest) ProtoMessage() {}
+
+func (x *PinPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinPostRequest.ProtoReflect.Descriptor instead.
+func (*PinPostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PinPostRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *PinPostRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Message for the PinPost response
+type PinPostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
+}
+
+func (x *PinPostResponse) Reset() {
+	*x = PinPostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinPostResponse) ProtoMessage() {}
+
+func (x *PinPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinPostResponse.ProtoReflect.Descriptor instead.
+func (*PinPostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PinPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Message for the UnpinPost request
+type UnpinPostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId int32 `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // ID of the post to unpin
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user unpinning the post, must be the post author
+}
+
+func (x *UnpinPostRequest) Reset() {
+	*x = UnpinPostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnpinPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinPostRequest) ProtoMessage() {}
+
+func (x *UnpinPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinPostRequest.ProtoReflect.Descriptor instead.
+func (*UnpinPostRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UnpinPostRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *UnpinPostRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Message for the UnpinPost response
+type UnpinPostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Success message
+}
+
+func (x *UnpinPostResponse) Reset() {
+	*x = UnpinPostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnpinPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinPostResponse) ProtoMessage() {}
+
+func (x *UnpinPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinPostResponse.ProtoReflect.Descriptor instead.
+func (*UnpinPostResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UnpinPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Message for the VotePoll request
+type VotePollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId    int32   `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                 // ID of the post the poll is attached to
+	UserId    int32   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                 // ID of the user voting
+	OptionIds []int32 `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"` // Options voted for, exactly one for a single choice poll
+}
+
+func (x *VotePollRequest) Reset() {
+	*x = VotePollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VotePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollRequest) ProtoMessage() {}
+
+func (x *VotePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollRequest.ProtoReflect.Descriptor instead.
+func (*VotePollRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VotePollRequest) GetPostId() int32 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *VotePollRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *VotePollRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+// Message for the VotePoll response
+type VotePollResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Poll *Poll `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"` // The poll with its results
+}
+
+func (x *VotePollResponse) Reset() {
+	*x = VotePollResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VotePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollResponse) ProtoMessage() {}
+
+func (x *VotePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollResponse.ProtoReflect.Descriptor instead.
+func (*VotePollResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *VotePollResponse) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+// Message for the SearchPosts request
+type SearchPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query    string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                        // Search text, "quoted phrases" and prefix* terms are supported
+	AuthorId int32  `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // Only posts by this user when set
+	From     string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                          // Only posts created at or after this RFC3339 time when set
+	To       string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`                              // Only posts created before this RFC3339 time when set
+	Cursor   int32  `protobuf:"varint,5,opt,name=cursor,proto3" json:"cursor,omitempty"`                     // Cursor for pagination, 0 for the newest matches
+	Limit    int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                       // Limit of posts to retrieve
+}
+
+func (x *SearchPostsRequest) Reset() {
+	*x = SearchPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsRequest) ProtoMessage() {}
+
+func (x *SearchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SearchPostsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetAuthorId() int32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *SearchPostsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetCursor() int32 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *SearchPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Message for the SearchPosts response
+type SearchPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Posts      []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`                              // Matching posts, newest first
+	NextCursor int32   `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Next cursor for pagination
+}
+
+func (x *SearchPostsResponse) Reset() {
+	*x = SearchPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_post_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsResponse) ProtoMessage() {}
+
+func (x *SearchPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPostsResponse) Descriptor() ([]byte, []int) {
+	return file_post_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SearchPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *SearchPostsResponse) GetNextCursor() int32 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+var File_post_proto protoreflect.FileDescriptor
+
+var file_post_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x6f,
+	0x73, 0x74, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb4, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x6c,
+	0x6c, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x22, 0x6b, 0x0a, 0x09,
+	0x50, 0x6f, 0x6c, 0x6c, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x41, 0x74,
+	0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x6c, 0x65, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x22, 0x38, 0x0a, 0x12, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x55, 0x52, 0x4c, 0x22, 0x45, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x49, 0x64, 0x22, 0xef, 0x02, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x04,
+	0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73,
+	0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x12, 0x36,
+	0x0a, 0x0c, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x0b, 0x6c, 0x69, 0x6e, 0x6b, 0x50,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x22, 0x74, 0x0a, 0x0b,
+	0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x55,
+	0x72, 0x6c, 0x22, 0x65, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x64, 0x22, 0xcb, 0x01, 0x0a, 0x04, 0x50, 0x6f,
+	0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x6c,
+	0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x41, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65,
+	0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x56, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f,
+	0x73, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x88, 0x01, 0x01, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x10, 0x45, 0x64,
+	0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24,
+	0x0a, 0x0e, 0x70, 0x72, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x55, 0x72, 0x6c, 0x22, 0x45, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x26, 0x0a, 0x12, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6d, 0x73, 0x67, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f,
+	0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70,
+	0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xae,
+	0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22,
+	0x79, 0x0a, 0x12, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x40, 0x0a, 0x13, 0x45, 0x64,
+	0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x67, 0x0a, 0x14,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x22, 0x43, 0x0a, 0x0f, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x12, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69,
+	0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x6f, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x6f,
+	0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65, 0x78,
+	0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x74, 0x6f, 0x70, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22,
+	0xf7, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x6b, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x18, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x67, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x58, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x57, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
+	0x62, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xb5,
+	0x02, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x61, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x36, 0x0a, 0x08, 0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08,
+	0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x76,
+	0x61, 0x74, 0x61, 0x72, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x22, 0x2f, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b,
+	0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x69,
+	0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0xe9, 0x01, 0x0a, 0x04, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x54, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x63, 0x0a, 0x0f, 0x53,
+	0x61, 0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x2c, 0x0a, 0x10, 0x53, 0x61, 0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x45,
+	0x0a, 0x11, 0x55, 0x6e, 0x73, 0x61, 0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x12, 0x55, 0x6e, 0x73, 0x61, 0x76, 0x65, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x7e, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76,
+	0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50,
+	0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61,
+	0x72, 0x6b, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x61, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x61, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x20, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73,
+	0x74, 0x22, 0x6d, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50, 0x6f,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0b, 0x73,
+	0x61, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x0a, 0x73, 0x61, 0x76, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0x42, 0x0a, 0x0e, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x44, 0x0a, 0x10, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x11, 0x55, 0x6e, 0x70, 0x69, 0x6e,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x62, 0x0a, 0x0f, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x09, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x22, 0x34, 0x0a, 0x10, 0x56, 0x6f,
+	0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20,
+	0x0a, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
+	0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x04, 0x70, 0x6f, 0x6c, 0x6c,
+	0x22, 0x99, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1b, 0x0a,
+	0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x5a, 0x0a, 0x13,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65,
+	0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x2a, 0x99, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f,
+	0x0a, 0x1b, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x52, 0x59, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12,
+	0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x52, 0x4d, 0x49,
+	0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x53, 0x10,
+	0x01, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x52,
+	0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x49, 0x4e,
+	0x47, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50,
+	0x45, 0x52, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c,
+	0x45, 0x44, 0x10, 0x03, 0x2a, 0x43, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x43, 0x48, 0x52, 0x4f, 0x4e, 0x4f, 0x4c, 0x4f, 0x47, 0x49, 0x43, 0x41,
+	0x4c, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x54, 0x4f, 0x50, 0x10, 0x01, 0x32, 0x86, 0x0b, 0x0a, 0x0b, 0x50, 0x6f,
+	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x6f, 0x73, 0x74,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x45, 0x64,
+	0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e,
+	0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c,
+	0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x12,
+	0x1c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x4f, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x6e,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b,
+	0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x6f,
+	0x73, 0x74, 0x70, 0x62, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x17,
+	0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x46, 0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x6f,
+	0x73, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x6f, 0x73, 0x74,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x53, 0x61, 0x76, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x55, 0x6e, 0x73, 0x61, 0x76,
+	0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x55,
+	0x6e, 0x73, 0x61, 0x76, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x73, 0x61, 0x76, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1d,
+	0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a,
+	0x07, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70,
+	0x62, 0x2e, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x55, 0x6e, 0x70,
+	0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e,
+	0x55, 0x6e, 0x70, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x56,
+	0x6f, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x17, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x6f, 0x73, 0x74,
+	0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x6e, 0x65, 0x77, 0x73, 0x2d, 0x66, 0x65, 0x65, 0x64, 0x2f,
+	0x70, 0x6f, 0x73, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_post_proto_rawDescOnce sync.Once
+	file_post_proto_rawDescData = file_post_proto_rawDesc
+)
+
+func file_post_proto_rawDescGZIP() []byte {
+	file_post_proto_rawDescOnce.Do(func() {
+		file_post_proto_rawDescData = protoimpl.X.CompressGZIP(file_post_proto_rawDescData)
+	})
+	return file_post_proto_rawDescData
+}
+
+var file_post_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_post_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_post_proto_goTypes = []any{
+	(CommentPermission)(0),            // 0: postpb.CommentPermission
+	(CommentSort)(0),                  // 1: postpb.CommentSort
+	(*CreatePostRequest)(nil),         // 2: postpb.CreatePostRequest
+	(*PollInput)(nil),                 // 3: postpb.PollInput
+	(*CreatePostResponse)(nil),        // 4: postpb.CreatePostResponse
+	(*GetPostRequest)(nil),            // 5: postpb.GetPostRequest
+	(*GetPostResponse)(nil),           // 6: postpb.GetPostResponse
+	(*LinkPreview)(nil),               // 7: postpb.LinkPreview
+	(*PollOption)(nil),                // 8: postpb.PollOption
+	(*Poll)(nil),                      // 9: postpb.Poll
+	(*EditPostRequest)(nil),           // 10: postpb.EditPostRequest
+	(*EditPostResponse)(nil),          // 11: postpb.EditPostResponse
+	(*DeletePostRequest)(nil),         // 12: postpb.DeletePostRequest
+	(*DeletePostResponse)(nil),        // 13: postpb.DeletePostResponse
+	(*CommentOnPostRequest)(nil),      // 14: postpb.CommentOnPostRequest
+	(*CommentOnPostResponse)(nil),     // 15: postpb.CommentOnPostResponse
+	(*EditCommentRequest)(nil),        // 16: postpb.EditCommentRequest
+	(*EditCommentResponse)(nil),       // 17: postpb.EditCommentResponse
+	(*DeleteCommentRequest)(nil),      // 18: postpb.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),     // 19: postpb.DeleteCommentResponse
+	(*LikePostRequest)(nil),           // 20: postpb.LikePostRequest
+	(*LikePostResponse)(nil),          // 21: postpb.LikePostResponse
+	(*LikeCommentRequest)(nil),        // 22: postpb.LikeCommentRequest
+	(*LikeCommentResponse)(nil),       // 23: postpb.LikeCommentResponse
+	(*GetCommentsRequest)(nil),        // 24: postpb.GetCommentsRequest
+	(*GetCommentsResponse)(nil),       // 25: postpb.GetCommentsResponse
+	(*Comment)(nil),                   // 26: postpb.Comment
+	(*GetCommentRepliesRequest)(nil),  // 27: postpb.GetCommentRepliesRequest
+	(*GetCommentRepliesResponse)(nil), // 28: postpb.GetCommentRepliesResponse
+	(*GetLikesRequest)(nil),           // 29: postpb.GetLikesRequest
+	(*GetLikesResponse)(nil),          // 30: postpb.GetLikesResponse
+	(*User)(nil),                      // 31: postpb.User
+	(*GetLikesCountRequest)(nil),      // 32: postpb.GetLikesCountRequest
+	(*GetLikesCountResponse)(nil),     // 33: postpb.GetLikesCountResponse
+	(*Post)(nil),                      // 34: postpb.Post
+	(*SavePostRequest)(nil),           // 35: postpb.SavePostRequest
+	(*SavePostResponse)(nil),          // 36: postpb.SavePostResponse
+	(*UnsavePostRequest)(nil),         // 37: postpb.UnsavePostRequest
+	(*UnsavePostResponse)(nil),        // 38: postpb.UnsavePostResponse
+	(*ListSavedPostsRequest)(nil),     // 39: postpb.ListSavedPostsRequest
+	(*SavedPost)(nil),                 // 40: postpb.SavedPost
+	(*ListSavedPostsResponse)(nil),    // 41: postpb.ListSavedPostsResponse
+	(*PinPostRequest)(nil),            // 42: postpb.PinPostRequest
+	(*PinPostResponse)(nil),           // 43: postpb.PinPostResponse
+	(*UnpinPostRequest)(nil),          // 44: postpb.UnpinPostRequest
+	(*UnpinPostResponse)(nil),         // 45: postpb.UnpinPostResponse
+	(*VotePollRequest)(nil),           // 46: postpb.VotePollRequest
+	(*VotePollResponse)(nil),          // 47: postpb.VotePollResponse
+	(*SearchPostsRequest)(nil),        // 48: postpb.SearchPostsRequest
+	(*SearchPostsResponse)(nil),       // 49: postpb.SearchPostsResponse
+	(*timestamppb.Timestamp)(nil),     // 50: google.protobuf.Timestamp
+}
+var file_post_proto_depIdxs = []int32{
+	0,  // 0: postpb.CreatePostRequest.comment_permission:type_name -> postpb.CommentPermission
+	3,  // 1: postpb.CreatePostRequest.poll:type_name -> postpb.PollInput
+	0,  // 2: postpb.GetPostResponse.comment_permission:type_name -> postpb.CommentPermission
+	9,  // 3: postpb.GetPostResponse.poll:type_name -> postpb.Poll
+	7,  // 4: postpb.GetPostResponse.link_preview:type_name -> postpb.LinkPreview
+	31, // 5: postpb.GetPostResponse.author:type_name -> postpb.User
+	8,  // 6: postpb.Poll.options:type_name -> postpb.PollOption
+	0,  // 7: postpb.EditPostRequest.comment_permission:type_name -> postpb.CommentPermission
+	26, // 8: postpb.EditCommentResponse.comment:type_name -> postpb.Comment
+	1,  // 9: postpb.GetCommentsRequest.sort:type_name -> postpb.CommentSort
+	26, // 10: postpb.GetCommentsResponse.comments:type_name -> postpb.Comment
+	31, // 11: postpb.Comment.author:type_name -> postpb.User
+	26, // 12: postpb.GetCommentRepliesResponse.replies:type_name -> postpb.Comment
+	31, // 13: postpb.GetLikesResponse.users:type_name -> postpb.User
+	50, // 14: postpb.User.birthday:type_name -> google.protobuf.Timestamp
+	0,  // 15: postpb.Post.comment_permission:type_name -> postpb.CommentPermission
+	34, // 16: postpb.SavedPost.post:type_name -> postpb.Post
+	40, // 17: postpb.ListSavedPostsResponse.saved_posts:type_name -> postpb.SavedPost
+	9,  // 18: postpb.VotePollResponse.poll:type_name -> postpb.Poll
+	34, // 19: postpb.SearchPostsResponse.posts:type_name -> postpb.Post
+	2,  // 20: postpb.PostService.CreatePost:input_type -> postpb.CreatePostRequest
+	5,  // 21: postpb.PostService.GetPost:input_type -> postpb.GetPostRequest
+	10, // 22: postpb.PostService.EditPost:input_type -> postpb.EditPostRequest
+	12, // 23: postpb.PostService.DeletePost:input_type -> postpb.DeletePostRequest
+	14, // 24: postpb.PostService.CommentOnPost:input_type -> postpb.CommentOnPostRequest
+	16, // 25: postpb.PostService.EditComment:input_type -> postpb.EditCommentRequest
+	18, // 26: postpb.PostService.DeleteComment:input_type -> postpb.DeleteCommentRequest
+	20, // 27: postpb.PostService.LikePost:input_type -> postpb.LikePostRequest
+	22, // 28: postpb.PostService.LikeComment:input_type -> postpb.LikeCommentRequest
+	24, // 29: postpb.PostService.GetComments:input_type -> postpb.GetCommentsRequest
+	27, // 30: postpb.PostService.GetCommentReplies:input_type -> postpb.GetCommentRepliesRequest
+	29, // 31: postpb.PostService.GetLikes:input_type -> postpb.GetLikesRequest
+	32, // 32: postpb.PostService.GetLikesCount:input_type -> postpb.GetLikesCountRequest
+	35, // 33: postpb.PostService.SavePost:input_type -> postpb.SavePostRequest
+	37, // 34: postpb.PostService.UnsavePost:input_type -> postpb.UnsavePostRequest
+	39, // 35: postpb.PostService.ListSavedPosts:input_type -> postpb.ListSavedPostsRequest
+	42, // 36: postpb.PostService.PinPost:input_type -> postpb.PinPostRequest
+	44, // 37: postpb.PostService.UnpinPost:input_type -> postpb.UnpinPostRequest
+	46, // 38: postpb.PostService.VotePoll:input_type -> postpb.VotePollRequest
+	48, // 39: postpb.PostService.SearchPosts:input_type -> postpb.SearchPostsRequest
+	4,  // 40: postpb.PostService.CreatePost:output_type -> postpb.CreatePostResponse
+	6,  // 41: postpb.PostService.GetPost:output_type -> postpb.GetPostResponse
+	11, // 42: postpb.PostService.EditPost:output_type -> postpb.EditPostResponse
+	13, // 43: postpb.PostService.DeletePost:output_type -> postpb.DeletePostResponse
+	15, // 44: postpb.PostService.CommentOnPost:output_type -> postpb.CommentOnPostResponse
+	17, // 45: postpb.PostService.EditComment:output_type -> postpb.EditCommentResponse
+	19, // 46: postpb.PostService.DeleteComment:output_type -> postpb.DeleteCommentResponse
+	21, // 47: postpb.PostService.LikePost:output_type -> postpb.LikePostResponse
+	23, // 48: postpb.PostService.LikeComment:output_type -> postpb.LikeCommentResponse
+	25, // 49: postpb.PostService.GetComments:output_type -> postpb.GetCommentsResponse
+	28, // 50: postpb.PostService.GetCommentReplies:output_type -> postpb.GetCommentRepliesResponse
+	30, // 51: postpb.PostService.GetLikes:output_type -> postpb.GetLikesResponse
+	33, // 52: postpb.PostService.GetLikesCount:output_type -> postpb.GetLikesCountResponse
+	36, // 53: postpb.PostService.SavePost:output_type -> postpb.SavePostResponse
+	38, // 54: postpb.PostService.UnsavePost:output_type -> postpb.UnsavePostResponse
+	41, // 55: postpb.PostService.ListSavedPosts:output_type -> postpb.ListSavedPostsResponse
+	43, // 56: postpb.PostService.PinPost:output_type -> postpb.PinPostResponse
+	45, // 57: postpb.PostService.UnpinPost:output_type -> postpb.UnpinPostResponse
+	47, // 58: postpb.PostService.VotePoll:output_type -> postpb.VotePollResponse
+	49, // 59: postpb.PostService.SearchPosts:output_type -> postpb.SearchPostsResponse
+	40, // [40:60] is the sub-list for method output_type
+	20, // [20:40] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_post_proto_init() }
+func file_post_proto_init() {
+	if File_post_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_post_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*PollInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*LinkPreview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*PollOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Poll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*CreatePostResponse); i {
+		file_post_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*EditPostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1469,8 +3891,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*GetPostRequest); i {
+		file_post_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*EditPostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1481,8 +3903,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*GetPostResponse); i {
+		file_post_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1493,8 +3915,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*EditPostRequest); i {
+		file_post_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1505,8 +3927,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*EditPostResponse); i {
+		file_post_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*CommentOnPostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1517,8 +3939,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*DeletePostRequest); i {
+		file_post_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*CommentOnPostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1529,8 +3951,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*DeletePostResponse); i {
+		file_post_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*EditCommentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1541,8 +3963,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*CommentOnPostRequest); i {
+		file_post_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*EditCommentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1553,8 +3975,8 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*CommentOnPostResponse); i {
+		file_post_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteCommentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1565,7 +3987,19 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[10].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteCommentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[18].Exporter = func(v any, i int) any {
 			switch v := v.(*LikePostRequest); i {
 			case 0:
 				return &v.state
@@ -1577,7 +4011,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[11].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[19].Exporter = func(v any, i int) any {
 			switch v := v.(*LikePostResponse); i {
 			case 0:
 				return &v.state
@@ -1589,7 +4023,31 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[12].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*LikeCommentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*LikeCommentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[22].Exporter = func(v any, i int) any {
 			switch v := v.(*GetCommentsRequest); i {
 			case 0:
 				return &v.state
@@ -1601,7 +4059,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[13].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[23].Exporter = func(v any, i int) any {
 			switch v := v.(*GetCommentsResponse); i {
 			case 0:
 				return &v.state
@@ -1613,7 +4071,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[14].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*Comment); i {
 			case 0:
 				return &v.state
@@ -1625,7 +4083,31 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[15].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCommentRepliesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCommentRepliesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[27].Exporter = func(v any, i int) any {
 			switch v := v.(*GetLikesRequest); i {
 			case 0:
 				return &v.state
@@ -1637,7 +4119,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[16].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[28].Exporter = func(v any, i int) any {
 			switch v := v.(*GetLikesResponse); i {
 			case 0:
 				return &v.state
@@ -1649,7 +4131,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[17].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[29].Exporter = func(v any, i int) any {
 			switch v := v.(*User); i {
 			case 0:
 				return &v.state
@@ -1661,7 +4143,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[18].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[30].Exporter = func(v any, i int) any {
 			switch v := v.(*GetLikesCountRequest); i {
 			case 0:
 				return &v.state
@@ -1673,7 +4155,7 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
-		file_post_proto_msgTypes[19].Exporter = func(v any, i int) any {
+		file_post_proto_msgTypes[31].Exporter = func(v any, i int) any {
 			switch v := v.(*GetLikesCountResponse); i {
 			case 0:
 				return &v.state
@@ -1685,19 +4167,213 @@ func file_post_proto_init() {
 				return nil
 			}
 		}
+		file_post_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*Post); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*SavePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*SavePostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsavePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*UnsavePostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSavedPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*SavedPost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSavedPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*PinPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*PinPostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*UnpinPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*UnpinPostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*VotePollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*VotePollResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_post_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_post_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_post_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      2,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_post_proto_goTypes,
 		DependencyIndexes: file_post_proto_depIdxs,
+		EnumInfos:         file_post_proto_enumTypes,
 		MessageInfos:      file_post_proto_msgTypes,
 	}.Build()
 	File_post_proto = out.File
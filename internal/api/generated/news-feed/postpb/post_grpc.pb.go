@@ -19,15 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PostService_CreatePost_FullMethodName    = "/postpb.PostService/CreatePost"
-	PostService_GetPost_FullMethodName       = "/postpb.PostService/GetPost"
-	PostService_EditPost_FullMethodName      = "/postpb.PostService/EditPost"
-	PostService_DeletePost_FullMethodName    = "/postpb.PostService/DeletePost"
-	PostService_CommentOnPost_FullMethodName = "/postpb.PostService/CommentOnPost"
-	PostService_LikePost_FullMethodName      = "/postpb.PostService/LikePost"
-	PostService_GetComments_FullMethodName   = "/postpb.PostService/GetComments"
-	PostService_GetLikes_FullMethodName      = "/postpb.PostService/GetLikes"
-	PostService_GetLikesCount_FullMethodName = "/postpb.PostService/GetLikesCount"
+	PostService_CreatePost_FullMethodName        = "/postpb.PostService/CreatePost"
+	PostService_GetPost_FullMethodName           = "/postpb.PostService/GetPost"
+	PostService_EditPost_FullMethodName          = "/postpb.PostService/EditPost"
+	PostService_DeletePost_FullMethodName        = "/postpb.PostService/DeletePost"
+	PostService_CommentOnPost_FullMethodName     = "/postpb.PostService/CommentOnPost"
+	PostService_EditComment_FullMethodName       = "/postpb.PostService/EditComment"
+	PostService_DeleteComment_FullMethodName     = "/postpb.PostService/DeleteComment"
+	PostService_LikePost_FullMethodName          = "/postpb.PostService/LikePost"
+	PostService_LikeComment_FullMethodName       = "/postpb.PostService/LikeComment"
+	PostService_GetComments_FullMethodName       = "/postpb.PostService/GetComments"
+	PostService_GetCommentReplies_FullMethodName = "/postpb.PostService/GetCommentReplies"
+	PostService_GetLikes_FullMethodName          = "/postpb.PostService/GetLikes"
+	PostService_GetLikesCount_FullMethodName     = "/postpb.PostService/GetLikesCount"
+	PostService_SavePost_FullMethodName          = "/postpb.PostService/SavePost"
+	PostService_UnsavePost_FullMethodName        = "/postpb.PostService/UnsavePost"
+	PostService_ListSavedPosts_FullMethodName    = "/postpb.PostService/ListSavedPosts"
+	PostService_PinPost_FullMethodName           = "/postpb.PostService/PinPost"
+	PostService_UnpinPost_FullMethodName         = "/postpb.PostService/UnpinPost"
+	PostService_VotePoll_FullMethodName          = "/postpb.PostService/VotePoll"
+	PostService_SearchPosts_FullMethodName       = "/postpb.PostService/SearchPosts"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -39,10 +50,21 @@ type PostServiceClient interface {
 	EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*EditPostResponse, error)
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
 	CommentOnPost(ctx context.Context, in *CommentOnPostRequest, opts ...grpc.CallOption) (*CommentOnPostResponse, error)
+	EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
 	LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*LikePostResponse, error)
+	LikeComment(ctx context.Context, in *LikeCommentRequest, opts ...grpc.CallOption) (*LikeCommentResponse, error)
 	GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error)
+	GetCommentReplies(ctx context.Context, in *GetCommentRepliesRequest, opts ...grpc.CallOption) (*GetCommentRepliesResponse, error)
 	GetLikes(ctx context.Context, in *GetLikesRequest, opts ...grpc.CallOption) (*GetLikesResponse, error)
 	GetLikesCount(ctx context.Context, in *GetLikesCountRequest, opts ...grpc.CallOption) (*GetLikesCountResponse, error)
+	SavePost(ctx context.Context, in *SavePostRequest, opts ...grpc.CallOption) (*SavePostResponse, error)
+	UnsavePost(ctx context.Context, in *UnsavePostRequest, opts ...grpc.CallOption) (*UnsavePostResponse, error)
+	ListSavedPosts(ctx context.Context, in *ListSavedPostsRequest, opts ...grpc.CallOption) (*ListSavedPostsResponse, error)
+	PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*PinPostResponse, error)
+	UnpinPost(ctx context.Context, in *UnpinPostRequest, opts ...grpc.CallOption) (*UnpinPostResponse, error)
+	VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error)
+	SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error)
 }
 
 type postServiceClient struct {
@@ -103,6 +125,26 @@ func (c *postServiceClient) CommentOnPost(ctx context.Context, in *CommentOnPost
 	return out, nil
 }
 
+func (c *postServiceClient) EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditCommentResponse)
+	err := c.cc.Invoke(ctx, PostService_EditComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, PostService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*LikePostResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LikePostResponse)
@@ -113,6 +155,16 @@ func (c *postServiceClient) LikePost(ctx context.Context, in *LikePostRequest, o
 	return out, nil
 }
 
+func (c *postServiceClient) LikeComment(ctx context.Context, in *LikeCommentRequest, opts ...grpc.CallOption) (*LikeCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikeCommentResponse)
+	err := c.cc.Invoke(ctx, PostService_LikeComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCommentsResponse)
@@ -123,6 +175,16 @@ func (c *postServiceClient) GetComments(ctx context.Context, in *GetCommentsRequ
 	return out, nil
 }
 
+func (c *postServiceClient) GetCommentReplies(ctx context.Context, in *GetCommentRepliesRequest, opts ...grpc.CallOption) (*GetCommentRepliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommentRepliesResponse)
+	err := c.cc.Invoke(ctx, PostService_GetCommentReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) GetLikes(ctx context.Context, in *GetLikesRequest, opts ...grpc.CallOption) (*GetLikesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetLikesResponse)
@@ -143,6 +205,76 @@ func (c *postServiceClient) GetLikesCount(ctx context.Context, in *GetLikesCount
 	return out, nil
 }
 
+func (c *postServiceClient) SavePost(ctx context.Context, in *SavePostRequest, opts ...grpc.CallOption) (*SavePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavePostResponse)
+	err := c.cc.Invoke(ctx, PostService_SavePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) UnsavePost(ctx context.Context, in *UnsavePostRequest, opts ...grpc.CallOption) (*UnsavePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsavePostResponse)
+	err := c.cc.Invoke(ctx, PostService_UnsavePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ListSavedPosts(ctx context.Context, in *ListSavedPostsRequest, opts ...grpc.CallOption) (*ListSavedPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_ListSavedPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*PinPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinPostResponse)
+	err := c.cc.Invoke(ctx, PostService_PinPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) UnpinPost(ctx context.Context, in *UnpinPostRequest, opts ...grpc.CallOption) (*UnpinPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpinPostResponse)
+	err := c.cc.Invoke(ctx, PostService_UnpinPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VotePollResponse)
+	err := c.cc.Invoke(ctx, PostService_VotePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_SearchPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility.
@@ -152,10 +284,21 @@ type PostServiceServer interface {
 	EditPost(context.Context, *EditPostRequest) (*EditPostResponse, error)
 	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
 	CommentOnPost(context.Context, *CommentOnPostRequest) (*CommentOnPostResponse, error)
+	EditComment(context.Context, *EditCommentRequest) (*EditCommentResponse, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
 	LikePost(context.Context, *LikePostRequest) (*LikePostResponse, error)
+	LikeComment(context.Context, *LikeCommentRequest) (*LikeCommentResponse, error)
 	GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error)
+	GetCommentReplies(context.Context, *GetCommentRepliesRequest) (*GetCommentRepliesResponse, error)
 	GetLikes(context.Context, *GetLikesRequest) (*GetLikesResponse, error)
 	GetLikesCount(context.Context, *GetLikesCountRequest) (*GetLikesCountResponse, error)
+	SavePost(context.Context, *SavePostRequest) (*SavePostResponse, error)
+	UnsavePost(context.Context, *UnsavePostRequest) (*UnsavePostResponse, error)
+	ListSavedPosts(context.Context, *ListSavedPostsRequest) (*ListSavedPostsResponse, error)
+	PinPost(context.Context, *PinPostRequest) (*PinPostResponse, error)
+	UnpinPost(context.Context, *UnpinPostRequest) (*UnpinPostResponse, error)
+	VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error)
+	SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -181,18 +324,51 @@ func (UnimplementedPostServiceServer) DeletePost(context.Context, *DeletePostReq
 func (UnimplementedPostServiceServer) CommentOnPost(context.Context, *CommentOnPostRequest) (*CommentOnPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CommentOnPost not implemented")
 }
+func (UnimplementedPostServiceServer) EditComment(context.Context, *EditCommentRequest) (*EditCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditComment not implemented")
+}
+func (UnimplementedPostServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
 func (UnimplementedPostServiceServer) LikePost(context.Context, *LikePostRequest) (*LikePostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LikePost not implemented")
 }
+func (UnimplementedPostServiceServer) LikeComment(context.Context, *LikeCommentRequest) (*LikeCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeComment not implemented")
+}
 func (UnimplementedPostServiceServer) GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetComments not implemented")
 }
+func (UnimplementedPostServiceServer) GetCommentReplies(context.Context, *GetCommentRepliesRequest) (*GetCommentRepliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentReplies not implemented")
+}
 func (UnimplementedPostServiceServer) GetLikes(context.Context, *GetLikesRequest) (*GetLikesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLikes not implemented")
 }
 func (UnimplementedPostServiceServer) GetLikesCount(context.Context, *GetLikesCountRequest) (*GetLikesCountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLikesCount not implemented")
 }
+func (UnimplementedPostServiceServer) SavePost(context.Context, *SavePostRequest) (*SavePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePost not implemented")
+}
+func (UnimplementedPostServiceServer) UnsavePost(context.Context, *UnsavePostRequest) (*UnsavePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsavePost not implemented")
+}
+func (UnimplementedPostServiceServer) ListSavedPosts(context.Context, *ListSavedPostsRequest) (*ListSavedPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedPosts not implemented")
+}
+func (UnimplementedPostServiceServer) PinPost(context.Context, *PinPostRequest) (*PinPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinPost not implemented")
+}
+func (UnimplementedPostServiceServer) UnpinPost(context.Context, *UnpinPostRequest) (*UnpinPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinPost not implemented")
+}
+func (UnimplementedPostServiceServer) VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedPostServiceServer) SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPosts not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
 
@@ -304,6 +480,42 @@ func _PostService_CommentOnPost_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_EditComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).EditComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_EditComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).EditComment(ctx, req.(*EditCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_LikePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LikePostRequest)
 	if err := dec(in); err != nil {
@@ -322,6 +534,24 @@ func _PostService_LikePost_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_LikeComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).LikeComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_LikeComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).LikeComment(ctx, req.(*LikeCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCommentsRequest)
 	if err := dec(in); err != nil {
@@ -340,6 +570,24 @@ func _PostService_GetComments_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetCommentReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRepliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetCommentReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetCommentReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetCommentReplies(ctx, req.(*GetCommentRepliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_GetLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLikesRequest)
 	if err := dec(in); err != nil {
@@ -376,6 +624,132 @@ func _PostService_GetLikesCount_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_SavePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SavePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SavePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SavePost(ctx, req.(*SavePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_UnsavePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsavePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).UnsavePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_UnsavePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).UnsavePost(ctx, req.(*UnsavePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ListSavedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ListSavedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ListSavedPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ListSavedPosts(ctx, req.(*ListSavedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_PinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).PinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_PinPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).PinPost(ctx, req.(*PinPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_UnpinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).UnpinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_UnpinPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).UnpinPost(ctx, req.(*UnpinPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).VotePoll(ctx, req.(*VotePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SearchPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SearchPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SearchPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SearchPosts(ctx, req.(*SearchPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -403,14 +777,30 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CommentOnPost",
 			Handler:    _PostService_CommentOnPost_Handler,
 		},
+		{
+			MethodName: "EditComment",
+			Handler:    _PostService_EditComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _PostService_DeleteComment_Handler,
+		},
 		{
 			MethodName: "LikePost",
 			Handler:    _PostService_LikePost_Handler,
 		},
+		{
+			MethodName: "LikeComment",
+			Handler:    _PostService_LikeComment_Handler,
+		},
 		{
 			MethodName: "GetComments",
 			Handler:    _PostService_GetComments_Handler,
 		},
+		{
+			MethodName: "GetCommentReplies",
+			Handler:    _PostService_GetCommentReplies_Handler,
+		},
 		{
 			MethodName: "GetLikes",
 			Handler:    _PostService_GetLikes_Handler,
@@ -419,6 +809,34 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLikesCount",
 			Handler:    _PostService_GetLikesCount_Handler,
 		},
+		{
+			MethodName: "SavePost",
+			Handler:    _PostService_SavePost_Handler,
+		},
+		{
+			MethodName: "UnsavePost",
+			Handler:    _PostService_UnsavePost_Handler,
+		},
+		{
+			MethodName: "ListSavedPosts",
+			Handler:    _PostService_ListSavedPosts_Handler,
+		},
+		{
+			MethodName: "PinPost",
+			Handler:    _PostService_PinPost_Handler,
+		},
+		{
+			MethodName: "UnpinPost",
+			Handler:    _PostService_UnpinPost_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _PostService_VotePoll_Handler,
+		},
+		{
+			MethodName: "SearchPosts",
+			Handler:    _PostService_SearchPosts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post.proto",
@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"news-feed/internal/api/generated/news-feed/friendspb"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/logger"
 	"news-feed/pkg/middleware"
 	"strconv"
@@ -39,9 +39,9 @@ func (h *FriendsHandler) FriendsHandler(w http.ResponseWriter, r *http.Request)
 	switch r.Method {
 	case http.MethodGet:
 		if len(parts) == 4 {
-			middleware.JWTAuthMiddleware(h.GetFriends()).ServeHTTP(w, r)
+			middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetFriends()).ServeHTTP(w, r)
 		} else if len(parts) == 5 && parts[4] == "posts" {
-			middleware.JWTAuthMiddleware(h.GetUserPosts()).ServeHTTP(w, r)
+			middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetUserPosts()).ServeHTTP(w, r)
 		} else {
 			http.NotFound(w, r)
 		}
@@ -109,7 +109,7 @@ func (h *FriendsHandler) GetFriends() http.HandlerFunc {
 			Cursor: int32(cursor),
 		}
 
-		response, err := h.grpcFriendsHandler.GetFriends(context.Background(), &req)
+		response, err := h.grpcFriendsHandler.GetFriends(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Get followers failed %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -129,7 +129,7 @@ func (h *FriendsHandler) GetFriends() http.HandlerFunc {
 func (h *FriendsHandler) FollowUser() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get the current user ID from the request context (assumes middleware has set it)
-		currentUserID, ok := r.Context().Value("userID").(int)
+		currentUserID, ok := auth.UserID(r.Context())
 		if !ok {
 			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -151,7 +151,7 @@ func (h *FriendsHandler) FollowUser() http.HandlerFunc {
 		}
 
 		// Call the service method to follow the target user
-		response, err := h.grpcFriendsHandler.FollowUser(context.Background(), &req)
+		response, err := h.grpcFriendsHandler.FollowUser(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Follow user failed %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -183,7 +183,7 @@ func (h *FriendsHandler) FollowUser() http.HandlerFunc {
 func (h *FriendsHandler) UnfollowUser() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get the current user ID from the request context (assumes middleware has set it)
-		currentUserID, ok := r.Context().Value("userID").(int)
+		currentUserID, ok := auth.UserID(r.Context())
 		if !ok {
 			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -205,7 +205,7 @@ func (h *FriendsHandler) UnfollowUser() http.HandlerFunc {
 		}
 
 		// Call the service method to unfollow the target user
-		response, err := h.grpcFriendsHandler.UnfollowUser(context.Background(), &req)
+		response, err := h.grpcFriendsHandler.UnfollowUser(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Unfollow user failed %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -266,7 +266,7 @@ func (h *FriendsHandler) GetUserPosts() http.HandlerFunc {
 			Limit:  int32(limit),
 			Cursor: int32(cursor),
 		}
-		response, err := h.grpcFriendsHandler.GetUserPosts(context.Background(), &req)
+		response, err := h.grpcFriendsHandler.GetUserPosts(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Get user posts failed %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
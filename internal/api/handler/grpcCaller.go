@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"news-feed/pkg/auth"
+)
+
+// callerID returns the ID of the user the webapp made the call for. The user IDs in requests are not
+// trusted for the caller, anyone reaching the service could set them.
+func callerID(ctx context.Context) (int, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "no authenticated caller")
+	}
+	return userID, nil
+}
+
+// viewerID returns the ID of the caller, or 0 for calls made without a signed-in user.
+func viewerID(ctx context.Context) int {
+	userID, _ := auth.UserID(ctx)
+	return userID
+}
@@ -39,6 +39,8 @@ func (h *GRPCFriendsHandler) GetFriends(ctx context.Context, req *friendspb.GetF
 			LastName:  user.LastName,
 			Email:     user.Email,
 			Username:  user.Username,
+			AvatarUrl: user.AvatarURL,
+			CoverUrl:  user.CoverURL,
 		}
 	}
 
@@ -46,7 +48,10 @@ func (h *GRPCFriendsHandler) GetFriends(ctx context.Context, req *friendspb.GetF
 }
 
 func (h *GRPCFriendsHandler) FollowUser(ctx context.Context, req *friendspb.FollowUserRequest) (*friendspb.FollowUserResponse, error) {
-	currentUserID := req.GetCurrentUserId()
+	currentUserID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	targetUserID := req.GetTargetUserId()
 
 	// Call the service method to follow the target user
@@ -65,7 +70,10 @@ func (h *GRPCFriendsHandler) FollowUser(ctx context.Context, req *friendspb.Foll
 }
 
 func (h *GRPCFriendsHandler) UnfollowUser(ctx context.Context, req *friendspb.UnfollowUserRequest) (*friendspb.UnfollowUserResponse, error) {
-	currentUserID := req.GetCurrentUserId()
+	currentUserID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	targetUserID := req.GetTargetUserId()
 
 	// Call the service method to unfollow the target user
@@ -104,6 +112,7 @@ func (h *GRPCFriendsHandler) GetUserPosts(ctx context.Context, req *friendspb.Ge
 			ContentText:      post.ContentText,                // Map ContentText
 			ContentImagePath: post.ContentImagePath,           // Map ContentImagePath
 			CreatedAt:        timestamppb.New(post.CreatedAt), // Convert time.Time to protobuf Timestamp
+			Pinned:           post.Pinned,
 		}
 	}
 
@@ -2,14 +2,19 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log"
 	_ "news-feed/docs"
 	"news-feed/internal/api/generated/news-feed/postpb"
 	"news-feed/internal/entity"
+	"news-feed/internal/repository"
 	"news-feed/internal/service"
+	"news-feed/pkg/auth"
 	"time"
 )
 
@@ -19,8 +24,8 @@ type GRPCPostHandler struct {
 }
 
 func (h *GRPCPostHandler) CreatePost(ctx context.Context, req *postpb.CreatePostRequest) (*postpb.CreatePostResponse, error) {
-	// Retrieve the user ID from the context
-	userID, ok := ctx.Value("userID").(int)
+	// Retrieve the user ID from the principal forwarded by the webapp
+	userID, ok := auth.UserID(ctx)
 	if !ok {
 		log.Printf("User ID not found in context")
 		return nil, fmt.Errorf("User ID not found in context")
@@ -33,10 +38,29 @@ func (h *GRPCPostHandler) CreatePost(ctx context.Context, req *postpb.CreatePost
 		imageFileName = ""
 	}
 
+	commentPermission, err := commentPermissionFromProto(req.CommentPermission)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var poll *entity.Poll
+	if req.Poll != nil {
+		poll, err = pollFromProto(req.Poll)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	// Call the CreatePost service method
-	createdPost, err := h.PostService.CreatePost(req.Text, imageFileName, userID)
+	createdPost, err := h.PostService.CreatePost(req.Text, imageFileName, userID, commentPermission, poll)
 	if err != nil {
 		log.Printf("Failed to create post: %v", err)
+		if errors.Is(err, service.ErrInvalidCommentPermission) || errors.Is(err, service.ErrInvalidPoll) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, fmt.Errorf("failed to create post: %v", err)
 	}
 
@@ -67,32 +91,98 @@ func (h *GRPCPostHandler) GetPost(ctx context.Context, req *postpb.GetPostReques
 		return nil, fmt.Errorf("failed to get post: %v", err)
 	}
 
+	poll, err := h.PostService.GetPoll(int(postID), viewerID(ctx))
+	if err != nil {
+		log.Printf("Failed to get poll: %v", err)
+		return nil, fmt.Errorf("failed to get poll: %v", err)
+	}
+
+	linkPreview, err := h.PostService.GetLinkPreview(int(postID))
+	if err != nil {
+		// The preview is optional, still return the post
+		log.Printf("Failed to get link preview: %v", err)
+	}
+
 	// Prepare the response
 	response := &postpb.GetPostResponse{
-		Id:               int32(post.ID),                      // Convert to int32 for gRPC
-		UserId:           int32(post.UserID),                  // Convert to int32 for gRPC
-		ContentText:      post.ContentText,                    // Post content text
-		ContentImagePath: post.ContentImagePath,               // Image URL or path
-		CreatedAt:        post.CreatedAt.Format(time.RFC3339), // Format time.Time to string in RFC3339
+		Id:                int32(post.ID),                      // Convert to int32 for gRPC
+		UserId:            int32(post.UserID),                  // Convert to int32 for gRPC
+		ContentText:       post.ContentText,                    // Post content text
+		ContentImagePath:  post.ContentImagePath,               // Image URL or path
+		CreatedAt:         post.CreatedAt.Format(time.RFC3339), // Format time.Time to string in RFC3339
+		CommentPermission: commentPermissionToProto(post.CommentPermission),
+		Poll:              toPollProto(poll),
+		Author:            toUserSummaryProto(post.Author),
+		LinkPreview:       toLinkPreviewProto(linkPreview),
 	}
 
 	return response, nil
 }
 
+// commentPermissionFromProto converts the gRPC comment permission to its stored representation.
+func commentPermissionFromProto(permission postpb.CommentPermission) (string, error) {
+	switch permission {
+	case postpb.CommentPermission_COMMENT_PERMISSION_EVERYONE:
+		return entity.CommentPermissionEveryone, nil
+	case postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWERS:
+		return entity.CommentPermissionFollowers, nil
+	case postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWING:
+		return entity.CommentPermissionFollowing, nil
+	case postpb.CommentPermission_COMMENT_PERMISSION_DISABLED:
+		return entity.CommentPermissionDisabled, nil
+	}
+	return "", fmt.Errorf("%w: %d", service.ErrInvalidCommentPermission, permission)
+}
+
+// commentPermissionToProto converts a stored comment permission to its gRPC enum, defaulting to everyone.
+func commentPermissionToProto(permission string) postpb.CommentPermission {
+	switch permission {
+	case entity.CommentPermissionFollowers:
+		return postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWERS
+	case entity.CommentPermissionFollowing:
+		return postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWING
+	case entity.CommentPermissionDisabled:
+		return postpb.CommentPermission_COMMENT_PERMISSION_DISABLED
+	}
+	return postpb.CommentPermission_COMMENT_PERMISSION_EVERYONE
+}
+
 func (h *GRPCPostHandler) EditPost(ctx context.Context, req *postpb.EditPostRequest) (*postpb.EditPostResponse, error) {
 	postID := req.PostId // postID is of type int32
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create an updated post object
 	post := entity.Post{
 		ID:               int(postID),     // Convert to int if needed
+		UserID:           userID,          // Only the author may edit the post
 		ContentText:      req.ContentText, // Content text from request
 		ContentImagePath: "",              // Placeholder for image path
 	}
 
+	// Only change the comment permission when the request sets one
+	if req.CommentPermission != nil {
+		commentPermission, err := commentPermissionFromProto(*req.CommentPermission)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		post.CommentPermission = commentPermission
+	}
+
 	// Call service to update the post
 	updatedPost, err := h.PostService.EditPost(post)
 	if err != nil {
 		log.Printf("Failed to update post: %v", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidCommentPermission):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrPostPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, repository.ErrPostNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		return nil, fmt.Errorf("failed to update post: %v", err)
 	}
 
@@ -110,10 +200,13 @@ func (h *GRPCPostHandler) EditPost(ctx context.Context, req *postpb.EditPostRequ
 
 func (h *GRPCPostHandler) DeletePost(ctx context.Context, req *postpb.DeletePostRequest) (*postpb.DeletePostResponse, error) {
 	postID := req.PostId
-	userID := req.UserId
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Call the DeletePost service method
-	err := h.PostService.DeletePost(int(postID), int(userID))
+	err = h.PostService.DeletePost(int(postID), int(userID))
 	if err != nil {
 		log.Printf("Failed to delete post: %v", err)
 		return nil, fmt.Errorf("failed to delete post: %v", err)
@@ -129,22 +222,87 @@ func (h *GRPCPostHandler) DeletePost(ctx context.Context, req *postpb.DeletePost
 
 func (h *GRPCPostHandler) CommentOnPost(ctx context.Context, req *postpb.CommentOnPostRequest) (*postpb.CommentOnPostResponse, error) {
 	postID := req.PostId
-	userID := req.UserId
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	commentText := req.Text
+	parentCommentID := req.ParentCommentId
 
 	// Call the CommentOnPost service method
-	createdComment, err := h.PostService.CommentOnPost(int(postID), int(userID), commentText)
+	createdComment, err := h.PostService.CommentOnPost(int(postID), int(userID), commentText, int(parentCommentID))
 	if err != nil {
 		log.Printf("Failed to comment on post: %v", err)
+		if errors.Is(err, service.ErrCommentNotAllowed) || errors.Is(err, service.ErrEmailNotVerified) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, service.ErrCommentMaxDepthExceeded) || errors.Is(err, service.ErrInvalidParentComment) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, fmt.Errorf("failed to comment on post: %v", err)
 	}
 
 	// Prepare the response
 	response := &postpb.CommentOnPostResponse{
-		CommentId: int32(createdComment.ID),                      // Assuming createdComment has an ID field
-		Text:      createdComment.Content,                        // Comment text
-		UserId:    int32(createdComment.UserID),                  // ID of the user who commented
-		CreatedAt: createdComment.CreatedAt.Format(time.RFC3339), // Format timestamp
+		CommentId:       int32(createdComment.ID),                      // Assuming createdComment has an ID field
+		Text:            createdComment.Content,                        // Comment text
+		UserId:          int32(createdComment.UserID),                  // ID of the user who commented
+		CreatedAt:       createdComment.CreatedAt.Format(time.RFC3339), // Format timestamp
+		ParentCommentId: int32(createdComment.ParentCommentID),         // ID of the comment replied to
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) EditComment(ctx context.Context, req *postpb.EditCommentRequest) (*postpb.EditCommentResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the EditComment service method
+	updatedComment, err := h.PostService.EditComment(int(req.PostId), int(req.CommentId), userID, req.Text)
+	if err != nil {
+		log.Printf("Failed to edit comment: %v", err)
+		if errors.Is(err, service.ErrCommentPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to edit comment: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.EditCommentResponse{
+		Comment: toCommentProto(*updatedComment),
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) DeleteComment(ctx context.Context, req *postpb.DeleteCommentRequest) (*postpb.DeleteCommentResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the DeleteComment service method
+	err = h.PostService.DeleteComment(int(req.PostId), int(req.CommentId), userID)
+	if err != nil {
+		log.Printf("Failed to delete comment: %v", err)
+		if errors.Is(err, service.ErrCommentPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to delete comment: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.DeleteCommentResponse{
+		Msg: "Comment deleted successfully", // Confirmation message
 	}
 
 	return response, nil
@@ -152,10 +310,13 @@ func (h *GRPCPostHandler) CommentOnPost(ctx context.Context, req *postpb.Comment
 
 func (h *GRPCPostHandler) LikePost(ctx context.Context, req *postpb.LikePostRequest) (*postpb.LikePostResponse, error) {
 	postID := req.PostId
-	userID := req.UserId
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Call the LikePost service method
-	err := h.PostService.LikePost(int(postID), int(userID))
+	err = h.PostService.LikePost(int(postID), int(userID))
 	if err != nil {
 		log.Printf("Failed to like post: %v", err)
 		return nil, fmt.Errorf("failed to like post: %v", err)
@@ -169,38 +330,121 @@ func (h *GRPCPostHandler) LikePost(ctx context.Context, req *postpb.LikePostRequ
 	return response, nil
 }
 
+func (h *GRPCPostHandler) LikeComment(ctx context.Context, req *postpb.LikeCommentRequest) (*postpb.LikeCommentResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the LikeComment service method
+	err = h.PostService.LikeComment(int(req.PostId), int(req.CommentId), userID)
+	if err != nil {
+		log.Printf("Failed to like comment: %v", err)
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, repository.ErrCommentAlreadyLiked) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, fmt.Errorf("failed to like comment: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.LikeCommentResponse{
+		Message: "Comment liked successfully",
+	}
+
+	return response, nil
+}
+
 func (h *GRPCPostHandler) GetComments(ctx context.Context, req *postpb.GetCommentsRequest) (*postpb.GetCommentsResponse, error) {
 	postID := req.PostId
 	cursor := req.Cursor
 	limit := req.Limit
 
-	// Call the GetComments service method
-	comments, nextCursor, err := h.PostService.GetComments(int(postID), int(cursor), int(limit))
+	// Prepare the response
+	response := &postpb.GetCommentsResponse{}
+
+	// Call the GetComments service method matching the requested ordering
+	var comments []entity.Comment
+	var err error
+	if req.Sort == postpb.CommentSort_COMMENT_SORT_TOP {
+		comments, response.NextTopCursor, err = h.PostService.GetTopComments(int(postID), req.TopCursor, int(limit))
+	} else {
+		var nextCursor int
+		comments, nextCursor, err = h.PostService.GetComments(int(postID), int(cursor), int(limit))
+		response.NextCursor = int32(nextCursor)
+	}
 	if err != nil {
 		log.Printf("Failed to get comments: %v", err)
+		if errors.Is(err, service.ErrInvalidCommentCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, fmt.Errorf("failed to get comments: %v", err)
 	}
 
+	// Populate the comments
+	for _, comment := range comments {
+		response.Comments = append(response.Comments, toCommentProto(comment))
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) GetCommentReplies(ctx context.Context, req *postpb.GetCommentRepliesRequest) (*postpb.GetCommentRepliesResponse, error) {
+	commentID := req.CommentId
+	cursor := req.Cursor
+	limit := req.Limit
+
+	// Call the GetCommentReplies service method
+	replies, nextCursor, err := h.PostService.GetCommentReplies(int(commentID), int(cursor), int(limit))
+	if err != nil {
+		log.Printf("Failed to get replies: %v", err)
+		return nil, fmt.Errorf("failed to get replies: %v", err)
+	}
+
 	// Prepare the response
-	response := &postpb.GetCommentsResponse{
+	response := &postpb.GetCommentRepliesResponse{
 		NextCursor: int32(nextCursor),
 	}
 
-	// Populate the comments
-	for _, comment := range comments {
-		response.Comments = append(
-			response.Comments, &postpb.Comment{
-				Id:        int32(comment.ID),
-				UserId:    int32(comment.UserID),
-				Text:      comment.Content,
-				CreatedAt: comment.CreatedAt.Format(time.RFC3339), // Format timestamp
-			},
-		)
+	// Populate the replies
+	for _, reply := range replies {
+		response.Replies = append(response.Replies, toCommentProto(reply))
 	}
 
 	return response, nil
 }
 
+// Convert entity.Comment to postpb.Comment
+func toCommentProto(comment entity.Comment) *postpb.Comment {
+	return &postpb.Comment{
+		Id:              int32(comment.ID),
+		UserId:          int32(comment.UserID),
+		Text:            comment.Content,
+		CreatedAt:       comment.CreatedAt.Format(time.RFC3339), // Format timestamp
+		ParentCommentId: int32(comment.ParentCommentID),
+		ReplyCount:      int32(comment.ReplyCount),
+		LikeCount:       int32(comment.LikeCount),
+		Author:          toUserSummaryProto(comment.Author),
+	}
+}
+
+// toUserSummaryProto converts the public fields of a user embedded in another response.
+func toUserSummaryProto(user *entity.User) *postpb.User {
+	if user == nil {
+		return nil
+	}
+	return &postpb.User{
+		Id:        int32(user.ID),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Username:  user.Username,
+		AvatarUrl: user.AvatarURL,
+		CoverUrl:  user.CoverURL,
+	}
+}
+
 func (h *GRPCPostHandler) GetLikes(ctx context.Context, req *postpb.GetLikesRequest) (*postpb.GetLikesResponse, error) {
 	postID := req.PostId
 	cursor := req.Cursor
@@ -230,6 +474,8 @@ func (h *GRPCPostHandler) GetLikes(ctx context.Context, req *postpb.GetLikesRequ
 				Birthday:       toTimestamp(&user.Birthday), // Convert time.Time to google.protobuf.Timestamp
 				Email:          user.Email,
 				Username:       user.Username,
+				AvatarUrl:      user.AvatarURL,
+				CoverUrl:       user.CoverURL,
 			},
 		)
 	}
@@ -277,3 +523,290 @@ func (h *GRPCPostHandler) GetLikesCount(ctx context.Context, req *postpb.GetLike
 
 	return response, nil
 }
+
+func (h *GRPCPostHandler) SavePost(ctx context.Context, req *postpb.SavePostRequest) (*postpb.SavePostResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the SavePost service method
+	err = h.PostService.SavePost(userID, int(req.PostId), req.Collection)
+	if err != nil {
+		log.Printf("Failed to save post: %v", err)
+		if errors.Is(err, repository.ErrPostNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, service.ErrInvalidBookmarkCollection) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to save post: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.SavePostResponse{
+		Message: "Post saved successfully",
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) UnsavePost(ctx context.Context, req *postpb.UnsavePostRequest) (*postpb.UnsavePostResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the UnsavePost service method
+	err = h.PostService.UnsavePost(userID, int(req.PostId))
+	if err != nil {
+		log.Printf("Failed to unsave post: %v", err)
+		return nil, fmt.Errorf("failed to unsave post: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.UnsavePostResponse{
+		Message: "Post removed from saved posts",
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) ListSavedPosts(ctx context.Context, req *postpb.ListSavedPostsRequest) (*postpb.ListSavedPostsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the ListSavedPosts service method
+	bookmarks, nextCursor, err := h.PostService.ListSavedPosts(
+		userID, req.Collection, int(req.Cursor), int(req.Limit),
+	)
+	if err != nil {
+		log.Printf("Failed to list saved posts: %v", err)
+		return nil, fmt.Errorf("failed to list saved posts: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.ListSavedPostsResponse{
+		NextCursor: int32(nextCursor),
+	}
+
+	// Populate the saved posts
+	for _, bookmark := range bookmarks {
+		response.SavedPosts = append(
+			response.SavedPosts, &postpb.SavedPost{
+				BookmarkId: int32(bookmark.ID),
+				Collection: bookmark.Collection,
+				SavedAt:    bookmark.CreatedAt.Format(time.RFC3339),
+				Post:       toPostProto(bookmark.Post),
+			},
+		)
+	}
+
+	return response, nil
+}
+
+// Convert entity.Post to postpb.Post
+func toPostProto(post entity.Post) *postpb.Post {
+	return &postpb.Post{
+		Id:                int32(post.ID),
+		UserId:            int32(post.UserID),
+		ContentText:       post.ContentText,
+		ContentImagePath:  post.ContentImagePath,
+		CreatedAt:         post.CreatedAt.Format(time.RFC3339),
+		CommentPermission: commentPermissionToProto(post.CommentPermission),
+	}
+}
+
+func (h *GRPCPostHandler) PinPost(ctx context.Context, req *postpb.PinPostRequest) (*postpb.PinPostResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the PinPost service method
+	err = h.PostService.PinPost(int(req.PostId), userID)
+	if err != nil {
+		log.Printf("Failed to pin post: %v", err)
+		return nil, pinPostError("pin", err)
+	}
+
+	// Prepare the response
+	response := &postpb.PinPostResponse{
+		Message: "Post pinned successfully",
+	}
+
+	return response, nil
+}
+
+func (h *GRPCPostHandler) UnpinPost(ctx context.Context, req *postpb.UnpinPostRequest) (*postpb.UnpinPostResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the UnpinPost service method
+	err = h.PostService.UnpinPost(int(req.PostId), userID)
+	if err != nil {
+		log.Printf("Failed to unpin post: %v", err)
+		return nil, pinPostError("unpin", err)
+	}
+
+	// Prepare the response
+	response := &postpb.UnpinPostResponse{
+		Message: "Post unpinned successfully",
+	}
+
+	return response, nil
+}
+
+// pinPostError maps pin and unpin service errors to gRPC status codes.
+func pinPostError(action string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrPostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPostPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, repository.ErrPinnedPostLimitReached):
+		return status.Error(
+			codes.FailedPrecondition, fmt.Sprintf("%v: at most %d posts can be pinned", err, service.MaxPinnedPosts),
+		)
+	}
+	return fmt.Errorf("failed to %s post: %v", action, err)
+}
+
+func (h *GRPCPostHandler) VotePoll(ctx context.Context, req *postpb.VotePollRequest) (*postpb.VotePollResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	optionIDs := make([]int, len(req.OptionIds))
+	for i, optionID := range req.OptionIds {
+		optionIDs[i] = int(optionID)
+	}
+
+	// Call the VotePoll service method
+	poll, err := h.PostService.VotePoll(int(req.PostId), userID, optionIDs)
+	if err != nil {
+		log.Printf("Failed to vote on poll: %v", err)
+		switch {
+		case errors.Is(err, repository.ErrPollNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, service.ErrInvalidPollVote):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repository.ErrPollAlreadyVoted):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, service.ErrPollClosed):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, fmt.Errorf("failed to vote on poll: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.VotePollResponse{
+		Poll: toPollProto(poll),
+	}
+
+	return response, nil
+}
+
+// pollFromProto converts the poll of a CreatePost request to an entity.Poll.
+func pollFromProto(input *postpb.PollInput) (*entity.Poll, error) {
+	closesAt, err := time.Parse(time.RFC3339, input.ClosesAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: closes_at must be an RFC3339 time", service.ErrInvalidPoll)
+	}
+
+	poll := &entity.Poll{
+		MultipleChoice: input.MultipleChoice,
+		ClosesAt:       closesAt,
+	}
+	for _, text := range input.Options {
+		poll.Options = append(poll.Options, entity.PollOption{Text: text})
+	}
+	return poll, nil
+}
+
+// Convert entity.Poll to postpb.Poll, nil when the post has no poll
+func toPollProto(poll *entity.Poll) *postpb.Poll {
+	if poll == nil {
+		return nil
+	}
+
+	pollProto := &postpb.Poll{
+		Id:             int32(poll.ID),
+		ClosesAt:       poll.ClosesAt.Format(time.RFC3339),
+		MultipleChoice: poll.MultipleChoice,
+		Closed:         poll.Closed(time.Now()),
+		ResultsVisible: poll.ResultsVisible,
+	}
+	for _, option := range poll.Options {
+		pollProto.Options = append(
+			pollProto.Options, &postpb.PollOption{
+				Id:        int32(option.ID),
+				Text:      option.Text,
+				VoteCount: int32(option.VoteCount),
+				Voted:     option.Voted,
+			},
+		)
+	}
+	return pollProto
+}
+
+// Convert entity.LinkPreview to postpb.LinkPreview, nil when the post has no preview
+func toLinkPreviewProto(preview *entity.LinkPreview) *postpb.LinkPreview {
+	if preview == nil {
+		return nil
+	}
+	return &postpb.LinkPreview{
+		Url:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageUrl:    preview.ImageURL,
+	}
+}
+
+func (h *GRPCPostHandler) SearchPosts(ctx context.Context, req *postpb.SearchPostsRequest) (*postpb.SearchPostsResponse, error) {
+	filter := entity.PostSearchFilter{
+		Query:    req.Query,
+		AuthorID: int(req.AuthorId),
+		Cursor:   int(req.Cursor),
+		Limit:    int(req.Limit),
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from must be an RFC3339 time")
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "to must be an RFC3339 time")
+		}
+		filter.To = &to
+	}
+
+	// Call the SearchPosts service method
+	posts, nextCursor, err := h.PostService.SearchPosts(filter)
+	if err != nil {
+		log.Printf("Failed to search posts: %v", err)
+		if errors.Is(err, service.ErrInvalidSearchQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to search posts: %v", err)
+	}
+
+	// Prepare the response
+	response := &postpb.SearchPostsResponse{
+		NextCursor: int32(nextCursor),
+	}
+	for _, post := range posts {
+		response.Posts = append(response.Posts, toPostProto(post))
+	}
+
+	return response, nil
+}
@@ -2,10 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
 	"news-feed/internal/api/generated/news-feed/userpb"
+	"news-feed/internal/apikey"
 	"news-feed/internal/entity"
+	"news-feed/internal/repository"
 	"news-feed/internal/service"
+	"news-feed/internal/session"
 	"news-feed/pkg/logger"
 	"time"
 )
@@ -33,18 +40,31 @@ func (h *GRPCUserHandler) Login(ctx context.Context, req *userpb.LoginRequest) (
 	}
 
 	// Simulate token generation or authentication process
-	token, err := h.UserService.Login(req.UserName, req.Password)
+	token, err := h.UserService.Login(req.UserName, req.Password, req.Device, req.UserAgent, req.ClientIp)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Login failed: %v", err))
-		return &userpb.LoginResponse{
+		response := &userpb.LoginResponse{
 			Error: fmt.Sprintf("Login failed: %v", err),
-		}, nil
+		}
+		var throttle *service.LoginThrottleError
+		if errors.As(err, &throttle) {
+			response.CaptchaRequired = throttle.CaptchaRequired
+			response.RetryAfterSeconds = int32(math.Ceil(throttle.RetryAfter.Seconds()))
+		}
+		return response, nil
 	}
 
 	// Return successful gRPC response
+	if token.TwoFactorToken != "" {
+		return &userpb.LoginResponse{
+			TwoFactorToken: token.TwoFactorToken,
+		}, nil
+	}
 	return &userpb.LoginResponse{
-		JwtToken: token,
-		Error:    "",
+		JwtToken:     token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int32(token.ExpiresIn),
+		Error:        "",
 	}, nil
 }
 
@@ -68,9 +88,14 @@ func (h *GRPCUserHandler) Signup(ctx context.Context, req *userpb.SignupRequest)
 	}
 
 	// Call the user service's Signup method
-	token, err := h.UserService.Signup(newUser)
+	token, err := h.UserService.Signup(newUser, req.Device, req.UserAgent)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Signup failed: %v", err))
+		if errors.Is(err, service.ErrInvalidProfile) {
+			return &userpb.SignupResponse{
+				Error: err.Error(),
+			}, nil
+		}
 		return &userpb.SignupResponse{
 			Error: "Signup failed",
 		}, nil
@@ -78,8 +103,149 @@ func (h *GRPCUserHandler) Signup(ctx context.Context, req *userpb.SignupRequest)
 
 	// Return successful gRPC response with the token
 	return &userpb.SignupResponse{
-		Token: token,
-		Error: "",
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int32(token.ExpiresIn),
+		Error:        "",
+	}, nil
+}
+
+func (h *GRPCUserHandler) EnrollTOTP(ctx context.Context, req *userpb.EnrollTOTPRequest) (*userpb.EnrollTOTPResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	otpauthURI, secret, err := h.UserService.EnrollTOTP(userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Enroll TOTP failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to enroll TOTP: %v", err)
+	}
+	return &userpb.EnrollTOTPResponse{
+		OtpauthUri: otpauthURI,
+		Secret:     secret,
+	}, nil
+}
+
+func (h *GRPCUserHandler) ActivateTOTP(ctx context.Context, req *userpb.ActivateTOTPRequest) (*userpb.ActivateTOTPResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := h.UserService.ActivateTOTP(userID, req.Code)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Activate TOTP failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrTwoFactorAlreadyEnabled), errors.Is(err, service.ErrTwoFactorNotEnrolled):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to activate TOTP: %v", err)
+	}
+	return &userpb.ActivateTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *GRPCUserHandler) VerifyTwoFactor(ctx context.Context, req *userpb.VerifyTwoFactorRequest) (*userpb.RefreshTokenResponse, error) {
+	if req.TwoFactorToken == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "two-factor token and code are required")
+	}
+
+	token, err := h.UserService.VerifyTwoFactor(req.TwoFactorToken, req.Code)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Verify two-factor failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrInvalidTwoFactorCode) || errors.Is(err, service.ErrInvalidTwoFactorToken):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrLoginLocked):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, fmt.Errorf("failed to verify two-factor code: %v", err)
+	}
+
+	return &userpb.RefreshTokenResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int32(token.ExpiresIn),
+	}, nil
+}
+
+func (h *GRPCUserHandler) StartOIDCLogin(ctx context.Context, req *userpb.StartOIDCLoginRequest) (*userpb.StartOIDCLoginResponse, error) {
+	authorizationURL, state, err := h.UserService.StartOIDCLogin(req.Device, req.UserAgent)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Start OIDC login failed: %v", err))
+		if errors.Is(err, service.ErrOIDCNotConfigured) {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, fmt.Errorf("failed to start OIDC login: %v", err)
+	}
+
+	return &userpb.StartOIDCLoginResponse{
+		AuthorizationUrl: authorizationURL,
+		State:            state,
+	}, nil
+}
+
+func (h *GRPCUserHandler) CompleteOIDCLogin(ctx context.Context, req *userpb.CompleteOIDCLoginRequest) (*userpb.LoginResponse, error) {
+	if req.State == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "state and code are required")
+	}
+
+	token, err := h.UserService.CompleteOIDCLogin(req.State, req.Code)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Complete OIDC login failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrOIDCNotConfigured):
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		case errors.Is(err, service.ErrInvalidOIDCState), errors.Is(err, service.ErrOIDCLoginFailed):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrOIDCEmailNotVerified):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, service.ErrOIDCEmailInUse):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, fmt.Errorf("failed to complete OIDC login: %v", err)
+	}
+
+	if token.TwoFactorToken != "" {
+		return &userpb.LoginResponse{
+			TwoFactorToken: token.TwoFactorToken,
+		}, nil
+	}
+	return &userpb.LoginResponse{
+		JwtToken:     token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int32(token.ExpiresIn),
+	}, nil
+}
+
+func (h *GRPCUserHandler) RefreshToken(ctx context.Context, req *userpb.RefreshTokenRequest) (*userpb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh token is required")
+	}
+
+	token, err := h.UserService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Refresh token failed: %v", err))
+		if errors.Is(err, session.ErrInvalidRefreshToken) || errors.Is(err, session.ErrRefreshTokenReused) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, fmt.Errorf("failed to refresh token: %v", err)
+	}
+
+	return &userpb.RefreshTokenResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    int32(token.ExpiresIn),
 	}, nil
 }
 
@@ -93,32 +259,45 @@ func (h *GRPCUserHandler) convertStringToDate(dateStr string) (time.Time, error)
 }
 
 func (h *GRPCUserHandler) EditProfile(ctx context.Context, req *userpb.EditProfileRequest) (*userpb.EditProfileResponse, error) {
-	// Validate input (you can also perform input validation in the client or using interceptors)
-	if req.FirstName == "" || req.LastName == "" || req.Birthday == "" || req.Password == "" {
-		return &userpb.EditProfileResponse{
-			Error: "All fields are required",
-		}, nil
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert birthday from string to date (assuming you have a utility for this)
-	birthday, err := h.convertStringToDate(req.Birthday)
-	if err != nil {
-		return &userpb.EditProfileResponse{
-			Error: "Invalid birthday format",
-		}, nil
+	// Convert birthday from string to date, an empty birthday is left for the service to validate
+	var birthday time.Time
+	if req.Birthday != "" {
+		var err error
+		birthday, err = h.convertStringToDate(req.Birthday)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid birthday format")
+		}
 	}
 
 	// Convert request model to entity model
 	user := entity.User{
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Birthday:  birthday,
-		Password:  req.Password,
+		ID:          userID,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Birthday:    birthday,
+		Password:    req.Password,
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		Website:     req.Website,
+		Location:    req.Location,
+		Email:       req.Email,
 	}
 
 	// Call service to update the profile
-	err = h.UserService.EditProfile(user)
+	err = h.UserService.EditProfile(user, req.GetUpdateMask().GetPaths())
 	if err != nil {
+		logger.LogError(fmt.Sprintf("Edit profile failed: %v", err))
+		if errors.Is(err, service.ErrInvalidProfile) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		return &userpb.EditProfileResponse{
 			Error: "Failed to update profile",
 		}, nil
@@ -130,3 +309,349 @@ func (h *GRPCUserHandler) EditProfile(ctx context.Context, req *userpb.EditProfi
 		Error:   "",
 	}, nil
 }
+
+func (h *GRPCUserHandler) SearchUsers(ctx context.Context, req *userpb.SearchUsersRequest) (*userpb.SearchUsersResponse, error) {
+	results, err := h.UserService.SearchUsers(viewerID(ctx), req.Query, int(req.Limit))
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Search users failed: %v", err))
+		if errors.Is(err, service.ErrInvalidSearchQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to search users: %v", err)
+	}
+
+	response := &userpb.SearchUsersResponse{}
+	for _, result := range results {
+		response.Users = append(
+			response.Users, &userpb.UserSearchResult{
+				Id:         int32(result.ID),
+				Username:   result.Username,
+				FirstName:  result.FirstName,
+				LastName:   result.LastName,
+				Following:  result.Following,
+				FollowedBy: result.FollowedBy,
+				AvatarUrl:  result.AvatarURL,
+			},
+		)
+	}
+	return response, nil
+}
+
+func (h *GRPCUserHandler) GetProfile(ctx context.Context, req *userpb.GetProfileRequest) (*userpb.GetProfileResponse, error) {
+	var profile *entity.UserProfile
+	var err error
+	if req.Username != "" {
+		profile, err = h.UserService.GetProfileByUsername(viewerID(ctx), req.Username)
+	} else {
+		profile, err = h.UserService.GetProfile(viewerID(ctx), int(req.UserId))
+	}
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Get profile failed: %v", err))
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get profile: %v", err)
+	}
+
+	return &userpb.GetProfileResponse{
+		Id:             int32(profile.ID),
+		Username:       profile.Username,
+		FirstName:      profile.FirstName,
+		LastName:       profile.LastName,
+		DisplayName:    profile.DisplayName,
+		Bio:            profile.Bio,
+		Website:        profile.Website,
+		Location:       profile.Location,
+		FollowerCount:  int32(profile.FollowerCount),
+		FollowingCount: int32(profile.FollowingCount),
+		PostCount:      int32(profile.PostCount),
+		Following:      profile.Following,
+		FollowedBy:     profile.FollowedBy,
+		Blocked:        profile.Blocked,
+		AvatarUrl:      profile.AvatarURL,
+		CoverUrl:       profile.CoverURL,
+	}, nil
+}
+
+func (h *GRPCUserHandler) BlockUser(ctx context.Context, req *userpb.BlockUserRequest) (*userpb.BlockUserResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.BlockUser(userID, int(req.BlockedUserId))
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Block user failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrCannotBlockSelf):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to block user: %v", err)
+	}
+	return &userpb.BlockUserResponse{
+		Message: "User blocked successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) UnblockUser(ctx context.Context, req *userpb.BlockUserRequest) (*userpb.BlockUserResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.UnblockUser(userID, int(req.BlockedUserId))
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Unblock user failed: %v", err))
+		return nil, fmt.Errorf("failed to unblock user: %v", err)
+	}
+	return &userpb.BlockUserResponse{
+		Message: "User unblocked successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) RequestProfileImageUpload(ctx context.Context, req *userpb.ProfileImageUploadRequest) (*userpb.ProfileImageUploadResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploadURL, objectKey, err := h.UserService.RequestProfileImageUpload(userID, req.Kind)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Request profile image upload failed: %v", err))
+		if errors.Is(err, service.ErrInvalidProfileImageKind) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to request profile image upload: %v", err)
+	}
+	return &userpb.ProfileImageUploadResponse{
+		UploadUrl: uploadURL,
+		ObjectKey: objectKey,
+	}, nil
+}
+
+func (h *GRPCUserHandler) ConfirmProfileImage(ctx context.Context, req *userpb.ConfirmProfileImageRequest) (*userpb.ConfirmProfileImageResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	url, err := h.UserService.ConfirmProfileImage(userID, req.Kind)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Confirm profile image failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrInvalidProfileImageKind), errors.Is(err, service.ErrInvalidProfileImage):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrProfileImageNotUploaded):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to confirm profile image: %v", err)
+	}
+	return &userpb.ConfirmProfileImageResponse{Url: url}, nil
+}
+
+func (h *GRPCUserHandler) RequestPasswordReset(ctx context.Context, req *userpb.RequestPasswordResetRequest) (*userpb.RequestPasswordResetResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	err := h.UserService.RequestPasswordReset(req.Email, req.ClientIp)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Request password reset failed: %v", err))
+		if errors.Is(err, service.ErrTooManyResetRequests) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, fmt.Errorf("failed to request password reset: %v", err)
+	}
+	return &userpb.RequestPasswordResetResponse{
+		Message: "If an account exists for this email, a password reset link is being sent",
+	}, nil
+}
+
+func (h *GRPCUserHandler) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.ResetPasswordResponse, error) {
+	err := h.UserService.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Reset password failed: %v", err))
+		if errors.Is(err, service.ErrInvalidResetToken) || errors.Is(err, service.ErrInvalidProfile) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to reset password: %v", err)
+	}
+	return &userpb.ResetPasswordResponse{
+		Message: "Password reset successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	err := h.UserService.VerifyEmail(req.Token)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Verify email failed: %v", err))
+		if errors.Is(err, service.ErrInvalidVerificationToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("failed to verify email: %v", err)
+	}
+	return &userpb.VerifyEmailResponse{
+		Message: "Email verified successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) ResendVerification(ctx context.Context, req *userpb.ResendVerificationRequest) (*userpb.ResendVerificationResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.ResendVerification(userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Resend verification failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrVerificationRecentlySent):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to resend verification: %v", err)
+	}
+	return &userpb.ResendVerificationResponse{
+		Message: "Verification email sent",
+	}, nil
+}
+
+func (h *GRPCUserHandler) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.Logout(userID, req.SessionId)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Logout failed: %v", err))
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to logout: %v", err)
+	}
+	return &userpb.LogoutResponse{
+		Message: "Logged out successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) ListSessions(ctx context.Context, req *userpb.ListSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := h.UserService.ListSessions(userID, req.CurrentSessionId)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("List sessions failed: %v", err))
+		return nil, fmt.Errorf("failed to list sessions: %v", err)
+	}
+
+	response := &userpb.ListSessionsResponse{}
+	for _, userSession := range sessions {
+		response.Sessions = append(
+			response.Sessions, &userpb.Session{
+				Id:        userSession.ID,
+				Device:    userSession.Device,
+				UserAgent: userSession.UserAgent,
+				CreatedAt: userSession.CreatedAt.Format(time.RFC3339),
+				ExpiresAt: userSession.ExpiresAt.Format(time.RFC3339),
+				Current:   userSession.Current,
+			},
+		)
+	}
+	return response, nil
+}
+
+func (h *GRPCUserHandler) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.RevokeSessionResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.RevokeSession(userID, req.SessionId)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Revoke session failed: %v", err))
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to revoke session: %v", err)
+	}
+	return &userpb.RevokeSessionResponse{
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+func (h *GRPCUserHandler) CreateAPIKey(ctx context.Context, req *userpb.CreateAPIKeyRequest) (*userpb.CreateAPIKeyResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := h.UserService.CreateAPIKey(userID, req.Name, req.Scope, int(req.ExpiresInDays))
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Create API key failed: %v", err))
+		switch {
+		case errors.Is(err, service.ErrInvalidAPIKeyRequest):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrTooManyAPIKeys):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to create API key: %v", err)
+	}
+
+	return &userpb.CreateAPIKeyResponse{
+		ApiKey: apiKeyToProto(*apiKey),
+		Key:    apiKey.Key,
+	}, nil
+}
+
+func (h *GRPCUserHandler) ListAPIKeys(ctx context.Context, req *userpb.ListAPIKeysRequest) (*userpb.ListAPIKeysResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiKeys, err := h.UserService.ListAPIKeys(userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("List API keys failed: %v", err))
+		return nil, fmt.Errorf("failed to list API keys: %v", err)
+	}
+
+	response := &userpb.ListAPIKeysResponse{}
+	for _, apiKey := range apiKeys {
+		response.ApiKeys = append(response.ApiKeys, apiKeyToProto(apiKey))
+	}
+	return response, nil
+}
+
+func (h *GRPCUserHandler) RevokeAPIKey(ctx context.Context, req *userpb.RevokeAPIKeyRequest) (*userpb.RevokeAPIKeyResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.UserService.RevokeAPIKey(userID, req.ApiKeyId)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Revoke API key failed: %v", err))
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to revoke API key: %v", err)
+	}
+	return &userpb.RevokeAPIKeyResponse{
+		Message: "API key revoked successfully",
+	}, nil
+}
+
+func apiKeyToProto(apiKey entity.APIKey) *userpb.APIKey {
+	result := &userpb.APIKey{
+		Id:        apiKey.ID,
+		Name:      apiKey.Name,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: apiKey.ExpiresAt.Format(time.RFC3339),
+	}
+	if apiKey.LastUsedAt != nil {
+		result.LastUsedAt = apiKey.LastUsedAt.Format(time.RFC3339)
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"news-feed/pkg/logger"
+	"news-feed/pkg/middleware"
+)
+
+// JWKSHandler serves the public keys JWTs are verified with.
+//
+// @Summary JSON Web Key Set
+// @Description Returns the public keys access tokens are signed with, identified by the "kid" token header. Keys may be cached for a few minutes, new keys are published before they are used for signing.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} middleware.JWKSet "Verification keys"
+// @Router /.well-known/jwks.json [get]
+func JWKSHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		err := json.NewEncoder(w).Encode(middleware.JWKS())
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode JWKS: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
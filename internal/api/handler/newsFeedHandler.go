@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"news-feed/internal/api/generated/news-feed/newsfeedpb"
@@ -28,7 +27,7 @@ type NewsfeedHandler struct {
 // @Router /v1/newsfeed [get]
 func (h *NewsfeedHandler) GetNewsfeed() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		posts, err := h.newsFeedService.GetNewsfeed(context.Background(), &newsfeedpb.GetNewsfeedRequest{})
+		posts, err := h.newsFeedService.GetNewsfeed(r.Context(), &newsfeedpb.GetNewsfeedRequest{})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
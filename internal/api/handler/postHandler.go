@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
@@ -9,10 +8,12 @@ import (
 	_ "news-feed/docs"
 	"news-feed/internal/api/generated/news-feed/postpb"
 	"news-feed/internal/api/model"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/logger"
 	"news-feed/pkg/middleware"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type PostHandlerInterface interface {
@@ -21,11 +22,23 @@ type PostHandlerInterface interface {
 	EditPost() http.HandlerFunc
 	DeletePost() http.HandlerFunc
 	CommentOnPost() http.HandlerFunc
+	EditComment() http.HandlerFunc
+	DeleteComment() http.HandlerFunc
 	LikePost() http.HandlerFunc
+	LikeComment() http.HandlerFunc
 	PostHandler(w http.ResponseWriter, r *http.Request)
 	GetComments() http.HandlerFunc
+	GetCommentReplies() http.HandlerFunc
 	GetLikes() http.HandlerFunc
 	GetLikesCount() http.HandlerFunc
+	SavePost() http.HandlerFunc
+	UnsavePost() http.HandlerFunc
+	ListSavedPosts() http.HandlerFunc
+	BookmarksHandler(w http.ResponseWriter, r *http.Request)
+	PinPost() http.HandlerFunc
+	UnpinPost() http.HandlerFunc
+	VotePoll() http.HandlerFunc
+	SearchPosts() http.HandlerFunc
 }
 
 type PostHandler struct {
@@ -44,19 +57,21 @@ func (h *PostHandler) PostHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
 		if len(parts) == 4 {
-			middleware.JWTAuthMiddleware(h.GetPost()).ServeHTTP(w, r)
+			middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetPost()).ServeHTTP(w, r)
 		} else if len(parts) == 5 {
 			if parts[4] == "comments" {
-				middleware.JWTAuthMiddleware(h.GetComments()).ServeHTTP(w, r)
+				middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetComments()).ServeHTTP(w, r)
 			} else if parts[4] == "likes" {
-				middleware.JWTAuthMiddleware(h.GetLikes()).ServeHTTP(w, r)
+				middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetLikes()).ServeHTTP(w, r)
 			} else {
 				http.NotFound(w, r)
 			}
 		} else if len(parts) == 6 {
 			if parts[4] == "likes" && parts[5] == "count" {
-				middleware.JWTAuthMiddleware(h.GetLikesCount()).ServeHTTP(w, r)
+				middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetLikesCount()).ServeHTTP(w, r)
 			}
+		} else if len(parts) == 7 && parts[4] == "comments" && parts[6] == "replies" {
+			middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetCommentReplies()).ServeHTTP(w, r)
 		} else {
 			http.NotFound(w, r)
 		}
@@ -66,20 +81,32 @@ func (h *PostHandler) PostHandler(w http.ResponseWriter, r *http.Request) {
 			middleware.JWTAuthMiddleware(h.CommentOnPost()).ServeHTTP(w, r)
 		} else if len(parts) == 5 && parts[4] == "likes" {
 			middleware.JWTAuthMiddleware(h.LikePost()).ServeHTTP(w, r)
+		} else if len(parts) == 7 && parts[4] == "comments" && parts[6] == "likes" {
+			middleware.JWTAuthMiddleware(h.LikeComment()).ServeHTTP(w, r)
+		} else if len(parts) == 5 && parts[4] == "pin" {
+			middleware.JWTAuthMiddleware(h.PinPost()).ServeHTTP(w, r)
+		} else if len(parts) == 6 && parts[4] == "poll" && parts[5] == "votes" {
+			middleware.JWTAuthMiddleware(h.VotePoll()).ServeHTTP(w, r)
 		} else {
 			http.Error(w, "Not Found", http.StatusNotFound)
 		}
 
 	case http.MethodPut:
-		if len(parts) == 3 {
+		if len(parts) == 4 {
 			middleware.JWTAuthMiddleware(h.EditPost()).ServeHTTP(w, r)
+		} else if len(parts) == 6 && parts[4] == "comments" {
+			middleware.JWTAuthMiddleware(h.EditComment()).ServeHTTP(w, r)
 		} else {
 			http.Error(w, "Not Found", http.StatusNotFound)
 		}
 
 	case http.MethodDelete:
-		if len(parts) == 3 {
+		if len(parts) == 4 {
 			middleware.JWTAuthMiddleware(h.DeletePost()).ServeHTTP(w, r)
+		} else if len(parts) == 6 && parts[4] == "comments" {
+			middleware.JWTAuthMiddleware(h.DeleteComment()).ServeHTTP(w, r)
+		} else if len(parts) == 5 && parts[4] == "pin" {
+			middleware.JWTAuthMiddleware(h.UnpinPost()).ServeHTTP(w, r)
 		} else {
 			http.Error(w, "Not Found", http.StatusNotFound)
 		}
@@ -111,11 +138,30 @@ func (h *PostHandler) CreatePost() http.HandlerFunc {
 			return
 		}
 
+		commentPermission, ok := parseCommentPermission(request.CommentPermission)
+		if !ok {
+			http.Error(w, "Invalid comment permission", http.StatusBadRequest)
+			return
+		}
+
 		req := &postpb.CreatePostRequest{
-			Text:     request.Text,
-			HasImage: request.HasImage,
+			Text:              request.Text,
+			HasImage:          request.HasImage,
+			CommentPermission: commentPermission,
+		}
+		if request.Poll != nil {
+			req.Poll = &postpb.PollInput{
+				Options:        request.Poll.Options,
+				ClosesAt:       request.Poll.ClosesAt.Format(time.RFC3339),
+				MultipleChoice: request.Poll.MultipleChoice,
+			}
+		}
+		resp, err := h.grpcPostHandler.CreatePost(r.Context(), req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to create post: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
 		}
-		resp, err := h.grpcPostHandler.CreatePost(context.Background(), req)
 		response := map[string]interface{}{
 			"preSignedURL": resp.PreSignedURL,
 		}
@@ -129,6 +175,21 @@ func (h *PostHandler) CreatePost() http.HandlerFunc {
 	}
 }
 
+// parseCommentPermission converts the comment permission of a request payload to its gRPC enum, empty means everyone.
+func parseCommentPermission(permission string) (postpb.CommentPermission, bool) {
+	switch permission {
+	case "", "everyone":
+		return postpb.CommentPermission_COMMENT_PERMISSION_EVERYONE, true
+	case "followers":
+		return postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWERS, true
+	case "following":
+		return postpb.CommentPermission_COMMENT_PERMISSION_FOLLOWING, true
+	case "disabled":
+		return postpb.CommentPermission_COMMENT_PERMISSION_DISABLED, true
+	}
+	return postpb.CommentPermission_COMMENT_PERMISSION_EVERYONE, false
+}
+
 // generateUniqueFileName generates a unique file name based on a UUID and the desired file extension.
 func (h *PostHandler) generateUniqueFileName() string {
 	// Generate a UUID
@@ -160,11 +221,15 @@ func (h *PostHandler) GetPost() http.HandlerFunc {
 			return
 		}
 
+		// The viewer is used to decide whether poll results are revealed
+		viewerID, _ := auth.UserID(r.Context())
+
 		req := postpb.GetPostRequest{
-			PostId: int32(postID),
+			PostId:   int32(postID),
+			ViewerId: int32(viewerID),
 		}
 
-		post, err := h.grpcPostHandler.GetPost(context.Background(), &req)
+		post, err := h.grpcPostHandler.GetPost(r.Context(), &req)
 
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to get post: %v", err))
@@ -193,6 +258,7 @@ func (h *PostHandler) GetPost() http.HandlerFunc {
 // @Param request body model.EditPostRequest true "Updated post data"
 // @Success 200 {object} map[string]string "success response"
 // @Failure 400 {object} string "Invalid post ID or request payload"
+// @Failure 403 {object} string "Not the author of the post"
 // @Failure 404 {object} string "Post not found"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/posts/{post_id} [put]
@@ -218,12 +284,20 @@ func (h *PostHandler) EditPost() http.HandlerFunc {
 			ContentText: request.Text,
 			HasImage:    request.HasImage,
 		}
+		if request.CommentPermission != "" {
+			commentPermission, ok := parseCommentPermission(request.CommentPermission)
+			if !ok {
+				http.Error(w, "Invalid comment permission", http.StatusBadRequest)
+				return
+			}
+			req.CommentPermission = &commentPermission
+		}
 
 		// Call service to update the post
-		response, err := h.grpcPostHandler.EditPost(context.Background(), &req)
+		response, err := h.grpcPostHandler.EditPost(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to update post: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
 			return
 		}
 
@@ -260,7 +334,7 @@ func (h *PostHandler) DeletePost() http.HandlerFunc {
 		}
 
 		// Retrieve the user ID from the context
-		userID, ok := r.Context().Value("userID").(int)
+		userID, ok := auth.UserID(r.Context())
 		if !ok {
 			logger.LogError(fmt.Sprintf("User ID not found int context"))
 			http.Error(w, "User ID not found in context", http.StatusInternalServerError)
@@ -272,7 +346,7 @@ func (h *PostHandler) DeletePost() http.HandlerFunc {
 			UserId: int32(userID),
 		}
 
-		response, err := h.grpcPostHandler.DeletePost(context.Background(), &req)
+		response, err := h.grpcPostHandler.DeletePost(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to delete post: %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -299,13 +373,14 @@ func (h *PostHandler) DeletePost() http.HandlerFunc {
 // @Param post_id path int true "Post ID"
 // @Param request body model.CommentOnPostRequest true "Comment data"
 // @Success 200 {object} entity.Comment "Comment data"
-// @Failure 400 {object} string "Invalid post ID or request payload"
+// @Failure 400 {object} string "Invalid post ID, request payload or parent comment, or replies nested too deep"
+// @Failure 403 {object} string "Comments not allowed for this user"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/posts/{post_id}/comments [post]
 func (h *PostHandler) CommentOnPost() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get the current user ID from the request context (assumes middleware has set it)
-		currentUserID, ok := r.Context().Value("userID").(int)
+		currentUserID, ok := auth.UserID(r.Context())
 		if !ok {
 			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -328,15 +403,16 @@ func (h *PostHandler) CommentOnPost() http.HandlerFunc {
 		}
 
 		req := postpb.CommentOnPostRequest{
-			PostId: int32(postID),
-			UserId: int32(currentUserID),
-			Text:   commentRequest.Text,
+			PostId:          int32(postID),
+			UserId:          int32(currentUserID),
+			Text:            commentRequest.Text,
+			ParentCommentId: int32(commentRequest.ParentCommentID),
 		}
 
-		createdComment, err := h.grpcPostHandler.CommentOnPost(context.Background(), &req)
+		createdComment, err := h.grpcPostHandler.CommentOnPost(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to comment on post: %v", err))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
 			return
 		}
 
@@ -350,6 +426,137 @@ func (h *PostHandler) CommentOnPost() http.HandlerFunc {
 	}
 }
 
+// EditComment updates the text of a comment.
+//
+// @Summary Edit a comment
+// @Description Updates the text of a comment. Only the comment author may edit it.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param post_id path int true "Post ID"
+// @Param comment_id path int true "Comment ID"
+// @Param request body model.EditCommentRequest true "Updated comment data"
+// @Success 200 {object} entity.Comment "Comment data"
+// @Failure 400 {object} string "Invalid comment ID or request payload"
+// @Failure 403 {object} string "Not allowed to edit the comment"
+// @Failure 404 {object} string "Comment not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/comments/{comment_id} [put]
+func (h *PostHandler) EditComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+		commentID, err := strconv.Atoi(pathParts[5])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid comment id %v", err))
+			http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+			return
+		}
+
+		var commentRequest model.EditCommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&commentRequest); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to decode JSON: %v", err))
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.EditCommentRequest{
+			PostId:    int32(postID),
+			CommentId: int32(commentID),
+			UserId:    int32(currentUserID),
+			Text:      commentRequest.Text,
+		}
+
+		response, err := h.grpcPostHandler.EditComment(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to edit comment: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response.Comment)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// DeleteComment removes a comment and its replies.
+//
+// @Summary Delete a comment
+// @Description Deletes a comment and its replies. The comment author and the post owner may delete it.
+// @Tags posts
+// @Param post_id path int true "Post ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid comment ID"
+// @Failure 403 {object} string "Not allowed to delete the comment"
+// @Failure 404 {object} string "Comment not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/comments/{comment_id} [delete]
+func (h *PostHandler) DeleteComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+		commentID, err := strconv.Atoi(pathParts[5])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid comment id %v", err))
+			http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.DeleteCommentRequest{
+			PostId:    int32(postID),
+			CommentId: int32(commentID),
+			UserId:    int32(currentUserID),
+		}
+
+		response, err := h.grpcPostHandler.DeleteComment(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to delete comment: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
 // LikePost allows a user to like a specific post.
 //
 // @Summary Like a post
@@ -363,7 +570,7 @@ func (h *PostHandler) CommentOnPost() http.HandlerFunc {
 func (h *PostHandler) LikePost() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get the current user ID from the request context (assumes middleware has set it)
-		currentUserID, ok := r.Context().Value("userID").(int)
+		currentUserID, ok := auth.UserID(r.Context())
 		if !ok {
 			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -383,7 +590,7 @@ func (h *PostHandler) LikePost() http.HandlerFunc {
 			UserId: int32(currentUserID),
 		}
 
-		response, err := h.grpcPostHandler.LikePost(context.Background(), &req)
+		response, err := h.grpcPostHandler.LikePost(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to like post: %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -407,10 +614,11 @@ func (h *PostHandler) LikePost() http.HandlerFunc {
 // @Tags posts
 // @Produce json
 // @Param post_id path int true "Post ID"
-// @Param cursor query int false "Cursor for pagination"
+// @Param sort query string false "Ordering of the comments" Enums(chronological, top)
+// @Param cursor query string false "Cursor for pagination, a comment ID for chronological or next_top_cursor for top"
 // @Param limit query int false "Limit for pagination"
 // @Success 200 {array} entity.Comment "List of comments"
-// @Failure 400 {object} string "Invalid post ID"
+// @Failure 400 {object} string "Invalid post ID or cursor"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/posts/{post_id}/comments [get]
 func (h *PostHandler) GetComments() http.HandlerFunc {
@@ -428,6 +636,138 @@ func (h *PostHandler) GetComments() http.HandlerFunc {
 			limit = l
 		}
 
+		req := postpb.GetCommentsRequest{
+			PostId: int32(postID),
+			Limit:  int32(limit),
+		}
+
+		switch r.URL.Query().Get("sort") {
+		case "", "chronological":
+			cursor := 0
+			if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+				cursor, err = strconv.Atoi(cursorStr)
+				if err != nil {
+					logger.LogError(fmt.Sprintf("Invalid cursor %v", err))
+					http.Error(w, "Invalid cursor", http.StatusBadRequest)
+					return
+				}
+			}
+			req.Cursor = int32(cursor)
+		case "top":
+			req.Sort = postpb.CommentSort_COMMENT_SORT_TOP
+			req.TopCursor = r.URL.Query().Get("cursor")
+		default:
+			http.Error(w, "Invalid sort", http.StatusBadRequest)
+			return
+		}
+
+		response, err := h.grpcPostHandler.GetComments(r.Context(), &req)
+
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get comments: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// LikeComment allows a user to like a specific comment.
+//
+// @Summary Like a comment
+// @Description Allows a user to like the specified comment.
+// @Tags posts
+// @Param post_id path int true "Post ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid comment ID"
+// @Failure 404 {object} string "Comment not found"
+// @Failure 409 {object} string "Comment already liked"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/comments/{comment_id}/likes [post]
+func (h *PostHandler) LikeComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+		commentID, err := strconv.Atoi(pathParts[5])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid comment id %v", err))
+			http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.LikeCommentRequest{
+			PostId:    int32(postID),
+			CommentId: int32(commentID),
+			UserId:    int32(currentUserID),
+		}
+
+		response, err := h.grpcPostHandler.LikeComment(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to like comment: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetCommentReplies retrieves replies to a specific comment.
+//
+// @Summary Get replies to a comment
+// @Description Retrieves the direct replies to the specified comment with pagination.
+// @Tags posts
+// @Produce json
+// @Param post_id path int true "Post ID"
+// @Param comment_id path int true "Comment ID"
+// @Param cursor query int false "Cursor for pagination"
+// @Param limit query int false "Limit for pagination"
+// @Success 200 {array} entity.Comment "List of replies"
+// @Failure 400 {object} string "Invalid comment ID"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/comments/{comment_id}/replies [get]
+func (h *PostHandler) GetCommentReplies() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(r.URL.Path, "/")
+		commentID, err := strconv.Atoi(pathParts[5])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid comment ID: %v", err))
+			http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+			return
+		}
+
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+			limit = l
+		}
+
 		cursor := 0
 		if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
 			cursor, err = strconv.Atoi(cursorStr)
@@ -438,16 +778,15 @@ func (h *PostHandler) GetComments() http.HandlerFunc {
 			}
 		}
 
-		req := postpb.GetCommentsRequest{
-			PostId: int32(postID),
-			Cursor: int32(cursor),
-			Limit:  int32(limit),
+		req := postpb.GetCommentRepliesRequest{
+			CommentId: int32(commentID),
+			Cursor:    int32(cursor),
+			Limit:     int32(limit),
 		}
 
-		response, err := h.grpcPostHandler.GetComments(context.Background(), &req)
-
+		response, err := h.grpcPostHandler.GetCommentReplies(r.Context(), &req)
 		if err != nil {
-			logger.LogError(fmt.Sprintf("Failed to get comments: %v", err))
+			logger.LogError(fmt.Sprintf("Failed to get replies: %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -494,7 +833,7 @@ func (h *PostHandler) GetLikes() http.HandlerFunc {
 			Limit:  int32(limit),
 			Cursor: cursorStr,
 		}
-		response, err := h.grpcPostHandler.GetLikes(context.Background(), &req)
+		response, err := h.grpcPostHandler.GetLikes(r.Context(), &req)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to get likes for post: %v", err))
 			return
@@ -532,7 +871,7 @@ func (h *PostHandler) GetLikesCount() http.HandlerFunc {
 		req := postpb.GetLikesCountRequest{
 			PostId: int32(postID),
 		}
-		response, err := h.grpcPostHandler.GetLikesCount(context.Background(), &req)
+		response, err := h.grpcPostHandler.GetLikesCount(r.Context(), &req)
 		// Respond with the like count
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(response)
@@ -543,3 +882,454 @@ func (h *PostHandler) GetLikesCount() http.HandlerFunc {
 		}
 	}
 }
+
+// BookmarksHandler routes the current user's bookmark endpoints under /v1/me/bookmarks.
+func (h *PostHandler) BookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+
+	if len(parts) < 4 || parts[2] != "me" || parts[3] != "bookmarks" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if len(parts) == 4 {
+			middleware.JWTAuthMiddleware(h.ListSavedPosts()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+
+	case http.MethodPost:
+		if len(parts) == 4 {
+			middleware.JWTAuthMiddleware(h.SavePost()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+
+	case http.MethodDelete:
+		if len(parts) == 5 {
+			middleware.JWTAuthMiddleware(h.UnsavePost()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// SavePost bookmarks a post for the current user.
+//
+// @Summary Save a post
+// @Description Bookmarks a post for the current user, optionally filed under a named collection.
+// @Tags bookmarks
+// @Accept json
+// @Produce json
+// @Param request body model.SavePostRequest true "Bookmark data"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid request payload"
+// @Failure 404 {object} string "Post not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/bookmarks [post]
+func (h *PostHandler) SavePost() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var request model.SavePostRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to decode JSON: %v", err))
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.SavePostRequest{
+			PostId:     int32(request.PostID),
+			UserId:     int32(currentUserID),
+			Collection: request.Collection,
+		}
+
+		response, err := h.grpcPostHandler.SavePost(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to save post: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// UnsavePost removes a post from the current user's bookmarks.
+//
+// @Summary Unsave a post
+// @Description Removes a post from the current user's bookmarks.
+// @Tags bookmarks
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid post ID"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/bookmarks/{post_id} [delete]
+func (h *PostHandler) UnsavePost() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[4])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.UnsavePostRequest{
+			PostId: int32(postID),
+			UserId: int32(currentUserID),
+		}
+
+		response, err := h.grpcPostHandler.UnsavePost(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to unsave post: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ListSavedPosts lists the current user's bookmarked posts.
+//
+// @Summary List saved posts
+// @Description Lists the current user's bookmarked posts newest first, optionally limited to one collection.
+// @Tags bookmarks
+// @Produce json
+// @Param collection query string false "Collection name"
+// @Param cursor query int false "Cursor for pagination"
+// @Param limit query int false "Limit of saved posts"
+// @Success 200 {object} postpb.ListSavedPostsResponse "Saved posts"
+// @Failure 400 {object} string "Invalid cursor"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/bookmarks [get]
+func (h *PostHandler) ListSavedPosts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		cursor := 0
+		if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+			var err error
+			cursor, err = strconv.Atoi(cursorStr)
+			if err != nil {
+				logger.LogError(fmt.Sprintf("Invalid cursor %v", err))
+				http.Error(w, "Invalid cursor", http.StatusBadRequest)
+				return
+			}
+		}
+
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+			limit = l
+		}
+
+		req := postpb.ListSavedPostsRequest{
+			UserId:     int32(currentUserID),
+			Collection: r.URL.Query().Get("collection"),
+			Cursor:     int32(cursor),
+			Limit:      int32(limit),
+		}
+
+		response, err := h.grpcPostHandler.ListSavedPosts(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to list saved posts: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// PinPost pins one of the current user's posts to the top of their profile.
+//
+// @Summary Pin a post
+// @Description Pins one of the current user's posts to the top of their profile, up to three posts.
+// @Tags posts
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid post ID"
+// @Failure 403 {object} string "Post owned by another user"
+// @Failure 404 {object} string "Post not found"
+// @Failure 409 {object} string "Pinned post limit reached"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/pin [post]
+func (h *PostHandler) PinPost() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.PinPostRequest{
+			PostId: int32(postID),
+			UserId: int32(currentUserID),
+		}
+
+		response, err := h.grpcPostHandler.PinPost(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to pin post: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// UnpinPost removes one of the current user's posts from the top of their profile.
+//
+// @Summary Unpin a post
+// @Description Removes one of the current user's posts from the top of their profile.
+// @Tags posts
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} map[string]string "success message"
+// @Failure 400 {object} string "Invalid post ID"
+// @Failure 403 {object} string "Post owned by another user"
+// @Failure 404 {object} string "Post not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/pin [delete]
+func (h *PostHandler) UnpinPost() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.UnpinPostRequest{
+			PostId: int32(postID),
+			UserId: int32(currentUserID),
+		}
+
+		response, err := h.grpcPostHandler.UnpinPost(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to unpin post: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VotePoll records the current user's vote on the poll of a post.
+//
+// @Summary Vote on a poll
+// @Description Votes for one option of a single choice poll, or one or more options of a multiple choice poll, and returns the results.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param post_id path int true "Post ID"
+// @Param request body model.VotePollRequest true "Vote data"
+// @Success 200 {object} postpb.VotePollResponse "Poll results"
+// @Failure 400 {object} string "Invalid post ID or options"
+// @Failure 404 {object} string "Poll not found"
+// @Failure 409 {object} string "Already voted or poll closed"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/posts/{post_id}/poll/votes [post]
+func (h *PostHandler) VotePoll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		postID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid post id %v", err))
+			http.Error(w, "Invalid post ID", http.StatusBadRequest)
+			return
+		}
+
+		var request model.VotePollRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to decode JSON: %v", err))
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := postpb.VotePollRequest{
+			PostId: int32(postID),
+			UserId: int32(currentUserID),
+		}
+		for _, optionID := range request.OptionIDs {
+			req.OptionIds = append(req.OptionIds, int32(optionID))
+		}
+
+		response, err := h.grpcPostHandler.VotePoll(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to vote on poll: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// SearchPosts searches visible posts by their text.
+//
+// @Summary Search posts
+// @Description Full-text search over posts, newest first. Every term must match, "quoted phrases" match exactly and terms ending in * match by prefix.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param author_id query int false "Only posts by this user"
+// @Param from query string false "Only posts created at or after this date (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only posts created before this date (RFC3339 or YYYY-MM-DD)"
+// @Param cursor query int false "Cursor for pagination"
+// @Param limit query int false "Limit of posts"
+// @Success 200 {object} postpb.SearchPostsResponse "Matching posts"
+// @Failure 400 {object} string "Invalid query or filters"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/search/posts [get]
+func (h *PostHandler) SearchPosts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		req := postpb.SearchPostsRequest{
+			Query: query.Get("q"),
+			Limit: 10,
+		}
+		if req.Query == "" {
+			http.Error(w, "Missing search query", http.StatusBadRequest)
+			return
+		}
+
+		for name, target := range map[string]*int32{"author_id": &req.AuthorId, "cursor": &req.Cursor, "limit": &req.Limit} {
+			if value := query.Get(name); value != "" {
+				number, err := strconv.Atoi(value)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("Invalid %s", name), http.StatusBadRequest)
+					return
+				}
+				*target = int32(number)
+			}
+		}
+		for name, target := range map[string]*string{"from": &req.From, "to": &req.To} {
+			if value := query.Get(name); value != "" {
+				date, err := parseSearchDate(value)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("Invalid %s date", name), http.StatusBadRequest)
+					return
+				}
+				*target = date.Format(time.RFC3339)
+			}
+		}
+
+		response, err := h.grpcPostHandler.SearchPosts(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to search posts: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// parseSearchDate accepts an RFC3339 time or a plain YYYY-MM-DD date.
+func parseSearchDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
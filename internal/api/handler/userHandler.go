@@ -1,16 +1,21 @@
 package handler
 
 import (
-	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"net"
 	"net/http"
 	_ "news-feed/docs"
 	"news-feed/internal/api/generated/news-feed/userpb"
 	"news-feed/internal/api/model"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/logger"
 	"news-feed/pkg/metrics"
 	"news-feed/pkg/middleware"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,6 +24,32 @@ type UserHandlerInterface interface {
 	Signup() http.HandlerFunc
 	EditProfile() http.HandlerFunc
 	UserHandler(w http.ResponseWriter, r *http.Request)
+	SearchUsers() http.HandlerFunc
+	ProfileHandler(w http.ResponseWriter, r *http.Request)
+	GetProfile() http.HandlerFunc
+	BlockUser() http.HandlerFunc
+	UnblockUser() http.HandlerFunc
+	ProfileImageHandler(w http.ResponseWriter, r *http.Request)
+	RequestProfileImageUpload() http.HandlerFunc
+	ConfirmProfileImage() http.HandlerFunc
+	RequestPasswordReset() http.HandlerFunc
+	ResetPassword() http.HandlerFunc
+	VerifyEmail() http.HandlerFunc
+	ResendVerification() http.HandlerFunc
+	Logout() http.HandlerFunc
+	RefreshToken() http.HandlerFunc
+	EnrollTOTP() http.HandlerFunc
+	ActivateTOTP() http.HandlerFunc
+	VerifyTwoFactor() http.HandlerFunc
+	OIDCLogin() http.HandlerFunc
+	OIDCCallback() http.HandlerFunc
+	SessionsHandler(w http.ResponseWriter, r *http.Request)
+	ListSessions() http.HandlerFunc
+	RevokeSession() http.HandlerFunc
+	APIKeysHandler(w http.ResponseWriter, r *http.Request)
+	CreateAPIKey() http.HandlerFunc
+	ListAPIKeys() http.HandlerFunc
+	RevokeAPIKey() http.HandlerFunc
 }
 
 // UserHandler handles requests related to users.
@@ -29,7 +60,7 @@ type UserHandler struct {
 func (h *UserHandler) UserHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		h.Signup()
+		h.Signup().ServeHTTP(w, r)
 	case http.MethodPut:
 		middleware.JWTAuthMiddleware(h.EditProfile()).ServeHTTP(w, r)
 	default:
@@ -37,17 +68,134 @@ func (h *UserHandler) UserHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *UserHandler) ProfileHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	if len(parts) < 4 || parts[2] != "users" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if len(parts) == 4 || (len(parts) == 5 && parts[3] == "by-username") {
+			middleware.APIKeyScopeMiddleware(auth.ScopeRead, h.GetProfile()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	case http.MethodPost:
+		if len(parts) == 5 && parts[4] == "block" {
+			middleware.JWTAuthMiddleware(h.BlockUser()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	case http.MethodDelete:
+		if len(parts) == 5 && parts[4] == "block" {
+			middleware.JWTAuthMiddleware(h.UnblockUser()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *UserHandler) ProfileImageHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	if len(parts) != 5 || parts[2] != "me" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		if parts[4] == "upload-url" {
+			middleware.JWTAuthMiddleware(h.RequestProfileImageUpload()).ServeHTTP(w, r)
+		} else if parts[4] == "confirm" {
+			middleware.JWTAuthMiddleware(h.ConfirmProfileImage()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *UserHandler) SessionsHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	if len(parts) < 4 || parts[2] != "me" || parts[3] != "sessions" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if len(parts) == 4 {
+			middleware.JWTAuthMiddleware(h.ListSessions()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	case http.MethodDelete:
+		if len(parts) == 5 {
+			middleware.JWTAuthMiddleware(h.RevokeSession()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *UserHandler) APIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	if len(parts) < 4 || parts[2] != "me" || parts[3] != "api-keys" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if len(parts) == 4 {
+			middleware.JWTAuthMiddleware(h.ListAPIKeys()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	case http.MethodPost:
+		if len(parts) == 4 {
+			middleware.JWTAuthMiddleware(h.CreateAPIKey()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	case http.MethodDelete:
+		if len(parts) == 5 {
+			middleware.JWTAuthMiddleware(h.RevokeAPIKey()).ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // Login handles user login.
 //
 // @Summary User login
-// @Description Authenticates a user and returns a token.
+// @Description Authenticates a user and returns a token. Accounts with two-factor authentication get a two_factor_token instead, exchanged for the tokens at /v1/auth/2fa/verify. Failed attempts are limited per username and IP address, failure responses tell whether a CAPTCHA is required and how long to wait when locked out.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param credentials body model.LoginRequest true "User credentials"
-// @Success 200 {object} map[string]string "JWT token"
+// @Success 200 {object} map[string]interface{} "JWT access token, refresh token and access token lifetime"
 // @Failure 400 {object} string "Invalid request payload"
-// @Failure 401 {object} string "Unauthorized"
+// @Failure 401 {object} model.LoginFailureResponse "Invalid credentials"
+// @Failure 429 {object} model.LoginFailureResponse "Too many failed attempts"
 // @Router /v1/users/login [post]
 func (h *UserHandler) Login() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -62,22 +210,63 @@ func (h *UserHandler) Login() http.HandlerFunc {
 
 		// Prepare the gRPC request
 		req := &userpb.LoginRequest{
-			UserName: credentials.UserName,
-			Password: credentials.Password,
+			UserName:  credentials.UserName,
+			Password:  credentials.Password,
+			UserAgent: r.UserAgent(),
+			Device:    credentials.Device,
+			ClientIp:  clientIP(r),
 		}
 
 		// Call gRPC Login method
-		resp, err := h.grpcUserHandler.Login(context.Background(), req)
+		resp, err := h.grpcUserHandler.Login(r.Context(), req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Login failed: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			metrics.RecordLoginAttempt("error", false)
+			metrics.RecordLoginLatency("failure", time.Since(startTime).Seconds()*1000)
+			return
+		}
 		if resp.Error != "" {
 			logger.LogError(fmt.Sprintf("Login failed: %v", resp.Error))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			failure := model.LoginFailureResponse{
+				Error:             "Unauthorized",
+				CaptchaRequired:   resp.CaptchaRequired,
+				RetryAfterSeconds: int(resp.RetryAfterSeconds),
+			}
+			statusCode := http.StatusUnauthorized
+			if resp.RetryAfterSeconds > 0 {
+				failure.Error = "Too many failed login attempts"
+				statusCode = http.StatusTooManyRequests
+				w.Header().Set("Retry-After", strconv.Itoa(int(resp.RetryAfterSeconds)))
+				metrics.RecordLoginAttempt("locked", resp.CaptchaRequired)
+			} else {
+				metrics.RecordLoginAttempt("failure", resp.CaptchaRequired)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			if err := json.NewEncoder(w).Encode(failure); err != nil {
+				logger.LogError(fmt.Sprintf("Encode failed: %v", err))
+			}
 			metrics.RecordLoginLatency("failure", time.Since(startTime).Seconds()*1000)
 			return
 		}
 
-		// Send back the token in the response
+		// Send back the token in the response, or the two-factor token when a code is required
+		response := map[string]interface{}{
+			"token":         resp.JwtToken,
+			"refresh_token": resp.RefreshToken,
+			"expires_in":    resp.ExpiresIn,
+		}
+		result := "success"
+		if resp.TwoFactorToken != "" {
+			response = map[string]interface{}{
+				"two_factor_required": true,
+				"two_factor_token":    resp.TwoFactorToken,
+			}
+			result = "two_factor"
+		}
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(map[string]string{"token": resp.JwtToken})
+		err = json.NewEncoder(w).Encode(response)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Encode failed: %v", err))
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -85,10 +274,20 @@ func (h *UserHandler) Login() http.HandlerFunc {
 			return
 		}
 
+		metrics.RecordLoginAttempt(result, false)
 		metrics.RecordLoginLatency("success", time.Since(startTime).Seconds()*1000)
 	}
 }
 
+// clientIP returns the IP address of the client that sent the request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // Signup handles user signup.
 //
 // @Summary User signup
@@ -97,7 +296,7 @@ func (h *UserHandler) Login() http.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param signupRequest body model.SignupRequest true "New user signup information"
-// @Success 200 {object} map[string]string "JWT token"
+// @Success 200 {object} map[string]interface{} "JWT access token, refresh token and access token lifetime"
 // @Failure 400 {object} string "Invalid request payload"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/users/signup [post]
@@ -117,16 +316,28 @@ func (h *UserHandler) Signup() http.HandlerFunc {
 			LastName:  signupRequest.LastName,
 			Birthday:  signupRequest.Birthday,
 			Password:  signupRequest.Password,
+			UserAgent: r.UserAgent(),
+			Device:    signupRequest.Device,
 		}
 
-		response, err := h.grpcUserHandler.Signup(context.Background(), &newUser)
+		response, err := h.grpcUserHandler.Signup(r.Context(), &newUser)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		if response.Error != "" {
+			http.Error(w, response.Error, http.StatusBadRequest)
+			return
+		}
 
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(map[string]string{"token": response.Token})
+		err = json.NewEncoder(w).Encode(
+			map[string]interface{}{
+				"token":         response.Token,
+				"refresh_token": response.RefreshToken,
+				"expires_in":    response.ExpiresIn,
+			},
+		)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -137,7 +348,7 @@ func (h *UserHandler) Signup() http.HandlerFunc {
 // EditProfile handles editing user profile.
 //
 // @Summary Edit user profile
-// @Description Updates the user profile information.
+// @Description Updates the fields of the caller's profile named in update_mask, or the non-empty fields when it is omitted. A named field sent empty is cleared.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -148,6 +359,14 @@ func (h *UserHandler) Signup() http.HandlerFunc {
 // @Router /v1/users/profile [put]
 func (h *UserHandler) EditProfile() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
 		var profileUpdate model.ProfileUpdateRequest
 		if err := json.NewDecoder(r.Body).Decode(&profileUpdate); err != nil {
 			http.Error(w, "Invalid request payload", http.StatusBadRequest)
@@ -156,15 +375,28 @@ func (h *UserHandler) EditProfile() http.HandlerFunc {
 
 		// Convert API model to entity model
 		user := userpb.EditProfileRequest{
-			FirstName: profileUpdate.FirstName,
-			LastName:  profileUpdate.LastName,
-			Birthday:  profileUpdate.Birthday,
-			Password:  profileUpdate.Password,
+			UserId:      int32(currentUserID),
+			FirstName:   profileUpdate.FirstName,
+			LastName:    profileUpdate.LastName,
+			Birthday:    profileUpdate.Birthday,
+			Password:    profileUpdate.Password,
+			DisplayName: profileUpdate.DisplayName,
+			Bio:         profileUpdate.Bio,
+			Website:     profileUpdate.Website,
+			Location:    profileUpdate.Location,
+			Email:       profileUpdate.Email,
+		}
+		if len(profileUpdate.UpdateMask) > 0 {
+			user.UpdateMask = &fieldmaskpb.FieldMask{Paths: profileUpdate.UpdateMask}
 		}
 
-		_, err := h.grpcUserHandler.EditProfile(context.Background(), &user)
+		response, err := h.grpcUserHandler.EditProfile(r.Context(), &user)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+		if response.Error != "" {
+			http.Error(w, response.Error, http.StatusInternalServerError)
 			return
 		}
 
@@ -185,3 +417,1090 @@ func (h *UserHandler) convertStringToDate(dateStr string) (time.Time, error) {
 	}
 	return date, nil
 }
+
+// SearchUsers searches users by name for typeahead.
+//
+// @Summary Search users
+// @Description Prefix-matches usernames, first names and last names. Users the caller follows or who follow the caller are ranked first.
+// @Tags search
+// @Produce json
+// @Param q query string true "Name prefix"
+// @Param limit query int false "Limit of users"
+// @Success 200 {object} userpb.SearchUsersResponse "Matching users"
+// @Failure 400 {object} string "Invalid query"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/search/users [get]
+func (h *UserHandler) SearchUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		query := r.URL.Query().Get("q")
+		if query == "" {
+			http.Error(w, "Missing search query", http.StatusBadRequest)
+			return
+		}
+
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+			limit = l
+		}
+
+		req := userpb.SearchUsersRequest{
+			Query:      query,
+			SearcherId: int32(currentUserID),
+			Limit:      int32(limit),
+		}
+
+		response, err := h.grpcUserHandler.SearchUsers(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to search users: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetProfile returns the public profile of a user.
+//
+// @Summary Get user profile
+// @Description Returns the public profile of a user by ID or username, with follower, following and post counts and the caller's relationship to the user.
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} userpb.GetProfileResponse "User profile"
+// @Failure 400 {object} string "Invalid user ID"
+// @Failure 404 {object} string "User not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/{id} [get]
+// @Router /v1/users/by-username/{username} [get]
+func (h *UserHandler) GetProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		req := userpb.GetProfileRequest{
+			ViewerId: int32(currentUserID),
+		}
+		pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+		if pathParts[3] == "by-username" {
+			req.Username = pathParts[4]
+		} else {
+			userID, err := strconv.Atoi(pathParts[3])
+			if err != nil {
+				logger.LogError(fmt.Sprintf("Invalid user ID: %v", err))
+				http.Error(w, "Invalid user ID", http.StatusBadRequest)
+				return
+			}
+			req.UserId = int32(userID)
+		}
+
+		response, err := h.grpcUserHandler.GetProfile(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get profile: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// BlockUser blocks another user for the caller.
+//
+// @Summary Block user
+// @Description Blocks a user for the caller. Blocking a user twice is not an error.
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} userpb.BlockUserResponse "User blocked"
+// @Failure 400 {object} string "Invalid user ID, or the caller's own ID"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "User not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/{id}/block [post]
+func (h *UserHandler) BlockUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+		blockedUserID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid user ID: %v", err))
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.BlockUserRequest{
+			BlockedUserId: int32(blockedUserID),
+		}
+		response, err := h.grpcUserHandler.BlockUser(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to block user %d for user %d: %v", blockedUserID, currentUserID, err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// UnblockUser lifts the caller's block of another user.
+//
+// @Summary Unblock user
+// @Description Lifts the caller's block of a user. Unblocking a user who is not blocked is not an error.
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} userpb.BlockUserResponse "User unblocked"
+// @Failure 400 {object} string "Invalid user ID"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/{id}/block [delete]
+func (h *UserHandler) UnblockUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+		blockedUserID, err := strconv.Atoi(pathParts[3])
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Invalid user ID: %v", err))
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.BlockUserRequest{
+			BlockedUserId: int32(blockedUserID),
+		}
+		response, err := h.grpcUserHandler.UnblockUser(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to unblock user %d for user %d: %v", blockedUserID, currentUserID, err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RequestProfileImageUpload returns a presigned URL to upload an avatar or cover image to.
+//
+// @Summary Request profile image upload
+// @Description Returns a presigned URL the caller PUTs their avatar or cover image to. The image is applied once confirmed.
+// @Tags users
+// @Produce json
+// @Param kind path string true "avatar or cover"
+// @Success 200 {object} userpb.ProfileImageUploadResponse "Presigned upload URL"
+// @Failure 400 {object} string "Invalid image kind"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/{kind}/upload-url [post]
+func (h *UserHandler) RequestProfileImageUpload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		req := userpb.ProfileImageUploadRequest{
+			UserId: int32(currentUserID),
+			Kind:   pathParts[3],
+		}
+
+		response, err := h.grpcUserHandler.RequestProfileImageUpload(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to request profile image upload: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ConfirmProfileImage applies the caller's uploaded avatar or cover image.
+//
+// @Summary Confirm profile image upload
+// @Description Replaces the caller's avatar or cover image with the one uploaded to the presigned URL and deletes the old image.
+// @Tags users
+// @Produce json
+// @Param kind path string true "avatar or cover"
+// @Success 200 {object} userpb.ConfirmProfileImageResponse "URL of the new image"
+// @Failure 400 {object} string "Invalid image kind, or the upload is not an image of at most 10 MB"
+// @Failure 409 {object} string "Image not uploaded"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/{kind}/confirm [post]
+func (h *UserHandler) ConfirmProfileImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(r.URL.Path, "/")
+		req := userpb.ConfirmProfileImageRequest{
+			UserId: int32(currentUserID),
+			Kind:   pathParts[3],
+		}
+
+		response, err := h.grpcUserHandler.ConfirmProfileImage(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to confirm profile image: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RequestPasswordReset emails a password reset link.
+//
+// @Summary Request password reset
+// @Description Emails a single-use password reset link to every account registered with the email. The response is the same whether or not an account exists. Requests are limited per email and client IP address.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body model.PasswordResetRequest true "Account email"
+// @Success 200 {object} map[string]string "Reset requested"
+// @Failure 400 {object} string "Invalid request payload"
+// @Failure 429 {object} string "Too many reset requests"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/password-reset [post]
+func (h *UserHandler) RequestPasswordReset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request model.PasswordResetRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.RequestPasswordResetRequest{
+			Email:    request.Email,
+			ClientIp: clientIP(r),
+		}
+		response, err := h.grpcUserHandler.RequestPasswordReset(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to request password reset: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ResetPassword sets a new password with a reset token.
+//
+// @Summary Reset password
+// @Description Sets a new password using the token from a reset email and signs the user out of every session.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body model.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string "Password reset"
+// @Failure 400 {object} string "Invalid or expired token"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/password-reset/confirm [post]
+func (h *UserHandler) ResetPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request model.ResetPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.ResetPasswordRequest{
+			Token:       request.Token,
+			NewPassword: request.NewPassword,
+		}
+		response, err := h.grpcUserHandler.ResetPassword(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to reset password: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VerifyEmail verifies an email with the emailed token.
+//
+// @Summary Verify email
+// @Description Marks the email the token was sent to as verified.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body model.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} map[string]string "Email verified"
+// @Failure 400 {object} string "Invalid or expired token"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/verify-email [post]
+func (h *UserHandler) VerifyEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request model.VerifyEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.VerifyEmailRequest{
+			Token: request.Token,
+		}
+		response, err := h.grpcUserHandler.VerifyEmail(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to verify email: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ResendVerification sends a new verification email to the caller.
+//
+// @Summary Resend verification email
+// @Description Sends a new verification email to the caller's current email.
+// @Tags users
+// @Produce json
+// @Success 200 {object} map[string]string "Verification email sent"
+// @Failure 409 {object} string "Email already verified"
+// @Failure 429 {object} string "Verification email sent recently"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/verify-email/resend [post]
+func (h *UserHandler) ResendVerification() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		req := userpb.ResendVerificationRequest{
+			UserId: int32(currentUserID),
+		}
+		response, err := h.grpcUserHandler.ResendVerification(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to resend verification: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// EnrollTOTP starts two-factor enrollment for the caller.
+//
+// @Summary Enroll TOTP
+// @Description Creates a TOTP secret for the caller and returns its otpauth URI for an authenticator app. Two-factor authentication is only required once activated.
+// @Tags users
+// @Produce json
+// @Success 200 {object} userpb.EnrollTOTPResponse "otpauth URI and secret"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 409 {object} string "Two-factor authentication is already enabled"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/2fa/totp/enroll [post]
+func (h *UserHandler) EnrollTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		req := userpb.EnrollTOTPRequest{
+			UserId: int32(currentUserID),
+		}
+		response, err := h.grpcUserHandler.EnrollTOTP(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to enroll TOTP: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ActivateTOTP turns on two-factor authentication for the caller.
+//
+// @Summary Activate TOTP
+// @Description Activates two-factor authentication with a code of the enrolled authenticator app and returns single-use recovery codes. The recovery codes are only shown once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param activateTOTPRequest body model.ActivateTOTPRequest true "Authenticator code"
+// @Success 200 {object} userpb.ActivateTOTPResponse "Recovery codes"
+// @Failure 400 {object} string "Invalid code"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 409 {object} string "Not enrolled or already enabled"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/2fa/totp/activate [post]
+func (h *UserHandler) ActivateTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var activateRequest model.ActivateTOTPRequest
+		if err := json.NewDecoder(r.Body).Decode(&activateRequest); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.ActivateTOTPRequest{
+			UserId: int32(currentUserID),
+			Code:   activateRequest.Code,
+		}
+		response, err := h.grpcUserHandler.ActivateTOTP(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to activate TOTP: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VerifyTwoFactor completes a login that requires a two-factor code.
+//
+// @Summary Verify two-factor code
+// @Description Exchanges the two_factor_token returned by login and a TOTP or recovery code for an access token and refresh token. A two_factor_token expires after a few minutes and a few wrong codes.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param verifyTwoFactorRequest body model.VerifyTwoFactorRequest true "Two-factor token and code"
+// @Success 200 {object} map[string]interface{} "JWT access token, refresh token and access token lifetime"
+// @Failure 400 {object} string "Invalid request payload"
+// @Failure 401 {object} string "Invalid code or expired two-factor token"
+// @Failure 429 {object} string "Too many failed attempts, sign in again later"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/auth/2fa/verify [post]
+func (h *UserHandler) VerifyTwoFactor() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var verifyRequest model.VerifyTwoFactorRequest
+		if err := json.NewDecoder(r.Body).Decode(&verifyRequest); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.VerifyTwoFactorRequest{
+			TwoFactorToken: verifyRequest.TwoFactorToken,
+			Code:           verifyRequest.Code,
+		}
+		response, err := h.grpcUserHandler.VerifyTwoFactor(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to verify two-factor code: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(
+			map[string]interface{}{
+				"token":         response.AccessToken,
+				"refresh_token": response.RefreshToken,
+				"expires_in":    response.ExpiresIn,
+			},
+		)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// oidcStateCookie keeps the state of a login with the identity provider from OIDCLogin to OIDCCallback.
+const oidcStateCookie = "oidc_state"
+
+// OIDCLogin starts a sign in with the configured OpenID Connect provider.
+//
+// @Summary Sign in with identity provider
+// @Description Redirects to the identity provider's login page using the authorization code flow with PKCE. The provider redirects back to /v1/auth/oidc/callback.
+// @Tags auth
+// @Param device query string false "Device name recorded on the session"
+// @Success 302 {object} string "Redirect to the identity provider"
+// @Failure 501 {object} string "No identity provider is configured"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/auth/oidc/login [get]
+func (h *UserHandler) OIDCLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		req := userpb.StartOIDCLoginRequest{
+			UserAgent: r.UserAgent(),
+			Device:    r.URL.Query().Get("device"),
+		}
+		response, err := h.grpcUserHandler.StartOIDCLogin(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to start OIDC login: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		// The callback must come back to the browser that started the login, otherwise an attacker could
+		// sign a victim in to the attacker's account with a callback URL of their own
+		http.SetCookie(
+			w, &http.Cookie{
+				Name:     oidcStateCookie,
+				Value:    response.State,
+				Path:     "/v1/auth/oidc",
+				HttpOnly: true,
+				Secure:   r.TLS != nil,
+				SameSite: http.SameSiteLaxMode, // Sent on the provider's top-level redirect back
+			},
+		)
+		http.Redirect(w, r, response.AuthorizationUrl, http.StatusFound)
+	}
+}
+
+// OIDCCallback completes a sign in with the identity provider.
+//
+// @Summary Identity provider callback
+// @Description Redeems the authorization code the identity provider redirected back with. An identity seen for the first time is linked to the account with its verified email, or signed up as a new account. Accounts with two-factor authentication get a two_factor_token instead of the tokens.
+// @Tags auth
+// @Produce json
+// @Param state query string true "State of the login"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} map[string]interface{} "JWT access token, refresh token and access token lifetime, or a two-factor token"
+// @Failure 400 {object} string "Missing state or code"
+// @Failure 401 {object} string "Invalid state, login started in another browser or rejected by the identity provider"
+// @Failure 403 {object} string "The identity provider did not verify the email"
+// @Failure 409 {object} string "An account with the email exists but has not verified it"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/auth/oidc/callback [get]
+func (h *UserHandler) OIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		stateCookie, cookieErr := r.Cookie(oidcStateCookie)
+		http.SetCookie(
+			w, &http.Cookie{Name: oidcStateCookie, Path: "/v1/auth/oidc", MaxAge: -1, HttpOnly: true, Secure: r.TLS != nil},
+		)
+		if providerError := query.Get("error"); providerError != "" {
+			http.Error(w, fmt.Sprintf("Identity provider error: %s", providerError), http.StatusUnauthorized)
+			return
+		}
+		if cookieErr != nil || subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(query.Get("state"))) != 1 {
+			http.Error(w, "Invalid OIDC state", http.StatusUnauthorized)
+			return
+		}
+
+		req := userpb.CompleteOIDCLoginRequest{
+			State: query.Get("state"),
+			Code:  query.Get("code"),
+		}
+		resp, err := h.grpcUserHandler.CompleteOIDCLogin(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to complete OIDC login: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		response := map[string]interface{}{
+			"token":         resp.JwtToken,
+			"refresh_token": resp.RefreshToken,
+			"expires_in":    resp.ExpiresIn,
+		}
+		if resp.TwoFactorToken != "" {
+			response = map[string]interface{}{
+				"two_factor_required": true,
+				"two_factor_token":    resp.TwoFactorToken,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new token pair.
+//
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access token and refresh token. Each refresh token can be used once, reusing one revokes the session it belongs to.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refreshTokenRequest body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} map[string]interface{} "New token pair"
+// @Failure 400 {object} string "Invalid request payload"
+// @Failure 401 {object} string "Invalid, expired or reused refresh token"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/auth/refresh [post]
+func (h *UserHandler) RefreshToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var refreshRequest model.RefreshTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&refreshRequest); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.RefreshTokenRequest{
+			RefreshToken: refreshRequest.RefreshToken,
+		}
+		response, err := h.grpcUserHandler.RefreshToken(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to refresh token: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(
+			map[string]interface{}{
+				"token":         response.AccessToken,
+				"refresh_token": response.RefreshToken,
+				"expires_in":    response.ExpiresIn,
+			},
+		)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// Logout ends the caller's current session.
+//
+// @Summary Logout
+// @Description Ends the session the request was made with, its token is rejected afterwards.
+// @Tags users
+// @Produce json
+// @Success 200 {object} map[string]string "Logged out"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/users/logout [post]
+func (h *UserHandler) Logout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Get the current user and session from the request context (assumes middleware has set them)
+		principal, ok := auth.FromContext(r.Context())
+		if !ok || principal.SessionID == "" {
+			logger.LogError(fmt.Sprintf("Unable to get session from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		req := userpb.LogoutRequest{
+			UserId:    int32(principal.UserID),
+			SessionId: principal.SessionID,
+		}
+		response, err := h.grpcUserHandler.Logout(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to logout: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ListSessions lists the caller's active sessions.
+//
+// @Summary List sessions
+// @Description Lists the devices the caller is signed in on, newest first.
+// @Tags users
+// @Produce json
+// @Success 200 {object} userpb.ListSessionsResponse "Active sessions"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 403 {object} string "Not signed in with a session"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/sessions [get]
+func (h *UserHandler) ListSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user and session from the request context (assumes middleware has set them)
+		principal, ok := auth.FromContext(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if principal.SessionID == "" {
+			http.Error(w, "Sessions can only be managed when signed in", http.StatusForbidden)
+			return
+		}
+
+		req := userpb.ListSessionsRequest{
+			UserId:           int32(principal.UserID),
+			CurrentSessionId: principal.SessionID,
+		}
+		response, err := h.grpcUserHandler.ListSessions(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to list sessions: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RevokeSession ends one of the caller's sessions.
+//
+// @Summary Revoke session
+// @Description Ends one of the caller's sessions, e.g. on a lost device.
+// @Tags users
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string "Session revoked"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "Session not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the current user ID from the request context (assumes middleware has set it)
+		currentUserID, ok := auth.UserID(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+		req := userpb.RevokeSessionRequest{
+			UserId:    int32(currentUserID),
+			SessionId: pathParts[4],
+		}
+		response, err := h.grpcUserHandler.RevokeSession(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to revoke session: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// CreateAPIKey creates a personal API key for the caller.
+//
+// @Summary Create API key
+// @Description Creates a personal API key for tools and bots, sent in the X-API-Key header instead of a JWT. Keys with the read scope may only make read-only requests, keys with the post scope may also create posts. The key is only returned once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param createAPIKeyRequest body model.CreateAPIKeyRequest true "Name, scope and lifetime of the key"
+// @Success 201 {object} userpb.CreateAPIKeyResponse "Created key"
+// @Failure 400 {object} string "Invalid name, scope or lifetime"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 403 {object} string "API keys cannot manage API keys"
+// @Failure 429 {object} string "Too many API keys"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/api-keys [post]
+func (h *UserHandler) CreateAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.FromContext(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if principal.SessionID == "" {
+			http.Error(w, "API keys can only be managed when signed in", http.StatusForbidden)
+			return
+		}
+
+		var createRequest model.CreateAPIKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		req := userpb.CreateAPIKeyRequest{
+			UserId:        int32(principal.UserID),
+			Name:          createRequest.Name,
+			Scope:         createRequest.Scope,
+			ExpiresInDays: int32(createRequest.ExpiresInDays),
+		}
+		response, err := h.grpcUserHandler.CreateAPIKey(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to create API key: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			return
+		}
+	}
+}
+
+// ListAPIKeys lists the caller's active API keys.
+//
+// @Summary List API keys
+// @Description Lists the caller's active API keys with their scopes, expiry and last use. The keys themselves are not returned.
+// @Tags users
+// @Produce json
+// @Success 200 {object} userpb.ListAPIKeysResponse "Active API keys"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 403 {object} string "API keys cannot manage API keys"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/api-keys [get]
+func (h *UserHandler) ListAPIKeys() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.FromContext(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if principal.SessionID == "" {
+			http.Error(w, "API keys can only be managed when signed in", http.StatusForbidden)
+			return
+		}
+
+		req := userpb.ListAPIKeysRequest{
+			UserId: int32(principal.UserID),
+		}
+		response, err := h.grpcUserHandler.ListAPIKeys(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to list API keys: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to encode response: %v", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RevokeAPIKey revokes one of the caller's API keys.
+//
+// @Summary Revoke API key
+// @Description Revokes one of the caller's API keys, it stops working immediately.
+// @Tags users
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} map[string]string "API key revoked"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 403 {object} string "API keys cannot manage API keys"
+// @Failure 404 {object} string "API key not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/me/api-keys/{id} [delete]
+func (h *UserHandler) RevokeAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.FromContext(r.Context())
+		if !ok {
+			logger.LogError(fmt.Sprintf("Unable to get user id from context"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if principal.SessionID == "" {
+			http.Error(w, "API keys can only be managed when signed in", http.StatusForbidden)
+			return
+		}
+
+		pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+		req := userpb.RevokeAPIKeyRequest{
+			UserId:   int32(principal.UserID),
+			ApiKeyId: pathParts[4],
+		}
+		response, err := h.grpcUserHandler.RevokeAPIKey(r.Context(), &req)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to revoke API key: %v", err))
+			http.Error(w, err.Error(), httpStatusFromGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]string{"msg": response.Message})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // CreatePostRequest represents the request payload for creating a new post.
 //
 // @Description Request payload for creating a new post in the news feed.
@@ -12,12 +14,27 @@ type CreatePostRequest struct {
 	// HasImage indicates whether the post includes an image.
 	// @example true
 	HasImage bool `json:"hasImage"` // Flag to indicate if the post contains an image
+
+	// CommentPermission controls who may comment: everyone, followers, following or disabled.
+	// @example "followers"
+	CommentPermission string `json:"comment_permission,omitempty"` // Defaults to everyone when empty
+
+	// Poll is an optional poll attached to the post.
+	Poll *PollRequest `json:"poll,omitempty"`
+}
+
+// PollRequest represents a poll attached to a new post.
+type PollRequest struct {
+	Options        []string  `json:"options"`         // 2 to 4 option texts
+	ClosesAt       time.Time `json:"closes_at"`       // RFC3339 closing time, must be in the future
+	MultipleChoice bool      `json:"multiple_choice"` // Whether voters may pick more than one option
 }
 
 // EditPostRequest represents the request payload for editing an existing post.
 type EditPostRequest struct {
-	Text     string `json:"text"`
-	HasImage bool   `json:"hasImage"`
+	Text              string `json:"text"`
+	HasImage          bool   `json:"hasImage"`
+	CommentPermission string `json:"comment_permission,omitempty"` // Unchanged when empty
 }
 
 // DeletePostRequest represents the request payload for deleting a post.
@@ -27,6 +44,12 @@ type DeletePostRequest struct {
 
 // CommentOnPostRequest represents the request payload for commenting on a post.
 type CommentOnPostRequest struct {
+	Text            string `json:"text"`
+	ParentCommentID int    `json:"parent_comment_id"` // Optional, set to reply to an existing comment
+}
+
+// EditCommentRequest represents the request payload for editing a comment.
+type EditCommentRequest struct {
 	Text string `json:"text"`
 }
 
@@ -41,3 +64,14 @@ type GetPostResponse struct {
 	Comments []string `json:"comments"`
 	Likes    int      `json:"likes"`
 }
+
+// SavePostRequest represents the request payload for bookmarking a post.
+type SavePostRequest struct {
+	PostID     int    `json:"post_id"`
+	Collection string `json:"collection,omitempty"` // Optional collection to file the bookmark under
+}
+
+// VotePollRequest represents the request payload for voting on the poll of a post.
+type VotePollRequest struct {
+	OptionIDs []int `json:"option_ids"` // Exactly one option for a single choice poll
+}
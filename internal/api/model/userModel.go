@@ -11,6 +11,7 @@ type User struct {
 type LoginRequest struct {
 	UserName string `json:"user_name"`
 	Password string `json:"password"`
+	Device   string `json:"device"` // Optional device name shown in the session list
 }
 
 // SignupRequest represents the payload for signup requests.
@@ -21,12 +22,67 @@ type SignupRequest struct {
 	LastName  string `json:"last_name"`
 	Birthday  string `json:"birthday"`
 	Password  string `json:"password"`
+	Device    string `json:"device"` // Optional device name shown in the session list
 }
 
 // ProfileUpdateRequest represents the payload for profile update requests.
 type ProfileUpdateRequest struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Birthday  string `json:"birthday"`
-	Password  string `json:"password"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Birthday    string `json:"birthday"`
+	Password    string `json:"password"`
+	DisplayName string `json:"display_name"`
+	Bio         string `json:"bio"`
+	Website     string `json:"website"`
+	Location    string `json:"location"`
+	Email       string `json:"email"` // A changed email has to be verified again
+	// UpdateMask names the fields to update, e.g. ["bio", "website"]. A named field with an empty value
+	// is cleared. When empty only the non-empty fields are updated.
+	UpdateMask []string `json:"update_mask"`
+}
+
+// LoginFailureResponse is returned when a login fails or is refused.
+type LoginFailureResponse struct {
+	Error             string `json:"error"`
+	CaptchaRequired   bool   `json:"captcha_required"`              // Show a CAPTCHA before the next attempt
+	RetryAfterSeconds int    `json:"retry_after_seconds,omitempty"` // Set while locked out
+}
+
+// ActivateTOTPRequest represents the payload for activating two-factor authentication.
+type ActivateTOTPRequest struct {
+	Code string `json:"code"` // Current code of the authenticator app
+}
+
+// VerifyTwoFactorRequest represents the payload for completing a login with a two-factor code.
+type VerifyTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	Code           string `json:"code"` // TOTP code or recovery code
+}
+
+// CreateAPIKeyRequest represents the payload for creating a personal API key.
+type CreateAPIKeyRequest struct {
+	Name          string `json:"name"`
+	Scope         string `json:"scope"`           // "read" or "post"
+	ExpiresInDays int    `json:"expires_in_days"` // Optional, the default lifetime when zero
+}
+
+// RefreshTokenRequest represents the payload for exchanging a refresh token for a new token pair.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PasswordResetRequest represents the payload for requesting a password reset email.
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents the payload for setting a new password with a reset token.
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// VerifyEmailRequest represents the payload for verifying an email with the emailed token.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
 }
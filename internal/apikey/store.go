@@ -0,0 +1,240 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"news-feed/internal/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrAPIKeyNotFound is returned when an API key does not exist, has expired or belongs to another user.
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// ErrInvalidAPIKey is returned when a presented API key is malformed, unknown, expired or revoked.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// ErrKeyLimitReached is returned when creating a key for a user who already has the maximum number of
+// active keys.
+var ErrKeyLimitReached = errors.New("API key limit reached")
+
+// keyPrefix starts every API key so leaked keys are easy to recognize, e.g. by secret scanners.
+const keyPrefix = "nfk_"
+
+// How often the last use of a key is written, so a busy bot does not write on every request
+const lastUsedResolution = time.Minute
+
+// createKeyScript drops the user's expired keys and adds the new one unless the user already has the
+// maximum number of keys, so concurrent creations cannot exceed it. It returns 0 when the limit is reached.
+// KEYS are the user's key set and the key's hash, ARGV the current time, the limit, the key's expiry and
+// ID, followed by the fields of the hash.
+var createKeyScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('HSET', KEYS[2], unpack(ARGV, 5))
+redis.call('EXPIREAT', KEYS[2], ARGV[3])
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[4])
+return 1
+`)
+
+type StoreInterface interface {
+	Create(userID int, username string, name string, scopes []string, expiresAt time.Time, maxKeys int) (*entity.APIKey, error)
+	Authenticate(key string) (*entity.APIKey, error)
+	List(userID int) ([]entity.APIKey, error)
+	Delete(userID int, keyID string) error
+	DeleteAll(userID int) error
+}
+
+// Store keeps API keys in Redis. A key is "nfk_<id>_<secret>", its metadata and the SHA-256 of the secret
+// are a hash at "api_key:<id>" expiring with the key, and the IDs of a user's keys are kept in the sorted
+// set "user_api_keys:<user id>" scored by expiry. The secret itself is never stored.
+type Store struct {
+	redisClient *redis.Client
+}
+
+func NewStore(redisClient *redis.Client) *Store {
+	return &Store{redisClient: redisClient}
+}
+
+// Create issues a new API key for the user unless they already have maxKeys active keys, the key is
+// returned in the APIKey's Key and cannot be shown again.
+func (s *Store) Create(userID int, username string, name string, scopes []string, expiresAt time.Time, maxKeys int) (*entity.APIKey, error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("could not generate API key id: %v", err)
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, fmt.Errorf("could not generate API key: %v", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	apiKey := &entity.APIKey{
+		ID:        hex.EncodeToString(idBytes),
+		UserID:    userID,
+		Username:  username,
+		Name:      name,
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+	apiKey.Key = keyPrefix + apiKey.ID + "_" + secret
+
+	created, err := createKeyScript.Run(
+		context.Background(), s.redisClient,
+		[]string{userAPIKeysKey(userID), apiKeyKey(apiKey.ID)},
+		apiKey.CreatedAt.Unix(), maxKeys, apiKey.ExpiresAt.Unix(), apiKey.ID,
+		"user_id", apiKey.UserID,
+		"username", apiKey.Username,
+		"name", apiKey.Name,
+		"scopes", strings.Join(apiKey.Scopes, ","),
+		"secret_hash", hashSecret(secret),
+		"created_at", apiKey.CreatedAt.Format(time.RFC3339),
+		"expires_at", apiKey.ExpiresAt.Format(time.RFC3339),
+	).Int()
+	if err != nil {
+		return nil, fmt.Errorf("could not store API key: %v", err)
+	}
+	if created == 0 {
+		return nil, ErrKeyLimitReached
+	}
+	return apiKey, nil
+}
+
+// Authenticate returns the active API key matching a presented key and records that it was used.
+func (s *Store) Authenticate(key string) (*entity.APIKey, error) {
+	keyID, secret, ok := strings.Cut(strings.TrimPrefix(key, keyPrefix), "_")
+	if !strings.HasPrefix(key, keyPrefix) || !ok || keyID == "" || secret == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	ctx := context.Background()
+	data, err := s.redisClient.HGetAll(ctx, apiKeyKey(keyID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(data["secret_hash"])) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey := apiKeyFromHash(keyID, data)
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		// Losing a last use update is harmless, the request is not failed for it. The expiry is set again in
+		// case the key was revoked in the meantime, so the update cannot leave a hash behind forever.
+		pipe := s.redisClient.Pipeline()
+		pipe.HSet(ctx, apiKeyKey(keyID), "last_used_at", now.Format(time.RFC3339))
+		pipe.ExpireAt(ctx, apiKeyKey(keyID), apiKey.ExpiresAt)
+		pipe.Exec(ctx)
+		apiKey.LastUsedAt = &now
+	}
+	return apiKey, nil
+}
+
+// List returns the user's active API keys, newest expiry first.
+func (s *Store) List(userID int) ([]entity.APIKey, error) {
+	ctx := context.Background()
+	// Drop keys that already expired
+	s.redisClient.ZRemRangeByScore(ctx, userAPIKeysKey(userID), "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+
+	keyIDs, err := s.redisClient.ZRevRange(ctx, userAPIKeysKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var apiKeys []entity.APIKey
+	for _, keyID := range keyIDs {
+		data, err := s.redisClient.HGetAll(ctx, apiKeyKey(keyID)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			continue
+		}
+		apiKeys = append(apiKeys, *apiKeyFromHash(keyID, data))
+	}
+	return apiKeys, nil
+}
+
+// Delete revokes one of the user's API keys.
+func (s *Store) Delete(userID int, keyID string) error {
+	ctx := context.Background()
+	owner, err := s.redisClient.HGet(ctx, apiKeyKey(keyID), "user_id").Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if owner != strconv.Itoa(userID) {
+		return ErrAPIKeyNotFound
+	}
+
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, apiKeyKey(keyID))
+			pipe.ZRem(ctx, userAPIKeysKey(userID), keyID)
+			return nil
+		},
+	)
+	return err
+}
+
+// DeleteAll revokes all of the user's API keys, e.g. when their password is reset.
+func (s *Store) DeleteAll(userID int) error {
+	ctx := context.Background()
+	keyIDs, err := s.redisClient.ZRange(ctx, userAPIKeysKey(userID), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userAPIKeysKey(userID)}
+	for _, keyID := range keyIDs {
+		keys = append(keys, apiKeyKey(keyID))
+	}
+	return s.redisClient.Del(ctx, keys...).Err()
+}
+
+func apiKeyKey(keyID string) string {
+	return "api_key:" + keyID
+}
+
+func userAPIKeysKey(userID int) string {
+	return fmt.Sprintf("user_api_keys:%d", userID)
+}
+
+// hashSecret returns the hex SHA-256 of the secret part of a key. The secret has 256 random bits, so a
+// fast hash is enough to make a leaked store useless.
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func apiKeyFromHash(keyID string, data map[string]string) *entity.APIKey {
+	apiKey := &entity.APIKey{
+		ID:       keyID,
+		Username: data["username"],
+		Name:     data["name"],
+		Scopes:   []string{},
+	}
+	if data["scopes"] != "" {
+		apiKey.Scopes = strings.Split(data["scopes"], ",")
+	}
+	apiKey.UserID, _ = strconv.Atoi(data["user_id"])
+	apiKey.CreatedAt, _ = time.Parse(time.RFC3339, data["created_at"])
+	apiKey.ExpiresAt, _ = time.Parse(time.RFC3339, data["expires_at"])
+	if lastUsedAt, err := time.Parse(time.RFC3339, data["last_used_at"]); err == nil {
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+	return apiKey
+}
@@ -6,15 +6,12 @@ import (
 	"github.com/redis/go-redis/v9"
 	"log"
 	"news-feed/pkg/config/webApp"
+	"sync"
 	"time"
 )
 
-var redisClient *redis.Client
-
-func init() {
-	// Initialize Redis client
-	redisClient = newRedisClient()
-}
+// redisClient connects on first use, so importing the package does not need a configured Redis.
+var redisClient = sync.OnceValue(newRedisClient)
 
 func newRedisClient() *redis.Client {
 	cfg := webApp.LoadConfig()
@@ -44,5 +41,5 @@ func newRedisClient() *redis.Client {
 }
 
 func GetRedisClient() *redis.Client {
-	return redisClient
+	return redisClient()
 }
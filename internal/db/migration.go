@@ -21,10 +21,40 @@ func Migrate(db *sql.DB) error {
 			salt VARCHAR(255) NOT NULL,
 			first_name VARCHAR(255) NOT NULL,
 			last_name VARCHAR(255) NOT NULL,
-			dob DATE NOT NULL,
+			dob DATE NULL,
 			email VARCHAR(255) NOT NULL,
+			email_verified BOOLEAN NOT NULL DEFAULT FALSE,
 			user_name VARCHAR(255) UNIQUE NOT NULL,
-			INDEX idx_user_name (user_name)
+			avatar_key VARCHAR(255) NOT NULL DEFAULT '',
+			cover_key VARCHAR(255) NOT NULL DEFAULT '',
+			display_name VARCHAR(64) NOT NULL DEFAULT '',
+			bio VARCHAR(500) NOT NULL DEFAULT '',
+			website VARCHAR(255) NOT NULL DEFAULT '',
+			location VARCHAR(100) NOT NULL DEFAULT '',
+			totp_secret VARCHAR(64) NOT NULL DEFAULT '',
+			totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+			INDEX idx_user_name (user_name),
+			INDEX idx_first_name (first_name),
+			INDEX idx_last_name (last_name)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS user_recovery_code (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_user_id INT NOT NULL,
+			code_hash CHAR(64) NOT NULL,
+			used_at TIMESTAMP NULL,
+			UNIQUE INDEX idx_user_code_hash (fk_user_id, code_hash),
+			FOREIGN KEY (fk_user_id) REFERENCES user(id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS user_identity (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_user_id INT NOT NULL,
+			issuer VARCHAR(255) NOT NULL,
+			subject VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE INDEX idx_issuer_subject (issuer, subject),
+			FOREIGN KEY (fk_user_id) REFERENCES user(id)
 		);`,
 
 		`CREATE TABLE IF NOT EXISTS post (
@@ -34,6 +64,10 @@ func Migrate(db *sql.DB) error {
 			content_image_path VARCHAR(255),
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			visible BOOLEAN DEFAULT TRUE,
+			comment_permission VARCHAR(16) NOT NULL DEFAULT 'everyone',
+			pinned_at TIMESTAMP NULL,
+			INDEX idx_user_pinned_at (fk_user_id, pinned_at),
+			FULLTEXT INDEX ft_content_text (content_text),
 			FOREIGN KEY (fk_user_id) REFERENCES user(id)
 		);`,
 
@@ -41,10 +75,16 @@ func Migrate(db *sql.DB) error {
 			id INT AUTO_INCREMENT PRIMARY KEY,
 			fk_post_id INT NOT NULL,
 			fk_user_id INT NOT NULL,
+			parent_comment_id INT NULL,
+			depth INT NOT NULL DEFAULT 0,
 			content TEXT NOT NULL,
+			like_count INT NOT NULL DEFAULT 0,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			INDEX idx_parent_comment_id (parent_comment_id),
+			INDEX idx_post_like_count (fk_post_id, like_count, id),
 			FOREIGN KEY (fk_post_id) REFERENCES post(id),
-			FOREIGN KEY (fk_user_id) REFERENCES user(id)
+			FOREIGN KEY (fk_user_id) REFERENCES user(id),
+			FOREIGN KEY (parent_comment_id) REFERENCES comment(id) ON DELETE CASCADE
 		);`,
 
 		`CREATE TABLE IF NOT EXISTS user_user (
@@ -56,6 +96,86 @@ func Migrate(db *sql.DB) error {
 		);`,
 
 		likeQuery,
+
+		`CREATE TABLE IF NOT EXISTS user_block (
+			fk_user_id INT NOT NULL,
+			fk_blocked_user_id INT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (fk_user_id, fk_blocked_user_id),
+			FOREIGN KEY (fk_user_id) REFERENCES user(id),
+			FOREIGN KEY (fk_blocked_user_id) REFERENCES user(id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS comment_like (
+			fk_comment_id INT NOT NULL,
+			fk_user_id INT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (fk_comment_id, fk_user_id),
+			FOREIGN KEY (fk_comment_id) REFERENCES comment(id) ON DELETE CASCADE,
+			FOREIGN KEY (fk_user_id) REFERENCES user(id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS bookmark_collection (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_user_id INT NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE KEY uq_user_name (fk_user_id, name),
+			FOREIGN KEY (fk_user_id) REFERENCES user(id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS bookmark (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_user_id INT NOT NULL,
+			fk_post_id INT NOT NULL,
+			fk_collection_id INT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE KEY uq_user_post (fk_user_id, fk_post_id),
+			FOREIGN KEY (fk_user_id) REFERENCES user(id),
+			FOREIGN KEY (fk_post_id) REFERENCES post(id) ON DELETE CASCADE,
+			FOREIGN KEY (fk_collection_id) REFERENCES bookmark_collection(id) ON DELETE SET NULL
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS poll (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_post_id INT NOT NULL UNIQUE,
+			multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+			closes_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (fk_post_id) REFERENCES post(id) ON DELETE CASCADE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS poll_option (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			fk_poll_id INT NOT NULL,
+			position INT NOT NULL,
+			text VARCHAR(255) NOT NULL,
+			vote_count INT NOT NULL DEFAULT 0,
+			INDEX idx_poll_position (fk_poll_id, position),
+			FOREIGN KEY (fk_poll_id) REFERENCES poll(id) ON DELETE CASCADE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS poll_vote (
+			fk_poll_id INT NOT NULL,
+			fk_option_id INT NOT NULL,
+			fk_user_id INT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (fk_option_id, fk_user_id),
+			INDEX idx_poll_user (fk_poll_id, fk_user_id),
+			FOREIGN KEY (fk_poll_id) REFERENCES poll(id) ON DELETE CASCADE,
+			FOREIGN KEY (fk_option_id) REFERENCES poll_option(id) ON DELETE CASCADE,
+			FOREIGN KEY (fk_user_id) REFERENCES user(id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS link_preview (
+			fk_post_id INT PRIMARY KEY,
+			url VARCHAR(2048) NOT NULL,
+			title VARCHAR(512) NOT NULL DEFAULT '',
+			description TEXT,
+			image_url VARCHAR(2048) NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (fk_post_id) REFERENCES post(id) ON DELETE CASCADE
+		);`,
 	}
 
 	for _, query := range queries {
@@ -65,5 +185,132 @@ func Migrate(db *sql.DB) error {
 		}
 	}
 
+	for _, upgrade := range schemaUpgrades {
+		if err := upgrade.apply(db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaUpgrade brings a table created by an older version up to date, CREATE TABLE IF NOT EXISTS leaves
+// existing tables untouched. It only runs when the information schema shows the change is missing.
+type schemaUpgrade struct {
+	exists     string // Query counting what the upgrade adds
+	args       []interface{}
+	statements []string
+}
+
+func (u schemaUpgrade) apply(db *sql.DB) error {
+	var count int
+	if err := db.QueryRow(u.exists, u.args...).Scan(&count); err != nil {
+		return fmt.Errorf("error checking schema: %v", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	for _, statement := range u.statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("error running migration query: %v", err)
+		}
+	}
 	return nil
 }
+
+// addColumn adds a column, then runs the backfill statements once.
+func addColumn(table string, column string, definition string, backfill ...string) schemaUpgrade {
+	return schemaUpgrade{
+		exists: `SELECT COUNT(*) FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`,
+		args:       []interface{}{table, column},
+		statements: append([]string{fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s %s", table, column, definition)}, backfill...),
+	}
+}
+
+// addIndex adds an index, definition is its clause as in CREATE TABLE.
+func addIndex(table string, index string, definition string) schemaUpgrade {
+	return schemaUpgrade{
+		exists: `SELECT COUNT(*) FROM information_schema.STATISTICS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`,
+		args:       []interface{}{table, index},
+		statements: []string{fmt.Sprintf("ALTER TABLE `%s` ADD %s", table, definition)},
+	}
+}
+
+// addForeignKey adds a foreign key on a column unless the column already references another table.
+func addForeignKey(table string, column string, definition string) schemaUpgrade {
+	return schemaUpgrade{
+		exists: `SELECT COUNT(*) FROM information_schema.KEY_COLUMN_USAGE
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL`,
+		args:       []interface{}{table, column},
+		statements: []string{fmt.Sprintf("ALTER TABLE `%s` ADD %s", table, definition)},
+	}
+}
+
+// allowNull makes a NOT NULL column nullable, definition is its type.
+func allowNull(table string, column string, definition string) schemaUpgrade {
+	return schemaUpgrade{
+		exists: `SELECT COUNT(*) FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ? AND IS_NULLABLE = 'YES'`,
+		args:       []interface{}{table, column},
+		statements: []string{fmt.Sprintf("ALTER TABLE `%s` MODIFY COLUMN %s %s NULL", table, column, definition)},
+	}
+}
+
+// schemaUpgrades are applied in order after the tables are created, keep them in the order the columns
+// were added.
+var schemaUpgrades = []schemaUpgrade{
+	// Threaded comment replies
+	addColumn("comment", "parent_comment_id", "INT NULL"),
+	addColumn("comment", "depth", "INT NOT NULL DEFAULT 0"),
+	addIndex("comment", "idx_parent_comment_id", "INDEX idx_parent_comment_id (parent_comment_id)"),
+	addForeignKey(
+		"comment", "parent_comment_id",
+		"FOREIGN KEY (parent_comment_id) REFERENCES comment(id) ON DELETE CASCADE",
+	),
+
+	// Comment likes, counts of existing comments are backfilled from comment_like
+	addColumn(
+		"comment", "like_count", "INT NOT NULL DEFAULT 0",
+		"UPDATE comment SET like_count = (SELECT COUNT(*) FROM comment_like WHERE fk_comment_id = comment.id)",
+	),
+	addIndex("comment", "idx_post_like_count", "INDEX idx_post_like_count (fk_post_id, like_count, id)"),
+
+	// Comment permissions
+	addColumn("post", "comment_permission", "VARCHAR(16) NOT NULL DEFAULT 'everyone'"),
+
+	// Pinned posts
+	addColumn("post", "pinned_at", "TIMESTAMP NULL"),
+	addIndex("post", "idx_user_pinned_at", "INDEX idx_user_pinned_at (fk_user_id, pinned_at)"),
+
+	// Post search
+	addIndex("post", "ft_content_text", "FULLTEXT INDEX ft_content_text (content_text)"),
+
+	// Profile images
+	addColumn("user", "avatar_key", "VARCHAR(255) NOT NULL DEFAULT ''"),
+	addColumn("user", "cover_key", "VARCHAR(255) NOT NULL DEFAULT ''"),
+
+	// Profile fields
+	addColumn("user", "display_name", "VARCHAR(64) NOT NULL DEFAULT ''"),
+	addColumn("user", "bio", "VARCHAR(500) NOT NULL DEFAULT ''"),
+	addColumn("user", "website", "VARCHAR(255) NOT NULL DEFAULT ''"),
+	addColumn("user", "location", "VARCHAR(100) NOT NULL DEFAULT ''"),
+
+	// Email verification. Accounts created before it existed stay unverified until their owner verifies
+	// the address, it was never checked.
+	addColumn("user", "email_verified", "BOOLEAN NOT NULL DEFAULT FALSE"),
+
+	// Two-factor authentication
+	addColumn("user", "totp_secret", "VARCHAR(64) NOT NULL DEFAULT ''"),
+	addColumn("user", "totp_enabled", "BOOLEAN NOT NULL DEFAULT FALSE"),
+
+	// Sign in with an identity provider. Accounts signed up through one have no birthday, and concurrent
+	// sign ups rely on the unique username to not get the same one.
+	allowNull("user", "dob", "DATE"),
+	addIndex("user", "user_name", "UNIQUE INDEX user_name (user_name)"),
+
+	// User search falls back to prefix matches on the names
+	addIndex("user", "idx_first_name", "INDEX idx_first_name (first_name)"),
+	addIndex("user", "idx_last_name", "INDEX idx_last_name (last_name)"),
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// APIKey is a personal API key tools and bots authenticate with instead of a user's JWT. It acts as the
+// user who created it, limited to its scopes.
+type APIKey struct {
+	ID         string     `json:"id"`
+	UserID     int        `json:"user_id"`
+	Username   string     `json:"username"`
+	Name       string     `json:"name"`   // Chosen by the user to tell their keys apart
+	Scopes     []string   `json:"scopes"` // auth.ScopeRead, plus auth.ScopePost for keys that may post
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"` // Nil until the key is used
+
+	Key string `json:"-"` // Set when the key is created, only its hash is stored
+}
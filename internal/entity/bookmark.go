@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Bookmark represents a post saved by a user to their private bookmarks.
+//
+// @Description Represents a post saved by a user, optionally filed under a named collection.
+// @Model
+type Bookmark struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	PostID     int       `json:"post_id"`
+	Collection string    `json:"collection"` // Empty when the bookmark is not filed in a collection
+	CreatedAt  time.Time `json:"created_at"`
+	Post       Post      `json:"post"`
+}
@@ -3,9 +3,14 @@ package entity
 import "time"
 
 type Comment struct {
-	ID        int
-	PostID    int
-	UserID    int
-	Content   string
-	CreatedAt time.Time
+	ID              int
+	PostID          int
+	UserID          int
+	ParentCommentID int // 0 for a top-level comment
+	Depth           int // 0 for a top-level comment, parent depth + 1 for a reply
+	Content         string
+	ReplyCount      int
+	LikeCount       int
+	CreatedAt       time.Time
+	Author          *User // Summary of the commenter, nil until resolved
 }
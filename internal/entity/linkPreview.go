@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// LinkPreview represents the unfurled preview of the first URL found in a post.
+//
+// @Description Represents the OpenGraph preview of a link shared in a post.
+// @Model
+type LinkPreview struct {
+	PostID      int       `json:"post_id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// Poll represents a poll attached to a post.
+//
+// @Description Represents a poll attached to a post with its options and, when visible, their tallies.
+// @Model
+type Poll struct {
+	ID             int          `json:"id"`
+	PostID         int          `json:"post_id"`
+	MultipleChoice bool         `json:"multiple_choice"` // Voters may pick more than one option
+	ClosesAt       time.Time    `json:"closes_at"`
+	Options        []PollOption `json:"options"`
+	// ResultsVisible reports whether the tallies are shown, only after the viewer voted or the poll closed.
+	ResultsVisible bool `json:"results_visible"`
+}
+
+// PollOption represents one choice of a poll.
+type PollOption struct {
+	ID        int    `json:"id"`
+	PollID    int    `json:"poll_id"`
+	Text      string `json:"text"`
+	VoteCount int    `json:"vote_count"`
+	Voted     bool   `json:"voted"` // Whether the viewer voted for the option
+}
+
+// Poll option limits.
+const (
+	PollMinOptions = 2
+	PollMaxOptions = 4
+)
+
+// Closed reports whether the poll no longer accepts votes at the given time.
+func (p *Poll) Closed(now time.Time) bool {
+	return !now.Before(p.ClosesAt)
+}
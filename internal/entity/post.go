@@ -12,4 +12,32 @@ type Post struct {
 	ContentText      string    `json:"content_text"`
 	ContentImagePath string    `json:"content_image_path"`
 	CreatedAt        time.Time `json:"created_at"`
+	// CommentPermission controls who may comment on the post, one of the CommentPermission constants.
+	CommentPermission string `json:"comment_permission"`
+	// Pinned reports whether the author pinned the post to the top of their profile.
+	Pinned bool `json:"pinned"`
+	// Poll is the poll attached to the post, nil when the post has none.
+	Poll *Poll `json:"poll,omitempty"`
+	// LinkPreview is the preview of the first link in the post, nil until it has been fetched.
+	LinkPreview *LinkPreview `json:"link_preview,omitempty"`
+	// Author is a summary of the user who created the post, nil until resolved.
+	Author *User `json:"author,omitempty"`
 }
+
+// PostSearchFilter narrows a full-text post search.
+type PostSearchFilter struct {
+	Query    string     // Search text, "quoted phrases" and prefix* terms are supported
+	AuthorID int        // Only posts by this user when non-zero
+	From     *time.Time // Only posts created at or after this time when set
+	To       *time.Time // Only posts created before this time when set
+	Cursor   int        // ID of the last post of the previous page, 0 for the first page
+	Limit    int
+}
+
+// Comment permissions a post author can set on their post.
+const (
+	CommentPermissionEveryone  = "everyone"
+	CommentPermissionFollowers = "followers" // Only users following the post author
+	CommentPermissionFollowing = "following" // Only users the post author follows
+	CommentPermissionDisabled  = "disabled"
+)
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Session is a signed-in device. Its ID is carried in the "sid" claim of the JWTs issued for it, and it is
+// the family of the refresh tokens rotated for it.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	Device    string    `json:"device"`     // Device name reported by the client, may be empty
+	UserAgent string    `json:"user_agent"` // User agent of the client that signed in
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Current   bool      `json:"current"` // The session the request was made with
+
+	RefreshToken string `json:"-"` // Set when a refresh token is issued, only its hash is stored
+}
+
+// TokenPair is returned on login and on refresh. The access token is a short-lived JWT, the refresh token
+// is exchanged for a new pair when it expires.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // Lifetime of the access token in seconds
+
+	// TwoFactorToken is set instead of the tokens when a login needs a two-factor code, it is exchanged for
+	// the tokens together with a valid code.
+	TwoFactorToken string
+}
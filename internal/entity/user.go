@@ -13,10 +13,78 @@ type User struct {
 	LastName       string    `json:"last_name"`
 	Birthday       time.Time `json:"birthday"`
 	Email          string    `json:"email"`
-	Username       string    `json:"username"` // This matches the column `user_name`
-	Password       string    `json:"password"` // Additional field not part of the query
+	Username       string    `json:"username"`   // This matches the column `user_name`
+	Password       string    `json:"password"`   // Additional field not part of the query
+	AvatarKey      string    `json:"-"`          // Object storage key of the avatar, empty when unset
+	CoverKey       string    `json:"-"`          // Object storage key of the cover image, empty when unset
+	AvatarURL      string    `json:"avatar_url"` // Resolved from AvatarKey, not part of the query
+	CoverURL       string    `json:"cover_url"`  // Resolved from CoverKey, not part of the query
+	DisplayName    string    `json:"display_name"`
+	Bio            string    `json:"bio"`
+	Website        string    `json:"website"`
+	Location       string    `json:"location"`
+	EmailVerified  bool      `json:"email_verified"`
+	TOTPSecret     string    `json:"-"` // Set on enrollment, only used once TOTPEnabled
+	TOTPEnabled    bool      `json:"totp_enabled"`
 }
 
+// Profile fields that can be named in an update mask.
+const (
+	ProfileFieldFirstName   = "first_name"
+	ProfileFieldLastName    = "last_name"
+	ProfileFieldBirthday    = "birthday"
+	ProfileFieldPassword    = "password"
+	ProfileFieldEmail       = "email"
+	ProfileFieldDisplayName = "display_name"
+	ProfileFieldBio         = "bio"
+	ProfileFieldWebsite     = "website"
+	ProfileFieldLocation    = "location"
+)
+
+// Maximum lengths, in characters, of the free-form profile fields.
+const (
+	MaxDisplayNameLength = 64
+	MaxBioLength         = 500
+	MaxWebsiteLength     = 255
+	MaxLocationLength    = 100
+)
+
+// Profile image kinds a user can upload.
+const (
+	ProfileImageAvatar = "avatar"
+	ProfileImageCover  = "cover"
+)
+
 func (u User) String() string {
 	return fmt.Sprintf("%d:%s", u.ID, u.Username) // Custom format
 }
+
+// UserSearchResult is a user matched by a search with its relationship to the searcher.
+type UserSearchResult struct {
+	User
+	Following  bool `json:"following"`   // The searcher follows the user
+	FollowedBy bool `json:"followed_by"` // The user follows the searcher
+}
+
+// UserProfile is the public view of a user with their stats and relationship to the viewer.
+//
+// @Description Public user profile, never includes credentials or email.
+// @Model
+type UserProfile struct {
+	ID             int    `json:"id"`
+	Username       string `json:"username"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	DisplayName    string `json:"display_name"`
+	Bio            string `json:"bio"`
+	Website        string `json:"website"`
+	Location       string `json:"location"`
+	FollowerCount  int    `json:"follower_count"`
+	FollowingCount int    `json:"following_count"`
+	PostCount      int    `json:"post_count"`
+	AvatarURL      string `json:"avatar_url"`
+	CoverURL       string `json:"cover_url"`
+	Following      bool   `json:"following"`   // The viewer follows the user
+	FollowedBy     bool   `json:"followed_by"` // The user follows the viewer
+	Blocked        bool   `json:"blocked"`     // The viewer blocked the user
+}
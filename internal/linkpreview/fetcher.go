@@ -0,0 +1,231 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/net/html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Preview holds the OpenGraph metadata extracted from a web page.
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+}
+
+// FetcherInterface fetches the preview of a URL.
+type FetcherInterface interface {
+	Fetch(ctx context.Context, rawURL string) (*Preview, error)
+}
+
+// ErrBlockedAddress is returned when a URL resolves to an address that is not publicly routable.
+var ErrBlockedAddress = errors.New("address is not allowed")
+
+// maxRedirects is the number of redirects followed before giving up.
+const maxRedirects = 5
+
+// blockedPrefixes are the non public ranges not already covered by the net.IP helpers.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// HTTPFetcher fetches pages over HTTP with a timeout and a size cap. Connections are only made to
+// publicly routable addresses, checked after DNS resolution so redirects and rebinding cannot reach
+// internal services.
+type HTTPFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewHTTPFetcher(timeout time.Duration, maxBytes int64) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: checkDialAddress,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil, // Never hand the request to a proxy that would bypass the address check
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return checkScheme(req.URL)
+			},
+		},
+		maxBytes: maxBytes,
+	}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err := checkScheme(pageURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "news-feed-link-preview/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, rawURL)
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "text/html" {
+		return nil, fmt.Errorf("unsupported content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse html: %w", err)
+	}
+
+	// Relative image URLs are resolved against the final URL after redirects
+	preview := parsePreview(doc, resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// checkDialAddress refuses connections to addresses that are not publicly routable, it runs after DNS
+// resolution for every connection including those of redirects.
+func checkDialAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("url has no host")
+	}
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsMulticast() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePreview reads the OpenGraph title, description and image of a page, falling back to the
+// page title and meta description.
+func parsePreview(doc *html.Node, pageURL *url.URL) *Preview {
+	preview := &Preview{}
+	var title, description string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if title == "" && n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
+					title = strings.TrimSpace(n.FirstChild.Data)
+				}
+			case "meta":
+				key, content := metaKeyContent(n)
+				switch key {
+				case "og:title":
+					preview.Title = content
+				case "og:description":
+					preview.Description = content
+				case "og:image":
+					preview.ImageURL = content
+				case "description":
+					description = content
+				}
+			case "body":
+				// OpenGraph tags live in the head
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if preview.Title == "" {
+		preview.Title = title
+	}
+	if preview.Description == "" {
+		preview.Description = description
+	}
+	if preview.ImageURL != "" {
+		imageURL, err := pageURL.Parse(preview.ImageURL)
+		if err != nil || (imageURL.Scheme != "http" && imageURL.Scheme != "https") {
+			preview.ImageURL = ""
+		} else {
+			preview.ImageURL = imageURL.String()
+		}
+	}
+	return preview
+}
+
+func metaKeyContent(n *html.Node) (string, string) {
+	var key, content string
+	for _, attr := range n.Attr {
+		switch strings.ToLower(attr.Key) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(attr.Val))
+			}
+		case "content":
+			content = strings.TrimSpace(attr.Val)
+		}
+	}
+	return key, content
+}
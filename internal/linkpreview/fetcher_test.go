@@ -0,0 +1,173 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newTestFetcher returns a fetcher that may connect to the allowed address, every other address goes
+// through the regular check. Test servers listen on loopback, which the regular check refuses.
+func newTestFetcher(t *testing.T, allowed string, maxBytes int64) *HTTPFetcher {
+	t.Helper()
+	f := NewHTTPFetcher(2*time.Second, maxBytes)
+	dialer := &net.Dialer{
+		Timeout: 2 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			if address == allowed {
+				return nil
+			}
+			return checkDialAddress(network, address, c)
+		},
+	}
+	f.client.Transport.(*http.Transport).DialContext = dialer.DialContext
+	return f
+}
+
+func serveHTML(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, body)
+	}
+}
+
+func TestFetchRefusesPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(serveHTML(`<title>internal</title>`))
+	defer server.Close()
+
+	_, err := NewHTTPFetcher(2*time.Second, 1<<20).Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch() error = %v, want %v", err, ErrBlockedAddress)
+	}
+}
+
+func TestFetchRefusesRedirectToPrivateAddress(t *testing.T) {
+	var internalHits atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHits.Add(1)
+		serveHTML(`<title>internal</title>`)(w, r)
+	}))
+	defer internal.Close()
+
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer public.Close()
+
+	f := newTestFetcher(t, public.Listener.Addr().String(), 1<<20)
+	_, err := f.Fetch(context.Background(), public.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch() error = %v, want %v", err, ErrBlockedAddress)
+	}
+	if hits := internalHits.Load(); hits != 0 {
+		t.Fatalf("internal server was reached %d times", hits)
+	}
+}
+
+func TestFetchStopsAfterMaxRedirects(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		http.Redirect(w, r, fmt.Sprintf("/hop/%d", n), http.StatusFound)
+	}))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.Listener.Addr().String(), 1<<20)
+	_, err := f.Fetch(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("stopped after %d redirects", maxRedirects)) {
+		t.Fatalf("Fetch() error = %v, want the redirect limit", err)
+	}
+	if got := hits.Load(); got != maxRedirects+1 {
+		t.Fatalf("server hit %d times, want %d", got, maxRedirects+1)
+	}
+}
+
+func TestFetchReadsAtMostMaxBytes(t *testing.T) {
+	head := `<html><head><title>Kept</title>`
+	padding := `<meta name="padding" content="` + strings.Repeat("x", 4096) + `">`
+	tail := `<meta property="og:description" content="beyond the limit"></head><body></body></html>`
+	server := httptest.NewServer(serveHTML(head + padding + tail))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.Listener.Addr().String(), int64(len(head)+len(padding)))
+	preview, err := f.Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if preview.Title != "Kept" {
+		t.Errorf("Title = %q, want %q", preview.Title, "Kept")
+	}
+	if preview.Description != "" {
+		t.Errorf("Description = %q, want the tag past the limit to be ignored", preview.Description)
+	}
+}
+
+func TestFetchParsesOpenGraph(t *testing.T) {
+	server := httptest.NewServer(serveHTML(`<html><head>
+		<title>Page title</title>
+		<meta property="og:title" content="OG title">
+		<meta name="description" content="Meta description">
+		<meta property="og:image" content="/images/cover.png">
+		</head><body></body></html>`))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.Listener.Addr().String(), 1<<20)
+	preview, err := f.Fetch(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	want := Preview{
+		URL:         server.URL + "/article",
+		Title:       "OG title",
+		Description: "Meta description",
+		ImageURL:    server.URL + "/images/cover.png",
+	}
+	if *preview != want {
+		t.Fatalf("Fetch() = %+v, want %+v", *preview, want)
+	}
+}
+
+func TestFetchRefusesUnsupportedScheme(t *testing.T) {
+	for _, rawURL := range []string{"file:///etc/passwd", "gopher://example.com", "http://"} {
+		if _, err := NewHTTPFetcher(time.Second, 1<<20).Fetch(context.Background(), rawURL); err == nil {
+			t.Errorf("Fetch(%q) succeeded, want an error", rawURL)
+		}
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fc00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
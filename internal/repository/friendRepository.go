@@ -10,6 +10,7 @@ type FriendsRepositoryInterface interface {
 	GetFriends(userID int, limit int, cursor int) ([]entity.User, int, error)
 	FollowUser(currentUserID int, followedUserID int) error
 	UnfollowUser(currentUserID int, unfollowedUserID int) error
+	IsFollowing(currentUserID int, followedUserID int) (bool, error)
 }
 
 type FriendsRepository struct {
@@ -20,7 +21,7 @@ type FriendsRepository struct {
 func (r *FriendsRepository) GetFriends(userID int, limit int, cursor int) ([]entity.User, int, error) {
 	// Query to get followers with pagination using cursor
 	rows, err := r.db.Query(
-		"SELECT u.id, u.first_name, u.last_name, u.email, u.user_name, uu.fk_follower_id FROM user u "+
+		"SELECT u.id, u.first_name, u.last_name, u.email, u.user_name, u.avatar_key, u.cover_key, uu.fk_follower_id FROM user u "+
 			"JOIN user_user uu ON u.id = uu.fk_follower_id "+
 			"WHERE uu.fk_user_id = ? AND uu.fk_follower_id > ? ORDER BY uu.fk_follower_id ASC LIMIT ?",
 		userID, cursor, limit,
@@ -44,7 +45,8 @@ func (r *FriendsRepository) GetFriends(userID int, limit int, cursor int) ([]ent
 
 		// Scan all the required fields including fk_follower_id
 		if err := rows.Scan(
-			&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Username, &followerID,
+			&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Username, &user.AvatarKey, &user.CoverKey,
+			&followerID,
 		); err != nil {
 			return nil, 0, err
 		}
@@ -88,3 +90,16 @@ func (r *FriendsRepository) UnfollowUser(currentUserID int, unfollowedUserID int
 	}
 	return err
 }
+
+// IsFollowing reports whether the current user follows the followed user.
+func (r *FriendsRepository) IsFollowing(currentUserID int, followedUserID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM user_user WHERE fk_user_id = ? AND fk_follower_id = ?)",
+		currentUserID, followedUserID,
+	).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
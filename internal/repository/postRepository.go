@@ -15,24 +15,74 @@ type PostRepositoryInterface interface {
 	UpdatePost(post entity.Post) (*entity.Post, error)
 	DeletePost(id int) error
 	CreateComment(comment entity.Comment) (*entity.Comment, error)
+	GetCommentByID(commentID int) (*entity.Comment, error)
+	UpdateComment(commentID int, content string) (*entity.Comment, error)
+	DeleteComment(commentID int) ([]int, error)
 	AddLike(postID int, userID int) (*entity.Like, error)
 	GetPostsByUserID(userID int, limit int, cursor int) ([]entity.Post, int, error)
 	GetAllPosts() ([]entity.Post, error)
 	GetComments(postID int, cursor int, limit int) ([]entity.Comment, int, error)
+	GetCommentReplies(commentID int, cursor int, limit int) ([]entity.Comment, int, error)
+	GetTopComments(postID int, cursorLikeCount int, cursorID int, limit int) ([]entity.Comment, error)
+	AddCommentLike(commentID int, userID int) error
 	GetLikes(postID int, cursor time.Time, limit int) ([]entity.Like, *time.Time, error)
 	GetLikeCount(postID int) (int, error)
+	SaveBookmark(userID int, postID int, collection string) error
+	DeleteBookmark(userID int, postID int) error
+	GetBookmarks(userID int, collection string, cursor int, limit int) ([]entity.Bookmark, int, error)
+	PinPost(postID int, userID int, maxPinned int) error
+	UnpinPost(postID int) error
+	GetPinnedPostsByUserID(userID int) ([]entity.Post, error)
+	GetPollByPostID(postID int) (*entity.Poll, error)
+	GetPollVotes(pollID int, userID int) ([]int, error)
+	AddPollVotes(pollID int, userID int, optionIDs []int, multipleChoice bool) ([]int, error)
+	SaveLinkPreview(preview entity.LinkPreview) error
+	DeleteLinkPreview(postID int) error
+	GetLinkPreviewByPostID(postID int) (*entity.LinkPreview, error)
+	SearchPosts(filter entity.PostSearchFilter) ([]entity.Post, int, error)
 }
 
+// ErrPostNotFound is returned when a post does not exist or is no longer visible.
+var ErrPostNotFound = errors.New("post not found")
+
+// ErrCommentNotFound is returned when a comment does not exist.
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ErrCommentAlreadyLiked is returned when a user likes a comment again.
+var ErrCommentAlreadyLiked = errors.New("comment already liked")
+
+// ErrPinnedPostLimitReached is returned when a user already pinned the maximum number of posts.
+var ErrPinnedPostLimitReached = errors.New("pinned post limit reached")
+
+// ErrPollNotFound is returned when a post has no poll.
+var ErrPollNotFound = errors.New("poll not found")
+
+// ErrPollAlreadyVoted is returned when a user votes again on a single choice poll.
+var ErrPollAlreadyVoted = errors.New("already voted on this poll")
+
+// ErrLinkPreviewNotFound is returned when a post has no link preview.
+var ErrLinkPreviewNotFound = errors.New("link preview not found")
+
 type PostRepository struct {
 	db *sql.DB
 }
 
 func (r *PostRepository) CreatePost(post entity.Post) (*entity.Post, error) {
+	// The post and its poll are created together
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	// Insert the post without using RETURNING
-	result, err := r.db.Exec(
+	if post.CommentPermission == "" {
+		post.CommentPermission = entity.CommentPermissionEveryone
+	}
+	result, err := tx.Exec(
 		`
-		INSERT INTO post (content_text, content_image_path, fk_user_id) VALUES (?, ?, ?)`,
-		post.ContentText, post.ContentImagePath, post.UserID,
+		INSERT INTO post (content_text, content_image_path, fk_user_id, comment_permission) VALUES (?, ?, ?, ?)`,
+		post.ContentText, post.ContentImagePath, post.UserID, post.CommentPermission,
 	)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error while inserting new post: %v", err))
@@ -48,34 +98,85 @@ func (r *PostRepository) CreatePost(post entity.Post) (*entity.Post, error) {
 
 	// Query the inserted post to get full details, including created_at
 	var createdPost entity.Post
-	err = r.db.QueryRow(
-		`SELECT id, content_text, content_image_path, fk_user_id, created_at 
+	err = tx.QueryRow(
+		`SELECT id, content_text, content_image_path, fk_user_id, created_at, comment_permission 
 		FROM post WHERE id = ?`, postID,
 	).Scan(
 		&createdPost.ID, &createdPost.ContentText, &createdPost.ContentImagePath, &createdPost.UserID,
-		&createdPost.CreatedAt,
+		&createdPost.CreatedAt, &createdPost.CommentPermission,
 	)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error while retrieving created post: %v", err))
 		return nil, err
 	}
 
+	if post.Poll != nil {
+		createdPost.Poll, err = createPoll(tx, createdPost.ID, *post.Poll)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while creating poll for post %d: %v", createdPost.ID, err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	// Return the created post with all details
 	return &createdPost, nil
 }
 
+// createPoll inserts a poll and its options for a post within the post's transaction.
+func createPoll(tx *sql.Tx, postID int, poll entity.Poll) (*entity.Poll, error) {
+	result, err := tx.Exec(
+		`INSERT INTO poll (fk_post_id, multiple_choice, closes_at) VALUES (?, ?, ?)`,
+		postID, poll.MultipleChoice, poll.ClosesAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pollID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	createdPoll := entity.Poll{
+		ID:             int(pollID),
+		PostID:         postID,
+		MultipleChoice: poll.MultipleChoice,
+		ClosesAt:       poll.ClosesAt,
+	}
+	for position, option := range poll.Options {
+		result, err := tx.Exec(
+			`INSERT INTO poll_option (fk_poll_id, position, text) VALUES (?, ?, ?)`,
+			pollID, position, option.Text,
+		)
+		if err != nil {
+			return nil, err
+		}
+		optionID, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		createdPoll.Options = append(
+			createdPoll.Options, entity.PollOption{ID: int(optionID), PollID: int(pollID), Text: option.Text},
+		)
+	}
+	return &createdPoll, nil
+}
+
 func (r *PostRepository) GetPostByID(id int) (*entity.Post, error) {
 	var post entity.Post
 	row := r.db.QueryRow(
 		`
-		SELECT id, content_text, content_image_path, fk_user_id 
+		SELECT id, content_text, content_image_path, fk_user_id, comment_permission 
 		FROM post 
 		WHERE id = ?`, id,
 	)
-	err := row.Scan(&post.ID, &post.ContentText, &post.ContentImagePath, &post.UserID)
+	err := row.Scan(&post.ID, &post.ContentText, &post.ContentImagePath, &post.UserID, &post.CommentPermission)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("post not found")
+			return nil, ErrPostNotFound
 		}
 		return nil, err
 	}
@@ -86,9 +187,9 @@ func (r *PostRepository) UpdatePost(post entity.Post) (*entity.Post, error) {
 	_, err := r.db.Exec(
 		`
 		UPDATE post 
-		SET content_text = ?
+		SET content_text = ?, comment_permission = COALESCE(NULLIF(?, ''), comment_permission)
 		WHERE id = ?`,
-		post.ContentText, post.ID,
+		post.ContentText, post.CommentPermission, post.ID,
 	)
 
 	if err != nil {
@@ -99,7 +200,7 @@ func (r *PostRepository) UpdatePost(post entity.Post) (*entity.Post, error) {
 	var updatedPost entity.Post
 	err = r.db.QueryRow(
 		`
-		SELECT id, fk_user_id, content_text, content_image_path, created_at 
+		SELECT id, fk_user_id, content_text, content_image_path, created_at, comment_permission 
 		FROM post 
 		WHERE id = ?`,
 		post.ID,
@@ -109,6 +210,7 @@ func (r *PostRepository) UpdatePost(post entity.Post) (*entity.Post, error) {
 		&updatedPost.ContentText,
 		&updatedPost.ContentImagePath,
 		&updatedPost.CreatedAt,
+		&updatedPost.CommentPermission,
 	)
 
 	if err != nil {
@@ -126,9 +228,13 @@ func (r *PostRepository) DeletePost(id int) error {
 
 func (r *PostRepository) CreateComment(comment entity.Comment) (*entity.Comment, error) {
 	// Execute the insert query
+	var parentCommentID sql.NullInt64
+	if comment.ParentCommentID != 0 {
+		parentCommentID = sql.NullInt64{Int64: int64(comment.ParentCommentID), Valid: true}
+	}
 	result, err := r.db.Exec(
-		`INSERT INTO comment (fk_post_id, fk_user_id, content) VALUES (?, ?, ?)`,
-		comment.PostID, comment.UserID, comment.Content,
+		`INSERT INTO comment (fk_post_id, fk_user_id, parent_comment_id, depth, content) VALUES (?, ?, ?, ?, ?)`,
+		comment.PostID, comment.UserID, parentCommentID, comment.Depth, comment.Content,
 	)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error while creating comment: %v", err))
@@ -143,23 +249,86 @@ func (r *PostRepository) CreateComment(comment entity.Comment) (*entity.Comment,
 	}
 
 	// Query the inserted comment using the retrieved comment ID
-	query := `SELECT id, fk_post_id, fk_user_id, content, created_at FROM comment WHERE id = ?`
-	err = r.db.QueryRow(query, commentID).Scan(
-		&comment.ID,
-		&comment.PostID,
-		&comment.UserID,
-		&comment.Content,
-		&comment.CreatedAt,
-	)
+	createdComment, err := r.GetCommentByID(int(commentID))
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error retrieving created comment: %v", err))
 		return nil, err
 	}
 
 	// Return the populated comment entity
+	return createdComment, nil
+}
+
+func (r *PostRepository) GetCommentByID(commentID int) (*entity.Comment, error) {
+	row := r.db.QueryRow(
+		`
+		SELECT `+commentColumns+`
+		FROM comment c
+		WHERE c.id = ?`, commentID,
+	)
+	comment, err := scanComment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
 	return &comment, nil
 }
 
+func (r *PostRepository) UpdateComment(commentID int, content string) (*entity.Comment, error) {
+	_, err := r.db.Exec(`UPDATE comment SET content = ? WHERE id = ?`, content, commentID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while updating comment %d: %v", commentID, err))
+		return nil, err
+	}
+	return r.GetCommentByID(commentID)
+}
+
+// DeleteComment removes a comment, its replies are removed by the ON DELETE CASCADE on parent_comment_id.
+// It returns the IDs of the replies removed with it, at any depth.
+func (r *PostRepository) DeleteComment(commentID int) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(
+		`
+		WITH RECURSIVE replies (id) AS (
+			SELECT id FROM comment WHERE parent_comment_id = ?
+			UNION ALL
+			SELECT c.id FROM comment c JOIN replies ON c.parent_comment_id = replies.id
+		)
+		SELECT id FROM replies`, commentID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while getting replies of comment %d: %v", commentID, err))
+		return nil, err
+	}
+	var replyIDs []int
+	for rows.Next() {
+		var replyID int
+		if err := rows.Scan(&replyID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		replyIDs = append(replyIDs, replyID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM comment WHERE id = ?`, commentID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while deleting comment %d: %v", commentID, err))
+		return nil, err
+	}
+	return replyIDs, tx.Commit()
+}
+
 func (r *PostRepository) AddLike(postID int, userID int) (*entity.Like, error) {
 	_, err := r.db.Exec(
 		`
@@ -193,7 +362,8 @@ func (r *PostRepository) AddLike(postID int, userID int) (*entity.Like, error) {
 
 func (r *PostRepository) GetPostsByUserID(userID int, limit int, cursor int) ([]entity.Post, int, error) {
 	rows, err := r.db.Query(
-		"SELECT id, content_text, content_image_path FROM post p WHERE p.fk_user_id = ? AND p.id > ? ORDER BY id ASC LIMIT ?",
+		// Pinned posts are returned separately by GetPinnedPostsByUserID
+		"SELECT id, content_text, content_image_path FROM post p WHERE p.fk_user_id = ? AND p.pinned_at IS NULL AND p.id > ? ORDER BY id ASC LIMIT ?",
 		userID, cursor, limit,
 	)
 	if err != nil {
@@ -246,13 +416,106 @@ func (r *PostRepository) GetAllPosts() ([]entity.Post, error) {
 
 func (r *PostRepository) GetComments(postID int, cursor int, limit int) ([]entity.Comment, int, error) {
 	rows, err := r.db.Query(
-		`SELECT id, fk_post_id, fk_user_id, content FROM comment WHERE fk_post_id = ? AND id > ? ORDER BY id ASC LIMIT ?`,
+		`
+		SELECT `+commentColumns+`
+		FROM comment c
+		WHERE c.fk_post_id = ? AND c.parent_comment_id IS NULL AND c.id > ?
+		ORDER BY c.id ASC LIMIT ?`,
 		postID, cursor, limit,
 	)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error while retrieving comments for post %d: %v", postID, err))
 		return nil, 0, err
 	}
+	return r.scanComments(rows)
+}
+
+func (r *PostRepository) GetCommentReplies(commentID int, cursor int, limit int) ([]entity.Comment, int, error) {
+	rows, err := r.db.Query(
+		`
+		SELECT `+commentColumns+`
+		FROM comment c
+		WHERE c.parent_comment_id = ? AND c.id > ?
+		ORDER BY c.id ASC LIMIT ?`,
+		commentID, cursor, limit,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while retrieving replies for comment %d: %v", commentID, err))
+		return nil, 0, err
+	}
+	return r.scanComments(rows)
+}
+
+func (r *PostRepository) GetTopComments(postID int, cursorLikeCount int, cursorID int, limit int) ([]entity.Comment, error) {
+	// Keyset pagination over (like_count DESC, id ASC), a zero cursor ID starts from the most liked comment
+	rows, err := r.db.Query(
+		`
+		SELECT `+commentColumns+`
+		FROM comment c
+		WHERE c.fk_post_id = ? AND c.parent_comment_id IS NULL
+			AND (? = 0 OR c.like_count < ? OR (c.like_count = ? AND c.id > ?))
+		ORDER BY c.like_count DESC, c.id ASC LIMIT ?`,
+		postID, cursorID, cursorLikeCount, cursorLikeCount, cursorID, limit,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while retrieving top comments for post %d: %v", postID, err))
+		return nil, err
+	}
+	comments, _, err := r.scanComments(rows)
+	return comments, err
+}
+
+func (r *PostRepository) AddCommentLike(commentID int, userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`INSERT IGNORE INTO comment_like (fk_comment_id, fk_user_id) VALUES (?, ?)`,
+		commentID, userID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while inserting new comment like: %v", err))
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrCommentAlreadyLiked
+	}
+
+	// Keep the denormalized like count used for top ordering in sync
+	_, err = tx.Exec(`UPDATE comment SET like_count = like_count + 1 WHERE id = ?`, commentID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while updating like count of comment %d: %v", commentID, err))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// commentColumns is the comment column list read by scanComment, including the direct reply count.
+const commentColumns = `c.id, c.fk_post_id, c.fk_user_id, c.parent_comment_id, c.depth, c.content, c.like_count, c.created_at,
+			(SELECT COUNT(*) FROM comment r WHERE r.parent_comment_id = c.id)`
+
+// scanComment reads a single row selected with commentColumns.
+func scanComment(row interface{ Scan(dest ...any) error }) (entity.Comment, error) {
+	var comment entity.Comment
+	var parentCommentID sql.NullInt64
+	err := row.Scan(
+		&comment.ID, &comment.PostID, &comment.UserID, &parentCommentID, &comment.Depth, &comment.Content,
+		&comment.LikeCount, &comment.CreatedAt, &comment.ReplyCount,
+	)
+	comment.ParentCommentID = int(parentCommentID.Int64)
+	return comment, err
+}
+
+// scanComments reads comment rows selected with commentColumns and closes them.
+func (r *PostRepository) scanComments(rows *sql.Rows) ([]entity.Comment, int, error) {
 	defer func(rows *sql.Rows) {
 		err := rows.Close()
 		if err != nil {
@@ -264,8 +527,8 @@ func (r *PostRepository) GetComments(postID int, cursor int, limit int) ([]entit
 	var comments []entity.Comment
 	var nextCursor int
 	for rows.Next() {
-		var comment entity.Comment
-		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.UserID, &comment.Content); err != nil {
+		comment, err := scanComment(rows)
+		if err != nil {
 			logger.LogError(fmt.Sprintf("Error while scanning comment: %v", err))
 			return nil, 0, err
 		}
@@ -317,3 +580,417 @@ func (r *PostRepository) GetLikeCount(postID int) (int, error) {
 	}
 	return count, nil
 }
+
+// SaveBookmark saves a visible post to the user's bookmarks, filing it under the named collection
+// when one is given. Saving an already bookmarked post moves it to the given collection.
+func (r *PostRepository) SaveBookmark(userID int, postID int, collection string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var visible bool
+	err = tx.QueryRow(`SELECT visible FROM post WHERE id = ?`, postID).Scan(&visible)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !visible) {
+		return ErrPostNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var collectionID sql.NullInt64
+	if collection != "" {
+		_, err = tx.Exec(
+			`INSERT IGNORE INTO bookmark_collection (fk_user_id, name) VALUES (?, ?)`,
+			userID, collection,
+		)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while creating bookmark collection %s: %v", collection, err))
+			return err
+		}
+		err = tx.QueryRow(
+			`SELECT id FROM bookmark_collection WHERE fk_user_id = ? AND name = ?`,
+			userID, collection,
+		).Scan(&collectionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(
+		`
+		INSERT INTO bookmark (fk_user_id, fk_post_id, fk_collection_id) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE fk_collection_id = VALUES(fk_collection_id)`,
+		userID, postID, collectionID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while saving bookmark of post %d: %v", postID, err))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostRepository) DeleteBookmark(userID int, postID int) error {
+	_, err := r.db.Exec(`DELETE FROM bookmark WHERE fk_user_id = ? AND fk_post_id = ?`, userID, postID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while deleting bookmark of post %d: %v", postID, err))
+	}
+	return err
+}
+
+// GetBookmarks returns the user's bookmarks newest first, optionally limited to one collection.
+// Bookmarks of hidden posts are skipped, deleted posts drop their bookmarks on delete.
+func (r *PostRepository) GetBookmarks(userID int, collection string, cursor int, limit int) ([]entity.Bookmark, int, error) {
+	rows, err := r.db.Query(
+		`
+		SELECT b.id, b.fk_user_id, b.fk_post_id, COALESCE(bc.name, ''), b.created_at,
+			p.id, p.fk_user_id, p.content_text, p.content_image_path, p.created_at, p.comment_permission
+		FROM bookmark b
+		JOIN post p ON p.id = b.fk_post_id AND p.visible = TRUE
+		LEFT JOIN bookmark_collection bc ON bc.id = b.fk_collection_id
+		WHERE b.fk_user_id = ? AND (? = 0 OR b.id < ?) AND (? = '' OR bc.name = ?)
+		ORDER BY b.id DESC LIMIT ?`,
+		userID, cursor, cursor, collection, collection, limit,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while retrieving bookmarks for user %d: %v", userID, err))
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Printf("Error closing rows: %v\n", err)
+			return
+		}
+	}(rows)
+
+	var bookmarks []entity.Bookmark
+	var nextCursor int
+	for rows.Next() {
+		var bookmark entity.Bookmark
+		var contentImagePath sql.NullString
+		err := rows.Scan(
+			&bookmark.ID, &bookmark.UserID, &bookmark.PostID, &bookmark.Collection, &bookmark.CreatedAt,
+			&bookmark.Post.ID, &bookmark.Post.UserID, &bookmark.Post.ContentText, &contentImagePath,
+			&bookmark.Post.CreatedAt, &bookmark.Post.CommentPermission,
+		)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while scanning bookmark: %v", err))
+			return nil, 0, err
+		}
+		bookmark.Post.ContentImagePath = contentImagePath.String
+		bookmarks = append(bookmarks, bookmark)
+		nextCursor = bookmark.ID // Bookmarks are newest first, the last ID continues the list
+	}
+	return bookmarks, nextCursor, nil
+}
+
+// PinPost pins a post of the user to their profile unless they already pinned maxPinned posts.
+// Pinning an already pinned post is a no-op.
+func (r *PostRepository) PinPost(postID int, userID int, maxPinned int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the user's pinned posts so concurrent pins cannot exceed the limit
+	rows, err := tx.Query(
+		`SELECT id FROM post WHERE fk_user_id = ? AND pinned_at IS NOT NULL FOR UPDATE`,
+		userID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while retrieving pinned posts for user %d: %v", userID, err))
+		return err
+	}
+	var pinnedIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		pinnedIDs = append(pinnedIDs, id)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, id := range pinnedIDs {
+		if id == postID {
+			return nil
+		}
+	}
+	if len(pinnedIDs) >= maxPinned {
+		return ErrPinnedPostLimitReached
+	}
+
+	_, err = tx.Exec(
+		`UPDATE post SET pinned_at = CURRENT_TIMESTAMP WHERE id = ? AND fk_user_id = ?`,
+		postID, userID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while pinning post %d: %v", postID, err))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostRepository) UnpinPost(postID int) error {
+	_, err := r.db.Exec(`UPDATE post SET pinned_at = NULL WHERE id = ?`, postID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while unpinning post %d: %v", postID, err))
+	}
+	return err
+}
+
+// GetPinnedPostsByUserID returns the user's visible pinned posts, most recently pinned first.
+func (r *PostRepository) GetPinnedPostsByUserID(userID int) ([]entity.Post, error) {
+	rows, err := r.db.Query(
+		`
+		SELECT id, content_text, content_image_path, created_at
+		FROM post
+		WHERE fk_user_id = ? AND pinned_at IS NOT NULL AND visible = TRUE
+		ORDER BY pinned_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Printf("Error closing rows: %v\n", err)
+			return
+		}
+	}(rows)
+
+	var posts []entity.Post
+	for rows.Next() {
+		post := entity.Post{UserID: userID, Pinned: true}
+		if err := rows.Scan(&post.ID, &post.ContentText, &post.ContentImagePath, &post.CreatedAt); err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// GetPollByPostID returns the poll of a post with the persisted tallies of its options.
+func (r *PostRepository) GetPollByPostID(postID int) (*entity.Poll, error) {
+	poll := entity.Poll{PostID: postID}
+	err := r.db.QueryRow(
+		`SELECT id, multiple_choice, closes_at FROM poll WHERE fk_post_id = ?`, postID,
+	).Scan(&poll.ID, &poll.MultipleChoice, &poll.ClosesAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPollNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, text, vote_count FROM poll_option WHERE fk_poll_id = ? ORDER BY position ASC`, poll.ID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while retrieving options of poll %d: %v", poll.ID, err))
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Printf("Error closing rows: %v\n", err)
+			return
+		}
+	}(rows)
+
+	for rows.Next() {
+		option := entity.PollOption{PollID: poll.ID}
+		if err := rows.Scan(&option.ID, &option.Text, &option.VoteCount); err != nil {
+			return nil, err
+		}
+		poll.Options = append(poll.Options, option)
+	}
+	return &poll, nil
+}
+
+// GetPollVotes returns the IDs of the poll options the user voted for.
+func (r *PostRepository) GetPollVotes(pollID int, userID int) ([]int, error) {
+	rows, err := r.db.Query(
+		`SELECT fk_option_id FROM poll_vote WHERE fk_poll_id = ? AND fk_user_id = ?`, pollID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Printf("Error closing rows: %v\n", err)
+			return
+		}
+	}(rows)
+
+	var optionIDs []int
+	for rows.Next() {
+		var optionID int
+		if err := rows.Scan(&optionID); err != nil {
+			return nil, err
+		}
+		optionIDs = append(optionIDs, optionID)
+	}
+	return optionIDs, nil
+}
+
+// AddPollVotes records the user's votes and bumps the persisted tallies, returning the option IDs
+// that gained a vote. Options already voted for are skipped, a single choice poll accepts one vote.
+func (r *PostRepository) AddPollVotes(pollID int, userID int, optionIDs []int, multipleChoice bool) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if !multipleChoice {
+		// Lock the user's votes on the poll so concurrent votes cannot both succeed
+		var votes int
+		err = tx.QueryRow(
+			`SELECT COUNT(*) FROM poll_vote WHERE fk_poll_id = ? AND fk_user_id = ? FOR UPDATE`,
+			pollID, userID,
+		).Scan(&votes)
+		if err != nil {
+			return nil, err
+		}
+		if votes > 0 {
+			return nil, ErrPollAlreadyVoted
+		}
+	}
+
+	var votedOptionIDs []int
+	for _, optionID := range optionIDs {
+		result, err := tx.Exec(
+			`INSERT IGNORE INTO poll_vote (fk_poll_id, fk_option_id, fk_user_id) VALUES (?, ?, ?)`,
+			pollID, optionID, userID,
+		)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while inserting vote on poll %d: %v", pollID, err))
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rows == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(`UPDATE poll_option SET vote_count = vote_count + 1 WHERE id = ?`, optionID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while updating tally of poll option %d: %v", optionID, err))
+			return nil, err
+		}
+		votedOptionIDs = append(votedOptionIDs, optionID)
+	}
+
+	if len(votedOptionIDs) == 0 {
+		return nil, ErrPollAlreadyVoted
+	}
+	return votedOptionIDs, tx.Commit()
+}
+
+// SaveLinkPreview stores the link preview of a post, replacing any previous one.
+func (r *PostRepository) SaveLinkPreview(preview entity.LinkPreview) error {
+	_, err := r.db.Exec(
+		`
+		INSERT INTO link_preview (fk_post_id, url, title, description, image_url) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE url = VALUES(url), title = VALUES(title), description = VALUES(description),
+			image_url = VALUES(image_url), created_at = CURRENT_TIMESTAMP`,
+		preview.PostID, preview.URL, preview.Title, preview.Description, preview.ImageURL,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while saving link preview of post %d: %v", preview.PostID, err))
+	}
+	return err
+}
+
+func (r *PostRepository) DeleteLinkPreview(postID int) error {
+	_, err := r.db.Exec(`DELETE FROM link_preview WHERE fk_post_id = ?`, postID)
+	return err
+}
+
+func (r *PostRepository) GetLinkPreviewByPostID(postID int) (*entity.LinkPreview, error) {
+	var preview entity.LinkPreview
+	var description sql.NullString
+	err := r.db.QueryRow(
+		`SELECT fk_post_id, url, title, description, image_url, created_at FROM link_preview WHERE fk_post_id = ?`,
+		postID,
+	).Scan(&preview.PostID, &preview.URL, &preview.Title, &description, &preview.ImageURL, &preview.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLinkPreviewNotFound
+		}
+		return nil, err
+	}
+	preview.Description = description.String
+	return &preview, nil
+}
+
+// SearchPosts returns visible posts matching the boolean mode query, newest first.
+func (r *PostRepository) SearchPosts(filter entity.PostSearchFilter) ([]entity.Post, int, error) {
+	query := `
+		SELECT id, fk_user_id, content_text, content_image_path, created_at, comment_permission
+		FROM post
+		WHERE MATCH(content_text) AGAINST (? IN BOOLEAN MODE) AND visible = TRUE`
+	args := []interface{}{filter.Query}
+	if filter.AuthorID != 0 {
+		query += ` AND fk_user_id = ?`
+		args = append(args, filter.AuthorID)
+	}
+	if filter.From != nil {
+		query += ` AND created_at >= ?`
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		query += ` AND created_at < ?`
+		args = append(args, *filter.To)
+	}
+	if filter.Cursor != 0 {
+		query += ` AND id < ?`
+		args = append(args, filter.Cursor)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error while searching posts: %v", err))
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			fmt.Printf("Error closing rows: %v\n", err)
+			return
+		}
+	}(rows)
+
+	var posts []entity.Post
+	var nextCursor int
+	for rows.Next() {
+		var post entity.Post
+		var contentImagePath sql.NullString
+		err := rows.Scan(
+			&post.ID, &post.UserID, &post.ContentText, &contentImagePath, &post.CreatedAt, &post.CommentPermission,
+		)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error while scanning post: %v", err))
+			return nil, 0, err
+		}
+		post.ContentImagePath = contentImagePath.String
+		posts = append(posts, post)
+		nextCursor = post.ID // Results are newest first, the last ID continues the search
+	}
+	return posts, nextCursor, nil
+}
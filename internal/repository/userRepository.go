@@ -4,7 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql" // Import the MySQL driver
+	"github.com/go-sql-driver/mysql" // Import the MySQL driver
 	"news-feed/internal/entity"
 	"news-feed/pkg/logger"
 	"strings"
@@ -13,13 +13,39 @@ import (
 // UserRepositoryInterface defines the methods for user data operations.
 type UserRepositoryInterface interface {
 	GetByUserName(userName string) (entity.User, error)
-	CreateUser(user entity.User) error
-	UpdateUser(user entity.User) error
+	CreateUser(user entity.User) (int, error)
+	UpdateUser(user entity.User, fields []string) error
+	MarkEmailVerified(userID int, email string) (bool, error)
+	UpdatePasswordHash(userName string, oldHashedPassword string, hashedPassword string, salt string) error
 	GetAllUserNames() ([]string, error)
 	GetByUserID(userID int) (entity.User, error)
+	GetByEmail(email string) (entity.User, error)
+	GetAllByEmail(email string) ([]entity.User, error)
 	GetUsers(userIDs []int) ([]entity.User, error)
+	SearchUsersByPrefix(prefix string, limit int) ([]entity.User, error)
+	GetFollowRelations(userID int, otherUserIDs []int) (following map[int]bool, followers map[int]bool, err error)
+	GetProfileCounts(userID int) (followerCount int, followingCount int, postCount int, err error)
+	IsBlocked(userID int, blockedUserID int) (bool, error)
+	BlockUser(userID int, blockedUserID int) error
+	UnblockUser(userID int, blockedUserID int) error
+	UpdateProfileImage(userID int, kind string, objectKey string) (previousKey string, err error)
+	SetTOTPSecret(userID int, secret string) error
+	EnableTOTP(userID int, recoveryCodeHashes []string) (bool, error)
+	UseRecoveryCode(userID int, codeHash string) (bool, error)
+	GetUserIDByIdentity(issuer string, subject string) (int, error)
+	LinkIdentity(userID int, issuer string, subject string) error
+	CreateUserWithIdentity(user entity.User, issuer string, subject string) (int, error)
 }
 
+// ErrUserNotFound is returned when no user matches the lookup.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUsernameTaken is returned when a user is created with the username of another user.
+var ErrUsernameTaken = errors.New("username already taken")
+
+// MySQL error number of a duplicate key in a unique index
+const mysqlErrDuplicateEntry = 1062
+
 // UserRepository is a concrete implementation of UserRepositoryInterface.
 type UserRepository struct {
 	db *sql.DB
@@ -27,18 +53,21 @@ type UserRepository struct {
 
 // GetByUserID retrieves a user by their user id.
 func (r *UserRepository) GetByUserID(userID int) (entity.User, error) {
-	query := `SELECT id, hashed_password, salt, first_name, last_name, email, user_name FROM user WHERE id = ?`
+	query := `SELECT id, hashed_password, salt, first_name, last_name, email, user_name, avatar_key, cover_key,
+		display_name, bio, website, location, email_verified, totp_secret, totp_enabled
+		FROM user WHERE id = ?`
 	row := r.db.QueryRow(query, userID)
 
 	var user entity.User
 	err := row.Scan(
 		&user.ID, &user.HashedPassword, &user.Salt, &user.FirstName, &user.LastName, &user.Email,
-		&user.Username,
+		&user.Username, &user.AvatarKey, &user.CoverKey, &user.DisplayName, &user.Bio, &user.Website, &user.Location,
+		&user.EmailVerified, &user.TOTPSecret, &user.TOTPEnabled,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.LogError(fmt.Sprintf("User not found"))
-			return user, fmt.Errorf("user not found")
+			return user, ErrUserNotFound
 		}
 		logger.LogError(fmt.Sprintf("error getting user: %v", err))
 		return user, fmt.Errorf("error getting user: %v", err)
@@ -48,18 +77,21 @@ func (r *UserRepository) GetByUserID(userID int) (entity.User, error) {
 
 // GetByUserName retrieves a user by their username.
 func (r *UserRepository) GetByUserName(userName string) (entity.User, error) {
-	query := `SELECT id, hashed_password, salt, first_name, last_name, email, user_name FROM user WHERE user_name = ?`
+	query := `SELECT id, hashed_password, salt, first_name, last_name, email, user_name, avatar_key, cover_key,
+		display_name, bio, website, location, email_verified, totp_secret, totp_enabled
+		FROM user WHERE user_name = ?`
 	row := r.db.QueryRow(query, userName)
 
 	var user entity.User
 	err := row.Scan(
 		&user.ID, &user.HashedPassword, &user.Salt, &user.FirstName, &user.LastName, &user.Email,
-		&user.Username,
+		&user.Username, &user.AvatarKey, &user.CoverKey, &user.DisplayName, &user.Bio, &user.Website, &user.Location,
+		&user.EmailVerified, &user.TOTPSecret, &user.TOTPEnabled,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.LogError(fmt.Sprintf("User not found"))
-			return user, fmt.Errorf("user not found")
+			return user, ErrUserNotFound
 		}
 		logger.LogError(fmt.Sprintf("error getting user: %v", err))
 		return user, fmt.Errorf("error getting user: %v", err)
@@ -67,7 +99,50 @@ func (r *UserRepository) GetByUserName(userName string) (entity.User, error) {
 	return user, nil
 }
 
+// GetByEmail retrieves the first user registered with the email.
+func (r *UserRepository) GetByEmail(email string) (entity.User, error) {
+	query := `SELECT id, first_name, last_name, email, email_verified, user_name FROM user WHERE email = ? ORDER BY id LIMIT 1`
+	row := r.db.QueryRow(query, email)
+
+	var user entity.User
+	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.EmailVerified, &user.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return user, ErrUserNotFound
+		}
+		logger.LogError(fmt.Sprintf("error getting user by email: %v", err))
+		return user, fmt.Errorf("error getting user: %v", err)
+	}
+	return user, nil
+}
+
+// GetAllByEmail retrieves every user registered with the email, emails are not unique.
+func (r *UserRepository) GetAllByEmail(email string) ([]entity.User, error) {
+	query := `SELECT id, first_name, last_name, email, email_verified, user_name FROM user WHERE email = ? ORDER BY id`
+	rows, err := r.db.Query(query, email)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("error getting users by email: %v", err))
+		return nil, fmt.Errorf("error getting users: %v", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+		err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.EmailVerified, &user.Username)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 func (r *UserRepository) GetUsers(userIDs []int) ([]entity.User, error) {
+	if len(userIDs) == 0 {
+		return []entity.User{}, nil
+	}
+
 	// Convert userIDs slice to a comma-separated string for the SQL query
 	idPlaceholders := make([]string, len(userIDs))
 	for i := range userIDs {
@@ -77,8 +152,8 @@ func (r *UserRepository) GetUsers(userIDs []int) ([]entity.User, error) {
 	// Prepare the SQL query
 	query := fmt.Sprintf(
 		`
-        SELECT id, first_name, last_name, birthday, email, username
-        FROM users
+        SELECT id, first_name, last_name, dob, email, user_name, avatar_key, cover_key
+        FROM user
         WHERE id IN (%s)`, strings.Join(idPlaceholders, ","),
 	)
 
@@ -101,16 +176,20 @@ func (r *UserRepository) GetUsers(userIDs []int) ([]entity.User, error) {
 	// Iterate over the result set
 	for rows.Next() {
 		var user entity.User
+		var birthday sql.NullTime // Users signed up through an identity provider have none
 		if err := rows.Scan(
 			&user.ID,
 			&user.FirstName,
 			&user.LastName,
-			&user.Birthday,
+			&birthday,
 			&user.Email,
 			&user.Username,
+			&user.AvatarKey,
+			&user.CoverKey,
 		); err != nil {
 			return nil, err
 		}
+		user.Birthday = birthday.Time
 		users = append(users, user)
 	}
 
@@ -122,49 +201,106 @@ func (r *UserRepository) GetUsers(userIDs []int) ([]entity.User, error) {
 	return users, nil
 }
 
-func (r *UserRepository) CreateUser(user entity.User) error {
+// CreateUser inserts a new user and returns its ID.
+func (r *UserRepository) CreateUser(user entity.User) (int, error) {
 	query := `INSERT INTO user (hashed_password, salt, first_name, last_name, dob, email, user_name) 
 		VALUES (?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.Exec(
+	result, err := r.db.Exec(
 		query, user.HashedPassword, user.Salt, user.FirstName, user.LastName, user.Birthday, user.Email, user.Username,
 	)
 	if err != nil {
-		return fmt.Errorf("error creating user: %v", err)
+		return 0, fmt.Errorf("error creating user: %v", err)
+	}
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving created user id: %v", err)
 	}
-	return nil
+	return int(userID), nil
 }
 
-// UpdateUser updates an existing user in the database.
-func (r *UserRepository) UpdateUser(user entity.User) error {
+// UpdateUser sets the named profile fields of an existing user, fields not named are left untouched.
+func (r *UserRepository) UpdateUser(user entity.User, fields []string) error {
 	var updateFields []string
 	var args []interface{}
-	if user.FirstName != "" {
-		updateFields = append(updateFields, "first_name = ?")
-		args = append(args, user.FirstName)
-	}
-	if user.LastName != "" {
-		updateFields = append(updateFields, "last_name = ?")
-		args = append(args, user.LastName)
-	}
-	if !user.Birthday.IsZero() {
-		updateFields = append(updateFields, "dob = ?")
-		args = append(args, user.Birthday)
-	}
-	if user.HashedPassword != "" {
-		updateFields = append(updateFields, "hashed_password = ?, salt = ?")
-		args = append(args, user.HashedPassword, user.Salt)
+	for _, field := range fields {
+		switch field {
+		case entity.ProfileFieldFirstName:
+			updateFields = append(updateFields, "first_name = ?")
+			args = append(args, user.FirstName)
+		case entity.ProfileFieldLastName:
+			updateFields = append(updateFields, "last_name = ?")
+			args = append(args, user.LastName)
+		case entity.ProfileFieldBirthday:
+			updateFields = append(updateFields, "dob = ?")
+			args = append(args, user.Birthday)
+		case entity.ProfileFieldPassword:
+			updateFields = append(updateFields, "hashed_password = ?, salt = ?")
+			args = append(args, user.HashedPassword, user.Salt)
+		case entity.ProfileFieldDisplayName:
+			updateFields = append(updateFields, "display_name = ?")
+			args = append(args, user.DisplayName)
+		case entity.ProfileFieldBio:
+			updateFields = append(updateFields, "bio = ?")
+			args = append(args, user.Bio)
+		case entity.ProfileFieldWebsite:
+			updateFields = append(updateFields, "website = ?")
+			args = append(args, user.Website)
+		case entity.ProfileFieldLocation:
+			updateFields = append(updateFields, "location = ?")
+			args = append(args, user.Location)
+		case entity.ProfileFieldEmail:
+			// A new email has to be verified again
+			updateFields = append(updateFields, "email = ?, email_verified = FALSE")
+			args = append(args, user.Email)
+		default:
+			return fmt.Errorf("unknown profile field %q", field)
+		}
 	}
 
 	if len(updateFields) == 0 {
 		return nil // No fields to update
 	}
 
-	args = append(args, user.Username)
-	updateQuery := "UPDATE user SET" + " " + strings.Join(updateFields, ", ") + " WHERE user_name = ?"
+	args = append(args, user.ID)
+	updateQuery := "UPDATE user SET" + " " + strings.Join(updateFields, ", ") + " WHERE id = ?"
 	_, err := r.db.Exec(updateQuery, args...)
 	return err
 }
 
+// UpdatePasswordHash replaces the user's password hash, unless it changed since oldHashedPassword was read.
+func (r *UserRepository) UpdatePasswordHash(userName string, oldHashedPassword string, hashedPassword string, salt string) error {
+	_, err := r.db.Exec(
+		"UPDATE user SET hashed_password = ?, salt = ? WHERE user_name = ? AND hashed_password = ?",
+		hashedPassword, salt, userName, oldHashedPassword,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error updating password hash of user %s: %v", userName, err))
+	}
+	return err
+}
+
+// MarkEmailVerified marks the user's email as verified, unless it changed from email since the
+// verification was sent. It reports whether the user was updated.
+func (r *UserRepository) MarkEmailVerified(userID int, email string) (bool, error) {
+	result, err := r.db.Exec("UPDATE user SET email_verified = TRUE WHERE id = ? AND email = ?", userID, email)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error marking email of user %d verified: %v", userID, err))
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected > 0 {
+		return true, nil
+	}
+
+	// MySQL reports no affected rows when the email was already verified
+	var matched bool
+	err = r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM user WHERE id = ? AND email = ?)", userID, email).Scan(&matched)
+	return matched, err
+}
+
 func (r *UserRepository) GetAllUserNames() ([]string, error) {
 	query := `SELECT user_name FROM user`
 
@@ -206,3 +342,326 @@ func (r *UserRepository) GetAllUserNames() ([]string, error) {
 	// Return the slice of usernames
 	return userNames, nil
 }
+
+// SearchUsersByPrefix returns users whose username, first name or last name starts with the prefix. Each
+// name is matched separately so every match is a range scan of that name's index.
+func (r *UserRepository) SearchUsersByPrefix(prefix string, limit int) ([]entity.User, error) {
+	// Escape LIKE wildcards so they match literally
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+	rows, err := r.db.Query(
+		`
+		SELECT id, first_name, last_name, user_name, avatar_key FROM (
+			(SELECT id, first_name, last_name, user_name, avatar_key FROM user WHERE user_name LIKE ? LIMIT ?)
+			UNION
+			(SELECT id, first_name, last_name, user_name, avatar_key FROM user WHERE first_name LIKE ? LIMIT ?)
+			UNION
+			(SELECT id, first_name, last_name, user_name, avatar_key FROM user WHERE last_name LIKE ? LIMIT ?)
+		) matches
+		ORDER BY user_name ASC LIMIT ?`,
+		pattern, limit, pattern, limit, pattern, limit, limit,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error searching users by prefix: %v", err))
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error closing rows: %v", err))
+			return
+		}
+	}(rows)
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Username, &user.AvatarKey); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// GetFollowRelations reports which of the other users the user follows and which follow the user.
+func (r *UserRepository) GetFollowRelations(userID int, otherUserIDs []int) (map[int]bool, map[int]bool, error) {
+	following := make(map[int]bool)
+	followers := make(map[int]bool)
+	if len(otherUserIDs) == 0 {
+		return following, followers, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(otherUserIDs)), ",")
+	args := []interface{}{userID}
+	for _, id := range otherUserIDs {
+		args = append(args, id)
+	}
+	args = append(args, userID)
+	for _, id := range otherUserIDs {
+		args = append(args, id)
+	}
+
+	// A user_user row (fk_user_id, fk_follower_id) means fk_user_id follows fk_follower_id
+	rows, err := r.db.Query(
+		fmt.Sprintf(
+			`
+			SELECT fk_user_id, fk_follower_id FROM user_user WHERE fk_user_id = ? AND fk_follower_id IN (%s)
+			UNION ALL
+			SELECT fk_user_id, fk_follower_id FROM user_user WHERE fk_follower_id = ? AND fk_user_id IN (%s)`,
+			placeholders, placeholders,
+		),
+		args...,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error getting follow relations of user %d: %v", userID, err))
+		return nil, nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error closing rows: %v", err))
+			return
+		}
+	}(rows)
+
+	for rows.Next() {
+		var followerID, followedID int
+		if err := rows.Scan(&followerID, &followedID); err != nil {
+			return nil, nil, err
+		}
+		if followerID == userID {
+			following[followedID] = true
+		} else {
+			followers[followerID] = true
+		}
+	}
+	return following, followers, rows.Err()
+}
+
+// GetProfileCounts returns how many users follow the user, how many the user follows and how many
+// visible posts they have.
+func (r *UserRepository) GetProfileCounts(userID int) (int, int, int, error) {
+	var followerCount, followingCount, postCount int
+	// A user_user row (fk_user_id, fk_follower_id) means fk_user_id follows fk_follower_id
+	err := r.db.QueryRow(
+		`
+		SELECT
+			(SELECT COUNT(*) FROM user_user WHERE fk_follower_id = ?),
+			(SELECT COUNT(*) FROM user_user WHERE fk_user_id = ?),
+			(SELECT COUNT(*) FROM post WHERE fk_user_id = ? AND visible = TRUE)`,
+		userID, userID, userID,
+	).Scan(&followerCount, &followingCount, &postCount)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error getting profile counts of user %d: %v", userID, err))
+		return 0, 0, 0, err
+	}
+	return followerCount, followingCount, postCount, nil
+}
+
+// IsBlocked reports whether the user blocked the other user.
+func (r *UserRepository) IsBlocked(userID int, blockedUserID int) (bool, error) {
+	var blocked bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM user_block WHERE fk_user_id = ? AND fk_blocked_user_id = ?)",
+		userID, blockedUserID,
+	).Scan(&blocked)
+	return blocked, err
+}
+
+// BlockUser records that the user blocked the other user, blocking twice is not an error.
+func (r *UserRepository) BlockUser(userID int, blockedUserID int) error {
+	_, err := r.db.Exec(
+		"INSERT IGNORE INTO user_block (fk_user_id, fk_blocked_user_id) VALUES (?, ?)", userID, blockedUserID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error blocking user %d for user %d: %v", blockedUserID, userID, err))
+	}
+	return err
+}
+
+// UnblockUser removes the user's block of the other user, if any.
+func (r *UserRepository) UnblockUser(userID int, blockedUserID int) error {
+	_, err := r.db.Exec(
+		"DELETE FROM user_block WHERE fk_user_id = ? AND fk_blocked_user_id = ?", userID, blockedUserID,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error unblocking user %d for user %d: %v", blockedUserID, userID, err))
+	}
+	return err
+}
+
+// UpdateProfileImage stores the object key of the user's avatar or cover image and returns the key it replaced.
+func (r *UserRepository) UpdateProfileImage(userID int, kind string, objectKey string) (string, error) {
+	var column string
+	switch kind {
+	case entity.ProfileImageAvatar:
+		column = "avatar_key"
+	case entity.ProfileImageCover:
+		column = "cover_key"
+	default:
+		return "", fmt.Errorf("unknown profile image kind %q", kind)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var previousKey string
+	err = tx.QueryRow(fmt.Sprintf("SELECT %s FROM user WHERE id = ? FOR UPDATE", column), userID).Scan(&previousKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		logger.LogError(fmt.Sprintf("Error getting %s of user %d: %v", column, userID, err))
+		return "", err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE user SET %s = ? WHERE id = ?", column), objectKey, userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error updating %s of user %d: %v", column, userID, err))
+		return "", err
+	}
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+	return previousKey, nil
+}
+
+// SetTOTPSecret stores the secret of a two-factor enrollment, it is not used until EnableTOTP.
+func (r *UserRepository) SetTOTPSecret(userID int, secret string) error {
+	_, err := r.db.Exec("UPDATE user SET totp_secret = ? WHERE id = ? AND totp_enabled = FALSE", secret, userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error setting TOTP secret of user %d: %v", userID, err))
+	}
+	return err
+}
+
+// EnableTOTP turns on two-factor authentication and replaces the user's recovery codes. It reports false
+// when it was already enabled.
+func (r *UserRepository) EnableTOTP(userID int, recoveryCodeHashes []string) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE user SET totp_enabled = TRUE WHERE id = ? AND totp_enabled = FALSE", userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error enabling TOTP of user %d: %v", userID, err))
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if _, err = tx.Exec("DELETE FROM user_recovery_code WHERE fk_user_id = ?", userID); err != nil {
+		return false, err
+	}
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = tx.Exec("INSERT INTO user_recovery_code (fk_user_id, code_hash) VALUES (?, ?)", userID, codeHash)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error storing recovery code of user %d: %v", userID, err))
+			return false, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used and reports whether there was one.
+func (r *UserRepository) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	result, err := r.db.Exec(
+		"UPDATE user_recovery_code SET used_at = CURRENT_TIMESTAMP WHERE fk_user_id = ? AND code_hash = ? AND used_at IS NULL",
+		userID, codeHash,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error using recovery code of user %d: %v", userID, err))
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetUserIDByIdentity returns the user an external identity is linked to.
+func (r *UserRepository) GetUserIDByIdentity(issuer string, subject string) (int, error) {
+	var userID int
+	err := r.db.QueryRow(
+		"SELECT fk_user_id FROM user_identity WHERE issuer = ? AND subject = ?", issuer, subject,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		logger.LogError(fmt.Sprintf("Error getting user of identity %s %s: %v", issuer, subject, err))
+		return 0, fmt.Errorf("error getting user: %v", err)
+	}
+	return userID, nil
+}
+
+// LinkIdentity links an external identity to an existing user.
+func (r *UserRepository) LinkIdentity(userID int, issuer string, subject string) error {
+	_, err := r.db.Exec(
+		"INSERT INTO user_identity (fk_user_id, issuer, subject) VALUES (?, ?, ?)", userID, issuer, subject,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error linking identity to user %d: %v", userID, err))
+	}
+	return err
+}
+
+// CreateUserWithIdentity creates a user signed up through an external identity provider and links the
+// identity to it. The provider verified the email, so it is stored as verified. A zero birthday is stored
+// as NULL. Returns ErrUsernameTaken when the username is in use.
+func (r *UserRepository) CreateUserWithIdentity(user entity.User, issuer string, subject string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var birthday interface{}
+	if !user.Birthday.IsZero() {
+		birthday = user.Birthday
+	}
+	result, err := tx.Exec(
+		`INSERT INTO user (hashed_password, salt, first_name, last_name, dob, email, email_verified, user_name)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.HashedPassword, user.Salt, user.FirstName, user.LastName, birthday, user.Email, user.EmailVerified,
+		user.Username,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		// user_name is the only unique column besides the id
+		return 0, ErrUsernameTaken
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error creating user: %v", err)
+	}
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving created user id: %v", err)
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO user_identity (fk_user_id, issuer, subject) VALUES (?, ?, ?)", userID, issuer, subject,
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error linking identity to user %d: %v", userID, err))
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(userID), nil
+}
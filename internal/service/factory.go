@@ -1,36 +1,157 @@
 package service
 
 import (
+	"net/http"
+	"news-feed/internal/apikey"
 	"news-feed/internal/cache"
+	"news-feed/internal/linkpreview"
 	"news-feed/internal/repository"
+	"news-feed/internal/session"
 	"news-feed/internal/storage"
+	"news-feed/pkg/config/userPostFriends"
+	"news-feed/pkg/mailer"
+	"news-feed/pkg/middleware"
+	"news-feed/pkg/oidc"
+	"news-feed/pkg/totp"
+	"strings"
+	"time"
 )
 
 type ServiceFactoryInterface interface {
-	CreateUserService(userRepo repository.UserRepositoryInterface) UserServiceInterface
-	CreatePostService(repo repository.PostRepositoryInterface, storage storage.MinioStorageInterface, userService UserServiceInterface) PostServiceInterface
+	CreateUserService(
+		userRepo repository.UserRepositoryInterface,
+		storage storage.MinioStorageInterface,
+		mailer mailer.Mailer) UserServiceInterface
+	CreateMailer() mailer.Mailer
+	CreatePostService(
+		repo repository.PostRepositoryInterface,
+		friendsRepo repository.FriendsRepositoryInterface,
+		storage storage.MinioStorageInterface,
+		userService UserServiceInterface,
+		linkPreviewWorker LinkPreviewWorkerInterface) PostServiceInterface
+	CreateLinkPreviewWorker(postRepo repository.PostRepositoryInterface) LinkPreviewWorkerInterface
 	CreateFriendsService(
 		friendsRepo repository.FriendsRepositoryInterface,
 		postRepo repository.PostRepositoryInterface,
-		userRepo repository.UserRepositoryInterface) FriendsServiceInterface
+		userRepo repository.UserRepositoryInterface,
+		storage storage.MinioStorageInterface) FriendsServiceInterface
 	CreateNewsFeedService(postRepo repository.PostRepositoryInterface) NewsFeedServiceInterface
 }
 
 type ServiceFactory struct{}
 
-func (*ServiceFactory) CreateUserService(userRepo repository.UserRepositoryInterface) UserServiceInterface {
-	return &UserService{userRepo: userRepo, redisClient: cache.GetRedisClient()}
+func (*ServiceFactory) CreateUserService(
+	userRepo repository.UserRepositoryInterface,
+	storage storage.MinioStorageInterface,
+	mailer mailer.Mailer) UserServiceInterface {
+	cfg := userPostFriends.LoadUserPostFriendsConfig()
+	return &UserService{
+		userRepo: userRepo,
+		storage:  storage,
+		mailer:   mailer,
+		sessions: session.NewStore(cache.GetRedisClient(), middleware.RefreshTokenTTL),
+		apiKeys:  apikey.NewStore(cache.GetRedisClient()),
+		loginLimiter: NewLoginLimiter(
+			cache.GetRedisClient(), LoginLimits{
+				MaxFailuresPerUsername: cfg.LoginMaxFailuresPerUsername,
+				MaxFailuresPerIP:       cfg.LoginMaxFailuresPerIP,
+				CaptchaAfterFailures:   cfg.LoginCaptchaAfterFailures,
+				FailureWindow:          time.Duration(cfg.LoginFailureWindowMinutes) * time.Minute,
+				BaseLockout:            time.Duration(cfg.LoginBaseLockoutSeconds) * time.Second,
+				MaxLockout:             time.Duration(cfg.LoginMaxLockoutMinutes) * time.Minute,
+			},
+		),
+		totp:         totp.New(time.Now),
+		oidcProvider: createOIDCProvider(cfg),
+		redisClient:  cache.GetRedisClient(),
+
+		passwordResetURL: cfg.PasswordResetURL,
+		passwordResetTTL: time.Duration(cfg.PasswordResetTTLMinutes) * time.Minute,
+
+		passwordResetMaxPerEmail: cfg.PasswordResetMaxRequestsPerEmail,
+		passwordResetMaxPerIP:    cfg.PasswordResetMaxRequestsPerIP,
+
+		emailVerificationURL: cfg.EmailVerificationURL,
+		emailVerificationTTL: time.Duration(cfg.EmailVerificationTTLHours) * time.Hour,
+
+		totpIssuer:        cfg.TOTPIssuer,
+		twoFactorTokenTTL: time.Duration(cfg.TwoFactorTokenTTLMinutes) * time.Minute,
+
+		oidcStateTTL: time.Duration(cfg.OIDCStateTTLMinutes) * time.Minute,
+
+		apiKeyDefaultTTL: time.Duration(cfg.APIKeyDefaultTTLDays) * 24 * time.Hour,
+		apiKeyMaxTTL:     time.Duration(cfg.APIKeyMaxTTLDays) * 24 * time.Hour,
+	}
+}
+
+// createOIDCProvider returns the configured identity provider, or nil when none is configured.
+func createOIDCProvider(cfg *userPostFriends.UserPostFriendsConfig) *oidc.Provider {
+	if cfg.OIDCClientID == "" {
+		return nil
+	}
+	return oidc.NewProvider(
+		oidc.Config{
+			Issuer:                cfg.OIDCIssuer,
+			ClientID:              cfg.OIDCClientID,
+			ClientSecret:          cfg.OIDCClientSecret,
+			RedirectURL:           cfg.OIDCRedirectURL,
+			AuthorizationEndpoint: cfg.OIDCAuthorizationEndpoint,
+			TokenEndpoint:         cfg.OIDCTokenEndpoint,
+			JWKSURI:               cfg.OIDCJWKSURI,
+			Scopes:                strings.Fields(cfg.OIDCScopes),
+		},
+		&http.Client{Timeout: 10 * time.Second},
+	)
+}
+
+// CreateMailer returns an SMTP mailer, or an in-memory one when no SMTP host is configured.
+func (*ServiceFactory) CreateMailer() mailer.Mailer {
+	cfg := userPostFriends.LoadUserPostFriendsConfig()
+	if cfg.SMTPHost == "" {
+		return mailer.NewMemoryMailer()
+	}
+	return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+}
+
+func (*ServiceFactory) CreatePostService(
+	repo repository.PostRepositoryInterface,
+	friendsRepo repository.FriendsRepositoryInterface,
+	storage storage.MinioStorageInterface,
+	userService UserServiceInterface,
+	linkPreviewWorker LinkPreviewWorkerInterface) PostServiceInterface {
+	cfg := userPostFriends.LoadUserPostFriendsConfig()
+	return &PostService{
+		postRepo:        repo,
+		friendsRepo:     friendsRepo,
+		storage:         storage,
+		redisClient:     cache.GetRedisClient(),
+		userService:     userService,
+		commentMaxDepth: cfg.CommentMaxDepth,
+
+		linkPreviewWorker:          linkPreviewWorker,
+		restrictUnverifiedAccounts: cfg.RestrictUnverifiedAccounts,
+	}
 }
 
-func (*ServiceFactory) CreatePostService(repo repository.PostRepositoryInterface, storage storage.MinioStorageInterface, userService UserServiceInterface) PostServiceInterface {
-	return &PostService{postRepo: repo, storage: storage, redisClient: cache.GetRedisClient(), userService: userService}
+func (*ServiceFactory) CreateLinkPreviewWorker(postRepo repository.PostRepositoryInterface) LinkPreviewWorkerInterface {
+	cfg := userPostFriends.LoadUserPostFriendsConfig()
+	timeout := time.Duration(cfg.LinkPreviewTimeoutSeconds) * time.Second
+	fetcher := linkpreview.NewHTTPFetcher(timeout, int64(cfg.LinkPreviewMaxBytes))
+	return NewLinkPreviewWorker(postRepo, fetcher, cfg.LinkPreviewWorkers, cfg.LinkPreviewQueueSize, timeout)
 }
 
 func (*ServiceFactory) CreateFriendsService(
 	friendsRepo repository.FriendsRepositoryInterface,
 	postRepo repository.PostRepositoryInterface,
-	userRepo repository.UserRepositoryInterface) FriendsServiceInterface {
-	return &FriendsService{friendsRepo: friendsRepo, postRepo: postRepo, redisClient: cache.GetRedisClient(), userRepo: userRepo}
+	userRepo repository.UserRepositoryInterface,
+	storage storage.MinioStorageInterface) FriendsServiceInterface {
+	return &FriendsService{
+		friendsRepo: friendsRepo,
+		postRepo:    postRepo,
+		redisClient: cache.GetRedisClient(),
+		userRepo:    userRepo,
+		storage:     storage,
+	}
 }
 
 func (*ServiceFactory) CreateNewsFeedService(postRepo repository.PostRepositoryInterface) NewsFeedServiceInterface {
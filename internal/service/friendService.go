@@ -6,6 +6,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"news-feed/internal/entity"
 	"news-feed/internal/repository"
+	"news-feed/internal/storage"
 	"news-feed/pkg/logger"
 	"strconv"
 )
@@ -21,6 +22,7 @@ type FriendsService struct {
 	friendsRepo repository.FriendsRepositoryInterface
 	postRepo    repository.PostRepositoryInterface
 	userRepo    repository.UserRepositoryInterface
+	storage     storage.MinioStorageInterface
 	redisClient *redis.Client
 }
 
@@ -63,6 +65,8 @@ func (s *FriendsService) GetFriends(userID int, limit int, cursor int) ([]entity
 			LastName:       userData["last_name"],
 			Email:          userData["email"],
 			Username:       userData["username"],
+			AvatarKey:      userData["avatar_key"],
+			CoverKey:       userData["cover_key"],
 		}
 		followers = append(followers, follower) // Append user to the slice
 		if follower.ID > maxID {
@@ -71,6 +75,7 @@ func (s *FriendsService) GetFriends(userID int, limit int, cursor int) ([]entity
 	}
 
 	if len(followers) > 0 {
+		resolveProfileImageURLs(s.storage, followers)
 		return followers, maxID, nil // next cursor = maxID+1
 	}
 
@@ -79,6 +84,7 @@ func (s *FriendsService) GetFriends(userID int, limit int, cursor int) ([]entity
 	if err != nil {
 		return nil, 0, err
 	}
+	resolveProfileImageURLs(s.storage, followers)
 
 	go func() {
 		// Prepare data for Redis sorted set
@@ -95,6 +101,8 @@ func (s *FriendsService) GetFriends(userID int, limit int, cursor int) ([]entity
 					"birthday":        follower.Birthday,
 					"email":           follower.Email,
 					"username":        follower.Username,
+					"avatar_key":      follower.AvatarKey,
+					"cover_key":       follower.CoverKey,
 				},
 			).Result()
 			if err != nil {
@@ -125,6 +133,7 @@ func (s *FriendsService) FollowUser(currentUserID int, followedUserID int) (stri
 	if err != nil {
 		return "Failed to follow user", err
 	}
+	invalidateProfileCache(s.redisClient, currentUserID, followedUserID)
 
 	go func() {
 		user, err := s.userRepo.GetByUserID(followedUserID)
@@ -144,6 +153,8 @@ func (s *FriendsService) FollowUser(currentUserID int, followedUserID int) (stri
 				"birthday":        user.Birthday,
 				"email":           user.Email,
 				"username":        user.Username,
+				"avatar_key":      user.AvatarKey,
+				"cover_key":       user.CoverKey,
 			},
 		).Result()
 		if err != nil {
@@ -174,6 +185,7 @@ func (s *FriendsService) UnfollowUser(currentUserID int, unfollowedUserID int) (
 	if err != nil {
 		return "Failed to unfollow user", err
 	}
+	invalidateProfileCache(s.redisClient, currentUserID, unfollowedUserID)
 	go func() {
 		cacheKey := fmt.Sprintf("%d", currentUserID)
 		_, err = s.redisClient.ZRem(
@@ -193,8 +205,42 @@ func (s *FriendsService) UnfollowUser(currentUserID int, unfollowedUserID int) (
 	return "Successfully unfollowed user", nil
 }
 
-// GetUserPosts retrieves the posts by a user.
+// GetUserPosts retrieves the posts by a user. The initial page (cursor 0) starts with the user's
+// pinned posts flagged as pinned, in addition to the limit, the paginated stream never repeats them.
 func (s *FriendsService) GetUserPosts(userID int, limit int, cursor int) ([]entity.Post, int, error) {
+	posts, nextCursor, err := s.getUserPostStream(userID, limit, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pinnedPosts, err := s.postRepo.GetPinnedPostsByUserID(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(pinnedPosts) == 0 {
+		return posts, nextCursor, nil
+	}
+
+	// Drop pinned posts a stale cache may still hold in the stream
+	pinnedIDs := make(map[int]bool, len(pinnedPosts))
+	for _, post := range pinnedPosts {
+		pinnedIDs[post.ID] = true
+	}
+	streamPosts := posts[:0]
+	for _, post := range posts {
+		if !pinnedIDs[post.ID] {
+			streamPosts = append(streamPosts, post)
+		}
+	}
+
+	if cursor != 0 {
+		return streamPosts, nextCursor, nil
+	}
+	return append(pinnedPosts, streamPosts...), nextCursor, nil
+}
+
+// getUserPostStream retrieves the user's unpinned posts ordered by ID, from cache when possible.
+func (s *FriendsService) getUserPostStream(userID int, limit int, cursor int) ([]entity.Post, int, error) {
 	// Create cache key
 	cacheKey := fmt.Sprintf("posts:%d", userID)
 
@@ -219,7 +265,7 @@ func (s *FriendsService) GetUserPosts(userID int, limit int, cursor int) ([]enti
 	for _, postIDStr := range postIDs {
 		postID, err := strconv.Atoi(postIDStr)
 		if err != nil {
-			logger.LogError(fmt.Sprintf("Error converting post ID %s to int: %v", postID, err))
+			logger.LogError(fmt.Sprintf("Error converting post ID %s to int: %v", postIDStr, err))
 			continue
 		}
 		postKey := fmt.Sprintf("user-posts:%d", postID)
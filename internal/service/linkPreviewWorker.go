@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-feed/internal/entity"
+	"news-feed/internal/linkpreview"
+	"news-feed/internal/repository"
+	"news-feed/pkg/logger"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type LinkPreviewWorkerInterface interface {
+	Enqueue(postID int, text string)
+	Start(ctx context.Context)
+}
+
+// urlPattern matches http and https URLs in post text.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+type linkPreviewJob struct {
+	postID int
+	url    string
+}
+
+// LinkPreviewWorker unfurls the first URL of new posts in the background and stores the preview.
+type LinkPreviewWorker struct {
+	postRepo repository.PostRepositoryInterface
+	fetcher  linkpreview.FetcherInterface
+	jobs     chan linkPreviewJob
+	workers  int
+	timeout  time.Duration
+}
+
+func NewLinkPreviewWorker(
+	postRepo repository.PostRepositoryInterface,
+	fetcher linkpreview.FetcherInterface,
+	workers int,
+	queueSize int,
+	timeout time.Duration,
+) *LinkPreviewWorker {
+	return &LinkPreviewWorker{
+		postRepo: postRepo,
+		fetcher:  fetcher,
+		jobs:     make(chan linkPreviewJob, queueSize),
+		workers:  max(workers, 1),
+		timeout:  timeout,
+	}
+}
+
+// Enqueue schedules a preview of the first URL in the text, posts without a URL are ignored.
+// The job is dropped when the queue is full so post creation never blocks on unfurling.
+func (w *LinkPreviewWorker) Enqueue(postID int, text string) {
+	url := firstURL(text)
+	if url == "" {
+		return
+	}
+
+	select {
+	case w.jobs <- linkPreviewJob{postID: postID, url: url}:
+	default:
+		logger.LogWarning(fmt.Sprintf("Link preview queue full, skipping preview for post %d", postID))
+	}
+}
+
+// Start runs the workers until the context is cancelled.
+func (w *LinkPreviewWorker) Start(ctx context.Context) {
+	for i := 0; i < w.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.jobs:
+					w.process(ctx, job)
+				}
+			}
+		}()
+	}
+}
+
+func (w *LinkPreviewWorker) process(ctx context.Context, job linkPreviewJob) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	preview, err := w.fetcher.Fetch(ctx, job.url)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to fetch link preview for post %d: %v", job.postID, err))
+		return
+	}
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return
+	}
+
+	// The post may have been edited or deleted while the page was fetched, its current preview is left to
+	// the job of the edit
+	post, err := w.postRepo.GetPostByID(job.postID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrPostNotFound) {
+			logger.LogError(fmt.Sprintf("Failed to get post %d for its link preview: %v", job.postID, err))
+		}
+		return
+	}
+	if firstURL(post.ContentText) != job.url {
+		logger.LogInfo(fmt.Sprintf("Dropping outdated link preview for post %d", job.postID))
+		return
+	}
+
+	err = w.postRepo.SaveLinkPreview(
+		entity.LinkPreview{
+			PostID:      job.postID,
+			URL:         job.url,
+			Title:       truncate(preview.Title, 512),
+			Description: preview.Description,
+			ImageURL:    truncate(preview.ImageURL, 2048),
+		},
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to save link preview for post %d: %v", job.postID, err))
+		return
+	}
+	logger.LogInfo(fmt.Sprintf("Saved link preview for post %d", job.postID))
+}
+
+// firstURL returns the first URL in the text, without trailing punctuation.
+func firstURL(text string) string {
+	url := strings.TrimRight(urlPattern.FindString(text), ".,;:!?)]}")
+	if len(url) > 2048 {
+		return ""
+	}
+	return url
+}
+
+// truncate shortens s to at most maxLen bytes without splitting a UTF-8 character.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
+	return s[:maxLen]
+}
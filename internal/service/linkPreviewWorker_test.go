@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"news-feed/internal/entity"
+	"news-feed/internal/linkpreview"
+	"news-feed/internal/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+// previewPostRepo serves posts from memory and records saved previews, the methods the worker does not
+// use panic through the nil embedded interface.
+type previewPostRepo struct {
+	repository.PostRepositoryInterface
+	posts map[int]*entity.Post
+	saved []entity.LinkPreview
+}
+
+func (r *previewPostRepo) GetPostByID(id int) (*entity.Post, error) {
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, repository.ErrPostNotFound
+	}
+	return post, nil
+}
+
+func (r *previewPostRepo) SaveLinkPreview(preview entity.LinkPreview) error {
+	r.saved = append(r.saved, preview)
+	return nil
+}
+
+type staticFetcher struct {
+	preview linkpreview.Preview
+}
+
+func (f staticFetcher) Fetch(_ context.Context, rawURL string) (*linkpreview.Preview, error) {
+	preview := f.preview
+	preview.URL = rawURL
+	return &preview, nil
+}
+
+func TestLinkPreviewWorkerProcess(t *testing.T) {
+	const fetchedURL = "https://example.com/article"
+	tests := []struct {
+		name      string
+		post      *entity.Post
+		preview   linkpreview.Preview
+		wantSaved bool
+	}{
+		{
+			name:      "post still links the url",
+			post:      &entity.Post{ID: 1, ContentText: "Read this: " + fetchedURL + "."},
+			preview:   linkpreview.Preview{Title: "Article"},
+			wantSaved: true,
+		},
+		{
+			name:    "post edited to another url",
+			post:    &entity.Post{ID: 1, ContentText: "Actually https://example.com/other"},
+			preview: linkpreview.Preview{Title: "Article"},
+		},
+		{
+			name:    "post edited to no url",
+			post:    &entity.Post{ID: 1, ContentText: "Never mind"},
+			preview: linkpreview.Preview{Title: "Article"},
+		},
+		{
+			name:    "post deleted",
+			preview: linkpreview.Preview{Title: "Article"},
+		},
+		{
+			name: "page without metadata",
+			post: &entity.Post{ID: 1, ContentText: fetchedURL},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &previewPostRepo{posts: map[int]*entity.Post{}}
+			if tt.post != nil {
+				repo.posts[tt.post.ID] = tt.post
+			}
+			w := NewLinkPreviewWorker(repo, staticFetcher{preview: tt.preview}, 1, 1, time.Second)
+
+			w.process(context.Background(), linkPreviewJob{postID: 1, url: fetchedURL})
+
+			if got := len(repo.saved) == 1; got != tt.wantSaved {
+				t.Fatalf("saved previews = %+v, want saved %v", repo.saved, tt.wantSaved)
+			}
+			if tt.wantSaved && (repo.saved[0].URL != fetchedURL || repo.saved[0].Title != tt.preview.Title) {
+				t.Fatalf("saved preview = %+v", repo.saved[0])
+			}
+		})
+	}
+}
+
+func TestLinkPreviewWorkerTruncatesLongFields(t *testing.T) {
+	const fetchedURL = "https://example.com/"
+	repo := &previewPostRepo{posts: map[int]*entity.Post{1: {ID: 1, ContentText: fetchedURL}}}
+	title := strings.Repeat("é", 300) // 600 bytes
+	w := NewLinkPreviewWorker(repo, staticFetcher{preview: linkpreview.Preview{Title: title}}, 1, 1, time.Second)
+
+	w.process(context.Background(), linkPreviewJob{postID: 1, url: fetchedURL})
+
+	if len(repo.saved) != 1 {
+		t.Fatalf("saved previews = %+v, want one", repo.saved)
+	}
+	if got := repo.saved[0].Title; got != strings.Repeat("é", 256) {
+		t.Fatalf("Title has %d bytes, want 512 without a split character", len(got))
+	}
+}
+
+func TestFirstURL(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"no link here", ""},
+		{"see https://example.com/a, and https://example.com/b", "https://example.com/a"},
+		{"(http://example.com/path?q=1)", "http://example.com/path?q=1"},
+		{"ftp://example.com", ""},
+		{"https://example.com/" + strings.Repeat("a", 2048), ""},
+	}
+	for _, tt := range tests {
+		if got := firstURL(tt.text); got != tt.want {
+			t.Errorf("firstURL(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// ErrInvalidCredentials is returned when the username or password is wrong.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrLoginLocked is returned while a username or IP address is locked out after too many failed logins.
+var ErrLoginLocked = errors.New("too many failed login attempts, try again later")
+
+// LoginThrottleError wraps ErrInvalidCredentials or ErrLoginLocked with what the client has to do before
+// trying again.
+type LoginThrottleError struct {
+	Err             error
+	CaptchaRequired bool
+	RetryAfter      time.Duration // Zero unless the username or IP address is locked out
+}
+
+func (e *LoginThrottleError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottleError) Unwrap() error {
+	return e.Err
+}
+
+// LoginLimits configure the LoginLimiter.
+type LoginLimits struct {
+	MaxFailuresPerUsername int           // Failures before the username is locked out
+	MaxFailuresPerIP       int           // Failures before the IP address is locked out
+	CaptchaAfterFailures   int           // Failures of a username before a CAPTCHA is required
+	FailureWindow          time.Duration // Failures are forgotten after this long without another one
+	BaseLockout            time.Duration // First lockout, each further failure doubles it
+	MaxLockout             time.Duration
+}
+
+type LoginLimiterInterface interface {
+	Check(username string, clientIP string) (*LoginThrottleError, error)
+	RecordFailure(username string, clientIP string) (*LoginThrottleError, error)
+	Reset(username string) error
+}
+
+// LoginLimiter counts failed logins per username and per IP address in Redis and locks them out with
+// exponential backoff once they reach their limit. Failure counts live at
+// "login_failures:<scope>:<value>" and lockouts at "login_lockout:<scope>:<value>". Attempts without a
+// client IP address are only limited per username.
+type LoginLimiter struct {
+	redisClient *redis.Client
+	limits      LoginLimits
+}
+
+func NewLoginLimiter(redisClient *redis.Client, limits LoginLimits) *LoginLimiter {
+	return &LoginLimiter{redisClient: redisClient, limits: limits}
+}
+
+// Check returns a LoginThrottleError wrapping ErrLoginLocked while the username or IP address is locked
+// out, and nil when the attempt may proceed.
+func (l *LoginLimiter) Check(username string, clientIP string) (*LoginThrottleError, error) {
+	ctx := context.Background()
+	pipe := l.redisClient.Pipeline()
+	userLockout := pipe.PTTL(ctx, loginLockoutKey("user", username))
+	userFailures := pipe.Get(ctx, loginFailuresKey("user", username))
+	var ipLockout *redis.DurationCmd
+	if clientIP != "" {
+		ipLockout = pipe.PTTL(ctx, loginLockoutKey("ip", clientIP))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	// PTTL is negative for missing keys
+	retryAfter := max(userLockout.Val(), 0)
+	if ipLockout != nil {
+		retryAfter = max(retryAfter, ipLockout.Val())
+	}
+	if retryAfter <= 0 {
+		return nil, nil
+	}
+	failures, _ := userFailures.Int()
+	return &LoginThrottleError{
+		Err:             ErrLoginLocked,
+		CaptchaRequired: failures >= l.limits.CaptchaAfterFailures,
+		RetryAfter:      retryAfter,
+	}, nil
+}
+
+// RecordFailure counts a failed login and locks the username or IP address out when it reached its limit.
+// The returned error tells the client what it has to do before trying again.
+func (l *LoginLimiter) RecordFailure(username string, clientIP string) (*LoginThrottleError, error) {
+	ctx := context.Background()
+	pipe := l.redisClient.TxPipeline()
+	userFailures := pipe.Incr(ctx, loginFailuresKey("user", username))
+	pipe.Expire(ctx, loginFailuresKey("user", username), l.limits.FailureWindow)
+	var ipFailures *redis.IntCmd
+	if clientIP != "" {
+		ipFailures = pipe.Incr(ctx, loginFailuresKey("ip", clientIP))
+		pipe.Expire(ctx, loginFailuresKey("ip", clientIP), l.limits.FailureWindow)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("could not record failed login: %v", err)
+	}
+
+	throttle := &LoginThrottleError{
+		Err:             ErrInvalidCredentials,
+		CaptchaRequired: int(userFailures.Val()) >= l.limits.CaptchaAfterFailures,
+	}
+	lockouts := map[string]time.Duration{
+		loginLockoutKey("user", username): l.lockoutFor(int(userFailures.Val()), l.limits.MaxFailuresPerUsername),
+	}
+	if ipFailures != nil {
+		lockouts[loginLockoutKey("ip", clientIP)] = l.lockoutFor(int(ipFailures.Val()), l.limits.MaxFailuresPerIP)
+	}
+	for key, lockout := range lockouts {
+		if lockout == 0 {
+			continue
+		}
+		if err := l.redisClient.Set(ctx, key, 1, lockout).Err(); err != nil {
+			return nil, fmt.Errorf("could not lock out login: %v", err)
+		}
+		throttle.Err = ErrLoginLocked
+		throttle.RetryAfter = max(throttle.RetryAfter, lockout)
+	}
+	return throttle, nil
+}
+
+// Reset forgets the failures of a username after a successful login. Failures per IP address are kept so
+// an attacker cannot reset them by signing in to an account of their own.
+func (l *LoginLimiter) Reset(username string) error {
+	return l.redisClient.Del(
+		context.Background(), loginFailuresKey("user", username), loginLockoutKey("user", username),
+	).Err()
+}
+
+// lockoutFor returns how long to lock out after the given number of failures, zero below the limit.
+func (l *LoginLimiter) lockoutFor(failures int, limit int) time.Duration {
+	if limit <= 0 || failures < limit {
+		return 0
+	}
+	lockout := l.limits.BaseLockout
+	for i := limit; i < failures && lockout < l.limits.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, l.limits.MaxLockout)
+}
+
+func loginFailuresKey(scope string, value string) string {
+	return fmt.Sprintf("login_failures:%s:%s", scope, value)
+}
+
+func loginLockoutKey(scope string, value string) string {
+	return fmt.Sprintf("login_lockout:%s:%s", scope, value)
+}
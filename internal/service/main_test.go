@@ -0,0 +1,30 @@
+package service
+
+import (
+	"log"
+	"news-feed/pkg/logger"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain runs the tests in a directory with a minimal .env.webApp, the middleware reads the JWT secret
+// from it when a test signs in.
+func TestMain(m *testing.M) {
+	logger.InitLogger()
+
+	dir, err := os.MkdirTemp("", "service-test")
+	if err != nil {
+		log.Fatalf("Could not create test directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".env.webApp"), []byte("JWTSecret=test-secret\n"), 0o600); err != nil {
+		log.Fatalf("Could not write test config: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		log.Fatalf("Could not enter test directory: %v", err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
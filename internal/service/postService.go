@@ -11,36 +11,142 @@ import (
 	"news-feed/internal/storage"
 	"news-feed/pkg/logger"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type PostServiceInterface interface {
-	CreatePost(text string, fileName string, userID int) (*entity.Post, error)
+	CreatePost(
+		text string, fileName string, userID int, commentPermission string, poll *entity.Poll,
+	) (*entity.Post, error)
 	GetPost(postID int) (*entity.Post, error)
 	EditPost(post entity.Post) (*entity.Post, error)
 	DeletePost(postID int, userID int) error
-	CommentOnPost(postID int, userID int, comment string) (*entity.Comment, error)
+	CommentOnPost(postID int, userID int, comment string, parentCommentID int) (*entity.Comment, error)
+	EditComment(postID int, commentID int, userID int, comment string) (*entity.Comment, error)
+	DeleteComment(postID int, commentID int, userID int) error
 	LikePost(postID int, userID int) error
+	LikeComment(postID int, commentID int, userID int) error
 	UploadImage(fileName string, file io.Reader) (string, error)
 	GetComments(postID int, cursor int, limit int) ([]entity.Comment, int, error)
+	GetCommentReplies(commentID int, cursor int, limit int) ([]entity.Comment, int, error)
+	GetTopComments(postID int, cursor string, limit int) ([]entity.Comment, string, error)
 	GetLikes(postID int, cursor time.Time, limit int) ([]entity.User, *time.Time, error)
 	GetLikeCount(postID int) (int, error)
+	SavePost(userID int, postID int, collection string) error
+	UnsavePost(userID int, postID int) error
+	ListSavedPosts(userID int, collection string, cursor int, limit int) ([]entity.Bookmark, int, error)
+	PinPost(postID int, userID int) error
+	UnpinPost(postID int, userID int) error
+	GetPoll(postID int, viewerID int) (*entity.Poll, error)
+	VotePoll(postID int, userID int, optionIDs []int) (*entity.Poll, error)
+	GetLinkPreview(postID int) (*entity.LinkPreview, error)
+	SearchPosts(filter entity.PostSearchFilter) ([]entity.Post, int, error)
 }
 
+// ErrCommentMaxDepthExceeded is returned when a reply would nest deeper than the configured maximum depth.
+var ErrCommentMaxDepthExceeded = errors.New("maximum comment reply depth exceeded")
+
+// ErrInvalidParentComment is returned when replying to a comment that does not exist or belongs to another post.
+var ErrInvalidParentComment = errors.New("invalid parent comment")
+
+// ErrInvalidCommentCursor is returned when a top comments cursor is malformed.
+var ErrInvalidCommentCursor = errors.New("invalid top comments cursor")
+
+// ErrCommentPermissionDenied is returned when a user edits or deletes a comment they are not allowed to.
+var ErrCommentPermissionDenied = errors.New("not allowed to modify this comment")
+
+// ErrCommentNotAllowed is returned when the post's comment permission does not allow the user to comment.
+var ErrCommentNotAllowed = errors.New("not allowed to comment on this post")
+
+// ErrPostPermissionDenied is returned when a user changes a post they do not own.
+var ErrPostPermissionDenied = errors.New("not allowed to modify this post")
+
+// MaxPinnedPosts is the maximum number of posts a user can pin to their profile.
+const MaxPinnedPosts = 3
+
+// ErrInvalidPoll is returned when a poll attached to a new post is malformed.
+var ErrInvalidPoll = errors.New("invalid poll")
+
+// ErrInvalidPollVote is returned when a vote names no option, an unknown option, or too many options.
+var ErrInvalidPollVote = errors.New("invalid poll vote")
+
+// ErrPollClosed is returned when voting on a poll after its closing time.
+var ErrPollClosed = errors.New("poll is closed")
+
+// syncPollTalliesScript increments the live tallies of a poll, or creates them when they are missing so
+// that stale counts never replace newer ones, and returns them. KEYS is the tallies hash, ARGV the expiry
+// in seconds, the number of voted options, the voted option IDs and the option ID and count pairs to
+// create the hash from.
+var syncPollTalliesScript = redis.NewScript(`
+local votes = tonumber(ARGV[2])
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	for i = 3, 2 + votes do
+		redis.call('HINCRBY', KEYS[1], ARGV[i], 1)
+	end
+elseif #ARGV > 2 + votes then
+	redis.call('HSET', KEYS[1], unpack(ARGV, 3 + votes))
+	redis.call('EXPIRE', KEYS[1], ARGV[1])
+end
+return redis.call('HGETALL', KEYS[1])
+`)
+
+// ErrInvalidSearchQuery is returned when a search query has no searchable terms or is too long.
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+// Search limits.
+const (
+	maxSearchQueryLength = 256
+	defaultSearchLimit   = 10
+	maxSearchLimit       = 50
+)
+
+// ErrInvalidBookmarkCollection is returned when a bookmark collection name is too long.
+var ErrInvalidBookmarkCollection = errors.New("invalid bookmark collection name")
+
+// maxBookmarkCollectionLength is the longest bookmark collection name that fits the collection table.
+const maxBookmarkCollectionLength = 255
+
+// ErrInvalidCommentPermission is returned when a post is given an unknown comment permission.
+var ErrInvalidCommentPermission = errors.New("invalid comment permission")
+
 type PostService struct {
-	postRepo    repository.PostRepositoryInterface
-	storage     storage.MinioStorageInterface
-	redisClient *redis.Client
-	userService UserServiceInterface
+	postRepo        repository.PostRepositoryInterface
+	friendsRepo     repository.FriendsRepositoryInterface
+	storage         storage.MinioStorageInterface
+	redisClient     *redis.Client
+	userService     UserServiceInterface
+	commentMaxDepth int
+
+	linkPreviewWorker LinkPreviewWorkerInterface
+	// restrictUnverifiedAccounts blocks posting and commenting until the author verifies their email
+	restrictUnverifiedAccounts bool
 }
 
-func (s *PostService) CreatePost(text string, fileName string, userID int) (*entity.Post, error) {
+func (s *PostService) CreatePost(
+	text string, fileName string, userID int, commentPermission string, poll *entity.Poll,
+) (*entity.Post, error) {
 	var preSignedURL string
 	if text == "" {
 		err := errors.New("empty post text")
 		logger.LogError("Cannot create post without text")
 		return nil, err
 	}
+	if commentPermission == "" {
+		commentPermission = entity.CommentPermissionEveryone
+	}
+	if !isValidCommentPermission(commentPermission) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommentPermission, commentPermission)
+	}
+	if poll != nil {
+		if err := validatePoll(*poll); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkEmailVerified(userID); err != nil {
+		return nil, err
+	}
 	if fileName != "" {
 		var err error
 		preSignedURL, err = s.storage.GenerateFileURL(fileName)
@@ -51,9 +157,11 @@ func (s *PostService) CreatePost(text string, fileName string, userID int) (*ent
 	}
 
 	post := entity.Post{
-		ContentText:      text,
-		ContentImagePath: fileName,
-		UserID:           userID,
+		ContentText:       text,
+		ContentImagePath:  fileName,
+		UserID:            userID,
+		CommentPermission: commentPermission,
+		Poll:              poll,
 	}
 
 	createdPost, err := s.postRepo.CreatePost(post)
@@ -62,6 +170,8 @@ func (s *PostService) CreatePost(text string, fileName string, userID int) (*ent
 		return nil, err
 	}
 	createdPost.ContentImagePath = preSignedURL
+	invalidateProfileCache(s.redisClient, userID)
+	s.linkPreviewWorker.Enqueue(createdPost.ID, createdPost.ContentText)
 	go func() {
 		ctx := context.Background()
 		postCacheKey := fmt.Sprintf("post:%d", createdPost.ID)    // Cache key for the post
@@ -70,11 +180,12 @@ func (s *PostService) CreatePost(text string, fileName string, userID int) (*ent
 		// 1. Cache the post itself in Redis (using post ID as key)
 		_, err := s.redisClient.HSet(
 			ctx, postCacheKey, map[string]interface{}{
-				"id":                createdPost.ID,
-				"content_text":      createdPost.ContentText,
-				"content_image_url": createdPost.ContentImagePath,
-				"user_id":           createdPost.UserID,
-				"created_at":        createdPost.CreatedAt.Format(time.RFC3339), // Store created_at as string
+				"id":                 createdPost.ID,
+				"content_text":       createdPost.ContentText,
+				"content_image_url":  createdPost.ContentImagePath,
+				"user_id":            createdPost.UserID,
+				"comment_permission": createdPost.CommentPermission,
+				"created_at":         createdPost.CreatedAt.Format(time.RFC3339), // Store created_at as string
 			},
 		).Result()
 		if err != nil {
@@ -118,7 +229,22 @@ func (s *PostService) UploadImage(fileName string, file io.Reader) (string, erro
 	return imageURL, nil
 }
 
+// GetPost returns the post with a summary of its author.
 func (s *PostService) GetPost(postID int) (*entity.Post, error) {
+	post, err := s.getPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := s.userService.GetUsers([]int{post.UserID})
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get author of post %d: %v", postID, err))
+	} else if len(authors) > 0 {
+		post.Author = &authors[0]
+	}
+	return post, nil
+}
+
+func (s *PostService) getPost(postID int) (*entity.Post, error) {
 	ctx := context.Background()
 	postCacheKey := fmt.Sprintf("post:%d", postID)
 
@@ -137,6 +263,7 @@ func (s *PostService) GetPost(postID int) (*entity.Post, error) {
 		post.ContentText = cachedPostData["content_text"]
 		post.ContentImagePath = cachedPostData["content_image_url"]
 		post.UserID, _ = strconv.Atoi(cachedPostData["user_id"])
+		post.CommentPermission = cachedPostData["comment_permission"]
 
 		// Parse the created_at field into time.Time
 		createdAt, err := time.Parse(time.RFC3339, cachedPostData["created_at"])
@@ -152,13 +279,27 @@ func (s *PostService) GetPost(postID int) (*entity.Post, error) {
 	return s.postRepo.GetPostByID(postID)
 }
 
+// EditPost updates the text and comment permission of a post, post.UserID must be its author.
 func (s *PostService) EditPost(post entity.Post) (*entity.Post, error) {
+	if post.CommentPermission != "" && !isValidCommentPermission(post.CommentPermission) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommentPermission, post.CommentPermission)
+	}
+	if err := s.checkPostOwner(post.ID, post.UserID); err != nil {
+		return nil, err
+	}
+
 	// 1. Update the post in the database
 	updatedPost, err := s.postRepo.UpdatePost(post)
 	if err != nil {
 		return nil, err
 	}
 
+	// The edited text may link elsewhere, drop the old preview and unfurl again
+	if err := s.postRepo.DeleteLinkPreview(updatedPost.ID); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to delete link preview of post %d: %v", updatedPost.ID, err))
+	}
+	s.linkPreviewWorker.Enqueue(updatedPost.ID, updatedPost.ContentText)
+
 	// 2. Update the post in Redis cache
 	go func() {
 		ctx := context.Background()
@@ -166,11 +307,12 @@ func (s *PostService) EditPost(post entity.Post) (*entity.Post, error) {
 
 		_, err := s.redisClient.HSet(
 			ctx, postCacheKey, map[string]interface{}{
-				"id":                updatedPost.ID,
-				"content_text":      updatedPost.ContentText,
-				"content_image_url": updatedPost.ContentImagePath,
-				"user_id":           updatedPost.UserID,
-				"created_at":        updatedPost.CreatedAt.Format(time.RFC3339), // Store created_at as string
+				"id":                 updatedPost.ID,
+				"content_text":       updatedPost.ContentText,
+				"content_image_url":  updatedPost.ContentImagePath,
+				"user_id":            updatedPost.UserID,
+				"comment_permission": updatedPost.CommentPermission,
+				"created_at":         updatedPost.CreatedAt.Format(time.RFC3339), // Store created_at as string
 			},
 		).Result()
 		if err != nil {
@@ -189,6 +331,7 @@ func (s *PostService) DeletePost(postID int, userID int) error {
 	if err != nil {
 		return err
 	}
+	invalidateProfileCache(s.redisClient, userID)
 
 	// 2. Remove the post from Redis cache
 	go func() {
@@ -216,11 +359,39 @@ func (s *PostService) DeletePost(postID int, userID int) error {
 	return nil
 }
 
-func (s *PostService) CommentOnPost(postID int, userID int, comment string) (*entity.Comment, error) {
+func (s *PostService) CommentOnPost(postID int, userID int, comment string, parentCommentID int) (*entity.Comment, error) {
 	commentEntity := entity.Comment{
-		PostID:  postID,
-		UserID:  userID,
-		Content: comment,
+		PostID:          postID,
+		UserID:          userID,
+		ParentCommentID: parentCommentID,
+		Content:         comment,
+	}
+
+	if err := s.checkEmailVerified(userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkCommentPermission(postID, userID); err != nil {
+		return nil, err
+	}
+
+	// Replies inherit the depth of their parent, bounded by the configured maximum
+	if parentCommentID != 0 {
+		parentComment, err := s.postRepo.GetCommentByID(parentCommentID)
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, fmt.Errorf("%w: comment %d not found", ErrInvalidParentComment, parentCommentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if parentComment.PostID != postID {
+			return nil, fmt.Errorf(
+				"%w: comment %d does not belong to post %d", ErrInvalidParentComment, parentCommentID, postID,
+			)
+		}
+		commentEntity.Depth = parentComment.Depth + 1
+		if commentEntity.Depth > s.commentMaxDepth {
+			return nil, ErrCommentMaxDepthExceeded
+		}
 	}
 
 	// 1. Add the comment to the database
@@ -232,46 +403,43 @@ func (s *PostService) CommentOnPost(postID int, userID int, comment string) (*en
 	// 2. Update cache with the new comment using ZADD
 	go func() {
 		ctx := context.Background()
-		postCommentsCacheKey := fmt.Sprintf("comments:post:%d", postID) // Cache key for post comments sorted set
-		commentCacheKey := fmt.Sprintf("comment:%d", createdComment.ID) // Cache key for the comment hash
+		// Top-level comments are listed per post, replies per parent comment
+		commentsCacheKey := fmt.Sprintf("comments:post:%d", postID)
+		if parentCommentID != 0 {
+			commentsCacheKey = fmt.Sprintf("comments:replies:%d", parentCommentID)
+		}
 
-		// Add comment details to the comment cache (hash)
-		_, err := s.redisClient.HSet(
-			ctx, commentCacheKey, map[string]interface{}{
-				"id":         createdComment.ID,
-				"user_id":    createdComment.UserID,
-				"post_id":    createdComment.PostID,
-				"content":    createdComment.Content,
-				"created_at": createdComment.CreatedAt.Format(time.RFC3339), // Store created_at as string
-			},
-		).Result()
+		err := s.cacheComment(ctx, *createdComment)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to cache comment ID %d: %v", createdComment.ID, err))
 			return
 		}
 
-		// Add the comment ID to the post's comment list in the cache (sorted set)
-		// Use `createdComment.CreatedAt.Unix()` as the score to sort by timestamp
+		// Add the comment ID to the comment list in the cache (sorted set)
 		_, err = s.redisClient.ZAdd(
 			ctx,
-			postCommentsCacheKey,
+			commentsCacheKey,
 			redis.Z{
-				Score:  float64(createdComment.ID), // Use created_at timestamp as score
+				Score:  float64(createdComment.ID),
 				Member: createdComment.ID,
 			},
 		).Result()
 		if err != nil {
 			logger.LogError(
 				fmt.Sprintf(
-					"Failed to add comment ID %d to post comments cache for post ID %d: %v", createdComment.ID, postID,
-					err,
+					"Failed to add comment ID %d to comments cache %s: %v", createdComment.ID, commentsCacheKey, err,
 				),
 			)
 		}
+		s.redisClient.Expire(ctx, commentsCacheKey, 24*time.Hour)
 
-		// Optionally, set TTL on the comment cache entry
-		s.redisClient.Expire(ctx, commentCacheKey, 24*time.Hour)
-		s.redisClient.Expire(ctx, postCommentsCacheKey, 24*time.Hour)
+		// Keep the reply count of a cached parent comment in sync
+		if parentCommentID != 0 {
+			parentCacheKey := fmt.Sprintf("comment:%d", parentCommentID)
+			if s.redisClient.Exists(ctx, parentCacheKey).Val() == 1 {
+				s.redisClient.HIncrBy(ctx, parentCacheKey, "reply_count", 1)
+			}
+		}
 
 		logger.LogInfo(fmt.Sprintf("Successfully cached comment ID %d for post ID %d", createdComment.ID, postID))
 	}()
@@ -279,6 +447,171 @@ func (s *PostService) CommentOnPost(postID int, userID int, comment string) (*en
 	return createdComment, nil
 }
 
+// checkCommentPermission verifies the post's comment permission lets the user comment, the author always can.
+func (s *PostService) checkCommentPermission(postID int, userID int) error {
+	post, err := s.getPost(postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID == userID {
+		return nil
+	}
+
+	switch post.CommentPermission {
+	case "", entity.CommentPermissionEveryone:
+		return nil
+	case entity.CommentPermissionDisabled:
+		return fmt.Errorf("%w: comments are disabled", ErrCommentNotAllowed)
+	case entity.CommentPermissionFollowers:
+		// Only users following the author may comment
+		following, err := s.friendsRepo.IsFollowing(userID, post.UserID)
+		if err != nil {
+			return err
+		}
+		if !following {
+			return fmt.Errorf("%w: only followers of the author can comment", ErrCommentNotAllowed)
+		}
+	case entity.CommentPermissionFollowing:
+		// Only users the author follows may comment
+		following, err := s.friendsRepo.IsFollowing(post.UserID, userID)
+		if err != nil {
+			return err
+		}
+		if !following {
+			return fmt.Errorf("%w: only users followed by the author can comment", ErrCommentNotAllowed)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidCommentPermission, post.CommentPermission)
+	}
+	return nil
+}
+
+// checkEmailVerified returns ErrEmailNotVerified when unverified accounts are restricted and the user has
+// not verified their email.
+func (s *PostService) checkEmailVerified(userID int) error {
+	if !s.restrictUnverifiedAccounts {
+		return nil
+	}
+	verified, err := s.userService.IsEmailVerified(userID)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+func isValidCommentPermission(permission string) bool {
+	switch permission {
+	case entity.CommentPermissionEveryone, entity.CommentPermissionFollowers,
+		entity.CommentPermissionFollowing, entity.CommentPermissionDisabled:
+		return true
+	}
+	return false
+}
+
+// EditComment updates the text of a comment, only the comment author may edit it.
+func (s *PostService) EditComment(postID int, commentID int, userID int, comment string) (*entity.Comment, error) {
+	if comment == "" {
+		return nil, errors.New("empty comment text")
+	}
+
+	existingComment, err := s.getPostComment(postID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if existingComment.UserID != userID {
+		return nil, ErrCommentPermissionDenied
+	}
+
+	updatedComment, err := s.postRepo.UpdateComment(commentID, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCommentCache(*existingComment, false)
+	return updatedComment, nil
+}
+
+// DeleteComment removes a comment and its replies, the comment author and the post owner may delete it.
+func (s *PostService) DeleteComment(postID int, commentID int, userID int) error {
+	existingComment, err := s.getPostComment(postID, commentID)
+	if err != nil {
+		return err
+	}
+	if existingComment.UserID != userID {
+		post, err := s.postRepo.GetPostByID(postID)
+		if err != nil {
+			return err
+		}
+		if post.UserID != userID {
+			return ErrCommentPermissionDenied
+		}
+	}
+
+	replyIDs, err := s.postRepo.DeleteComment(commentID)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCommentCache(*existingComment, true)
+	s.invalidateReplyCaches(replyIDs)
+	return nil
+}
+
+// invalidateReplyCaches drops the cached hashes and reply lists of replies deleted with their parent.
+func (s *PostService) invalidateReplyCaches(replyIDs []int) {
+	if len(replyIDs) == 0 {
+		return
+	}
+	cacheKeys := make([]string, 0, 2*len(replyIDs))
+	for _, replyID := range replyIDs {
+		cacheKeys = append(cacheKeys, fmt.Sprintf("comment:%d", replyID), fmt.Sprintf("comments:replies:%d", replyID))
+	}
+	if err := s.redisClient.Del(context.Background(), cacheKeys...).Err(); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to invalidate cache for %d deleted replies: %v", len(replyIDs), err))
+	}
+}
+
+// getPostComment loads a comment and checks that it belongs to the given post.
+func (s *PostService) getPostComment(postID int, commentID int) (*entity.Comment, error) {
+	comment, err := s.postRepo.GetCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.PostID != postID {
+		return nil, fmt.Errorf("%w: comment %d does not belong to post %d", repository.ErrCommentNotFound, commentID, postID)
+	}
+	return comment, nil
+}
+
+// invalidateCommentCache drops the cached comment hash and the comment lists it appears in.
+// When the comment was deleted its replies list and the parent's reply count are dropped as well.
+func (s *PostService) invalidateCommentCache(comment entity.Comment, deleted bool) {
+	ctx := context.Background()
+	cacheKeys := []string{
+		fmt.Sprintf("comment:%d", comment.ID),
+		fmt.Sprintf("comments:post:%d", comment.PostID),
+	}
+	if comment.ParentCommentID != 0 {
+		cacheKeys = append(cacheKeys, fmt.Sprintf("comments:replies:%d", comment.ParentCommentID))
+	}
+	if deleted {
+		cacheKeys = append(cacheKeys, fmt.Sprintf("comments:replies:%d", comment.ID))
+		if comment.ParentCommentID != 0 {
+			cacheKeys = append(cacheKeys, fmt.Sprintf("comment:%d", comment.ParentCommentID))
+		}
+	}
+
+	_, err := s.redisClient.Del(ctx, cacheKeys...).Result()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to invalidate cache for comment ID %d: %v", comment.ID, err))
+	} else {
+		logger.LogInfo(fmt.Sprintf("Successfully invalidated cache for comment ID %d", comment.ID))
+	}
+}
+
 func (s *PostService) LikePost(postID int, userID int) error {
 	// Add the like in the repository (database)
 	like, err := s.postRepo.AddLike(postID, userID)
@@ -324,16 +657,127 @@ func (s *PostService) LikePost(postID int, userID int) error {
 	return nil
 }
 
+// LikeComment records a like on a comment and bumps its cached like count.
+func (s *PostService) LikeComment(postID int, commentID int, userID int) error {
+	_, err := s.getPostComment(postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	err = s.postRepo.AddCommentLike(commentID, userID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to add like to comment %d by user %d: %v", commentID, userID, err))
+		return err
+	}
+
+	// Update the cache asynchronously
+	go func() {
+		ctx := context.Background()
+		commentCacheKey := fmt.Sprintf("comment:%d", commentID)
+		if s.redisClient.Exists(ctx, commentCacheKey).Val() == 1 {
+			s.redisClient.HIncrBy(ctx, commentCacheKey, "like_count", 1)
+		}
+	}()
+
+	return nil
+}
+
 func (s *PostService) GetComments(postID int, cursor int, limit int) ([]entity.Comment, int, error) {
 	postCommentsCacheKey := fmt.Sprintf("comments:post:%d", postID) // Cache key for post comments sorted set
+	comments, nextCursor, err := s.getCachedComments(
+		postCommentsCacheKey, cursor, limit, func() ([]entity.Comment, int, error) {
+			return s.postRepo.GetComments(postID, cursor, limit)
+		},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachCommentAuthors(comments)
+	return comments, nextCursor, nil
+}
+
+func (s *PostService) GetCommentReplies(commentID int, cursor int, limit int) ([]entity.Comment, int, error) {
+	repliesCacheKey := fmt.Sprintf("comments:replies:%d", commentID) // Cache key for comment replies sorted set
+	replies, nextCursor, err := s.getCachedComments(
+		repliesCacheKey, cursor, limit, func() ([]entity.Comment, int, error) {
+			return s.postRepo.GetCommentReplies(commentID, cursor, limit)
+		},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachCommentAuthors(replies)
+	return replies, nextCursor, nil
+}
+
+// GetTopComments retrieves top-level comments ordered by like count, most liked first.
+// The cursor has the form "<like_count>:<comment_id>" and an empty cursor starts from the first page.
+func (s *PostService) GetTopComments(postID int, cursor string, limit int) ([]entity.Comment, string, error) {
+	cursorLikeCount, cursorID := 0, 0
+	if cursor != "" {
+		_, err := fmt.Sscanf(cursor, "%d:%d", &cursorLikeCount, &cursorID)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w %q: %v", ErrInvalidCommentCursor, cursor, err)
+		}
+	}
+
+	// Like counts change constantly, so top ordering is always read from the database
+	comments, err := s.postRepo.GetTopComments(postID, cursorLikeCount, cursorID, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(comments) > 0 {
+		lastComment := comments[len(comments)-1]
+		nextCursor = fmt.Sprintf("%d:%d", lastComment.LikeCount, lastComment.ID)
+	}
+	s.attachCommentAuthors(comments)
+	return comments, nextCursor, nil
+}
+
+// attachCommentAuthors sets a summary of the commenter on each comment. Comments are still returned
+// without authors when the lookup fails.
+func (s *PostService) attachCommentAuthors(comments []entity.Comment) {
+	var userIDs []int
+	seen := make(map[int]bool)
+	for _, comment := range comments {
+		if !seen[comment.UserID] {
+			seen[comment.UserID] = true
+			userIDs = append(userIDs, comment.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	users, err := s.userService.GetUsers(userIDs)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get comment authors: %v", err))
+		return
+	}
+	usersByID := make(map[int]*entity.User, len(users))
+	for i := range users {
+		usersByID[users[i].ID] = &users[i]
+	}
+	for i := range comments {
+		comments[i].Author = usersByID[comments[i].UserID]
+	}
+}
+
+// getCachedComments reads a page of comments from the sorted set at listCacheKey, falling back to
+// fetchFromDB and refilling the cache when the page is not fully cached.
+func (s *PostService) getCachedComments(
+	listCacheKey string, cursor int, limit int, fetchFromDB func() ([]entity.Comment, int, error),
+) ([]entity.Comment, int, error) {
 	// Attempt to fetch comments from cache
 	commentIDs, err := s.redisClient.ZRangeByScore(
 		context.Background(),
-		postCommentsCacheKey,
+		listCacheKey,
 		&redis.ZRangeBy{
-			Min:   fmt.Sprintf("%d", cursor), // Start from the cursor
-			Max:   "+inf",                    // Up to the maximum value
-			Count: int64(limit),              // Limit the number of comments
+			Min:   fmt.Sprintf("(%d", cursor), // Start after the cursor
+			Max:   "+inf",                     // Up to the maximum value
+			Count: int64(limit),               // Limit the number of comments
 		},
 	).Result()
 
@@ -352,16 +796,12 @@ func (s *PostService) GetComments(postID int, cursor int, limit int) ([]entity.C
 		}
 		commentCacheKey := fmt.Sprintf("comment:%d", commentID) // Cache key for the comment hash
 		commentData, err := s.redisClient.HGetAll(context.Background(), commentCacheKey).Result()
-		if err != nil {
-			logger.LogError(fmt.Sprintf("Failed to get comment data %d: %v", commentID, err))
-			continue
+		if err != nil || len(commentData) == 0 {
+			// The comment hash expired or failed to load, treat the page as a cache miss
+			comments = nil
+			break
 		}
-		comment := entity.Comment{
-			PostID:  postID,
-			ID:      commentID,
-			Content: commentData["content"],
-		}
-		comments = append(comments, comment)
+		comments = append(comments, commentFromCache(commentID, commentData))
 		maxID = max(maxID, commentID)
 	}
 
@@ -370,43 +810,77 @@ func (s *PostService) GetComments(postID int, cursor int, limit int) ([]entity.C
 	}
 
 	// If not found in cache, query the database
-	comments, nextCursor, err := s.postRepo.GetComments(postID, cursor, limit)
+	comments, nextCursor, err := fetchFromDB()
 	if err != nil {
 		return nil, nextCursor, err
 	}
 
 	// Cache the retrieved comments
 	go func() {
+		ctx := context.Background()
 		for _, comment := range comments {
-			commentCacheKey := fmt.Sprintf("comment:%d", comment.ID) // Cache key for the comment hash
-			_, err := s.redisClient.HSet(
-				context.Background(), commentCacheKey, map[string]interface{}{
-					"id":      comment.ID,
-					"post_id": comment.PostID,
-					"content": comment.Content,
-				},
-			).Result()
+			err := s.cacheComment(ctx, comment)
 			if err != nil {
 				logger.LogError(fmt.Sprintf("Failed to cache comment %d: %v", comment.ID, err))
 				return
 			}
 			_, err = s.redisClient.ZAdd(
-				context.Background(),
-				postCommentsCacheKey,
+				ctx,
+				listCacheKey,
 				redis.Z{
 					Score:  float64(comment.ID),
 					Member: comment.ID,
 				},
 			).Result()
 			if err != nil {
-				logger.LogError(fmt.Sprintf("Failed to cache comment %d for post %d: %v", comment.ID, postID, err))
+				logger.LogError(fmt.Sprintf("Failed to cache comment %d in %s: %v", comment.ID, listCacheKey, err))
 			}
 		}
+		s.redisClient.Expire(ctx, listCacheKey, 24*time.Hour)
 	}()
 
 	return comments, nextCursor, nil
 }
 
+// cacheComment stores the comment details in the comment:<id> hash.
+func (s *PostService) cacheComment(ctx context.Context, comment entity.Comment) error {
+	commentCacheKey := fmt.Sprintf("comment:%d", comment.ID) // Cache key for the comment hash
+	_, err := s.redisClient.HSet(
+		ctx, commentCacheKey, map[string]interface{}{
+			"id":                comment.ID,
+			"user_id":           comment.UserID,
+			"post_id":           comment.PostID,
+			"parent_comment_id": comment.ParentCommentID,
+			"depth":             comment.Depth,
+			"content":           comment.Content,
+			"reply_count":       comment.ReplyCount,
+			"like_count":        comment.LikeCount,
+			"created_at":        comment.CreatedAt.Format(time.RFC3339), // Store created_at as string
+		},
+	).Result()
+	if err != nil {
+		return err
+	}
+	s.redisClient.Expire(ctx, commentCacheKey, 24*time.Hour)
+	return nil
+}
+
+// commentFromCache maps a cached comment hash back to the Comment entity.
+func commentFromCache(commentID int, commentData map[string]string) entity.Comment {
+	comment := entity.Comment{
+		ID:      commentID,
+		Content: commentData["content"],
+	}
+	comment.PostID, _ = strconv.Atoi(commentData["post_id"])
+	comment.UserID, _ = strconv.Atoi(commentData["user_id"])
+	comment.ParentCommentID, _ = strconv.Atoi(commentData["parent_comment_id"])
+	comment.Depth, _ = strconv.Atoi(commentData["depth"])
+	comment.ReplyCount, _ = strconv.Atoi(commentData["reply_count"])
+	comment.LikeCount, _ = strconv.Atoi(commentData["like_count"])
+	comment.CreatedAt, _ = time.Parse(time.RFC3339, commentData["created_at"])
+	return comment
+}
+
 func (s *PostService) GetLikes(postID int, cursor time.Time, limit int) ([]entity.User, *time.Time, error) {
 	userLikesKey := fmt.Sprintf("user_likes:%d", postID) // Cache key for the user's liked posts sorted set
 	// Attempt to fetch likes from cache
@@ -519,3 +993,322 @@ func (s *PostService) GetLikeCount(postID int) (int, error) {
 
 	return int(likeCount), nil
 }
+
+// SavePost bookmarks a post for the user, filing it under the collection when one is given.
+func (s *PostService) SavePost(userID int, postID int, collection string) error {
+	collection = strings.TrimSpace(collection)
+	if utf8.RuneCountInString(collection) > maxBookmarkCollectionLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidBookmarkCollection, maxBookmarkCollectionLength)
+	}
+
+	err := s.postRepo.SaveBookmark(userID, postID, collection)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to save post %d for user %d: %v", postID, userID, err))
+		return err
+	}
+	return nil
+}
+
+// UnsavePost removes a post from the user's bookmarks, removing a post that is not saved is a no-op.
+func (s *PostService) UnsavePost(userID int, postID int) error {
+	err := s.postRepo.DeleteBookmark(userID, postID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to unsave post %d for user %d: %v", postID, userID, err))
+		return err
+	}
+	return nil
+}
+
+// ListSavedPosts returns the user's bookmarked posts newest first, optionally limited to one collection.
+func (s *PostService) ListSavedPosts(userID int, collection string, cursor int, limit int) ([]entity.Bookmark, int, error) {
+	return s.postRepo.GetBookmarks(userID, strings.TrimSpace(collection), cursor, limit)
+}
+
+// PinPost pins one of the user's own posts to the top of their profile.
+func (s *PostService) PinPost(postID int, userID int) error {
+	if err := s.checkPostOwner(postID, userID); err != nil {
+		return err
+	}
+
+	err := s.postRepo.PinPost(postID, userID, MaxPinnedPosts)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to pin post %d for user %d: %v", postID, userID, err))
+		return err
+	}
+	s.invalidateUserPostsCache(userID)
+	return nil
+}
+
+// UnpinPost removes one of the user's own posts from the top of their profile.
+func (s *PostService) UnpinPost(postID int, userID int) error {
+	if err := s.checkPostOwner(postID, userID); err != nil {
+		return err
+	}
+
+	err := s.postRepo.UnpinPost(postID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to unpin post %d for user %d: %v", postID, userID, err))
+		return err
+	}
+	s.invalidateUserPostsCache(userID)
+	return nil
+}
+
+func (s *PostService) checkPostOwner(postID int, userID int) error {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return ErrPostPermissionDenied
+	}
+	return nil
+}
+
+// invalidateUserPostsCache drops the cached profile post stream so pinned posts are not listed twice.
+func (s *PostService) invalidateUserPostsCache(userID int) {
+	err := s.redisClient.Del(context.Background(), fmt.Sprintf("posts:%d", userID)).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to invalidate posts cache for user %d: %v", userID, err))
+	}
+}
+
+func validatePoll(poll entity.Poll) error {
+	if len(poll.Options) < entity.PollMinOptions || len(poll.Options) > entity.PollMaxOptions {
+		return fmt.Errorf(
+			"%w: a poll needs %d to %d options", ErrInvalidPoll, entity.PollMinOptions, entity.PollMaxOptions,
+		)
+	}
+	seen := make(map[string]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		text := strings.TrimSpace(option.Text)
+		if text == "" || utf8.RuneCountInString(text) > 255 {
+			return fmt.Errorf("%w: option text must be 1 to 255 characters", ErrInvalidPoll)
+		}
+		if seen[text] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidPoll, text)
+		}
+		seen[text] = true
+	}
+	if !poll.ClosesAt.After(time.Now()) {
+		return fmt.Errorf("%w: closing time must be in the future", ErrInvalidPoll)
+	}
+	return nil
+}
+
+// GetPoll returns the poll of a post as seen by the viewer, nil when the post has no poll.
+// Tallies are hidden until the viewer voted or the poll closed.
+func (s *PostService) GetPoll(postID int, viewerID int) (*entity.Poll, error) {
+	poll, err := s.postRepo.GetPollByPostID(postID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPollNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	votedOptionIDs, err := s.postRepo.GetPollVotes(poll.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	s.applyPollResults(poll, votedOptionIDs)
+	return poll, nil
+}
+
+// VotePoll records the user's votes on the poll of a post and returns the poll with its results.
+func (s *PostService) VotePoll(postID int, userID int, optionIDs []int) (*entity.Poll, error) {
+	poll, err := s.postRepo.GetPollByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Closed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	if len(optionIDs) == 0 {
+		return nil, fmt.Errorf("%w: no option selected", ErrInvalidPollVote)
+	}
+	if !poll.MultipleChoice && len(optionIDs) > 1 {
+		return nil, fmt.Errorf("%w: poll allows a single choice", ErrInvalidPollVote)
+	}
+	pollOptionIDs := make(map[int]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		pollOptionIDs[option.ID] = true
+	}
+	for _, optionID := range optionIDs {
+		if !pollOptionIDs[optionID] {
+			return nil, fmt.Errorf("%w: option %d does not belong to the poll", ErrInvalidPollVote, optionID)
+		}
+	}
+
+	// Create the live tallies before the votes are committed, so a concurrent reader cannot seed them from
+	// counts that already include these votes and have them counted twice
+	if _, err := s.syncPollTallies(poll, nil); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to cache tallies of poll %d: %v", poll.ID, err))
+	}
+
+	votedOptionIDs, err := s.postRepo.AddPollVotes(poll.ID, userID, optionIDs, poll.MultipleChoice)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to vote on poll %d by user %d: %v", poll.ID, userID, err))
+		return nil, err
+	}
+
+	// Count the votes in the live tallies, which are seeded from the counts read after the commit if they
+	// are gone by now
+	poll, err = s.postRepo.GetPollByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+	tallies, err := s.syncPollTallies(poll, votedOptionIDs)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to update tallies of poll %d: %v", poll.ID, err))
+	}
+
+	allVotedOptionIDs, err := s.postRepo.GetPollVotes(poll.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	applyPollVotes(poll, allVotedOptionIDs)
+	applyPollTallies(poll, tallies)
+	return poll, nil
+}
+
+// applyPollResults fills the poll tallies from the live Redis hash, or hides them when the viewer
+// has not voted and the poll is still open.
+func (s *PostService) applyPollResults(poll *entity.Poll, votedOptionIDs []int) {
+	applyPollVotes(poll, votedOptionIDs)
+	if !poll.ResultsVisible {
+		return
+	}
+
+	tallies, err := s.syncPollTallies(poll, nil)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get tallies of poll %d from cache: %v", poll.ID, err))
+		return
+	}
+	applyPollTallies(poll, tallies)
+}
+
+// applyPollVotes marks the options the viewer voted for and hides the tallies when the viewer has not
+// voted and the poll is still open.
+func applyPollVotes(poll *entity.Poll, votedOptionIDs []int) {
+	voted := make(map[int]bool, len(votedOptionIDs))
+	for _, optionID := range votedOptionIDs {
+		voted[optionID] = true
+	}
+	for i := range poll.Options {
+		poll.Options[i].Voted = voted[poll.Options[i].ID]
+	}
+
+	poll.ResultsVisible = len(votedOptionIDs) > 0 || poll.Closed(time.Now())
+	if !poll.ResultsVisible {
+		for i := range poll.Options {
+			poll.Options[i].VoteCount = 0
+		}
+	}
+}
+
+// applyPollTallies replaces the persisted counts of a poll whose results are visible with the live
+// tallies keyed by option ID. Options missing from the tallies keep their persisted count.
+func applyPollTallies(poll *entity.Poll, tallies map[string]string) {
+	if !poll.ResultsVisible {
+		return
+	}
+	for i := range poll.Options {
+		if count, err := strconv.Atoi(tallies[strconv.Itoa(poll.Options[i].ID)]); err == nil {
+			poll.Options[i].VoteCount = count
+		}
+	}
+}
+
+// syncPollTallies adds one vote per option in votedOptionIDs to the live tallies of the poll, creating
+// them from the poll's persisted counts when they are missing, and returns the tallies keyed by option ID.
+// Existing tallies are never overwritten, the persisted counts may be older than them.
+func (s *PostService) syncPollTallies(poll *entity.Poll, votedOptionIDs []int) (map[string]string, error) {
+	args := []interface{}{int((24 * time.Hour).Seconds()), len(votedOptionIDs)}
+	for _, optionID := range votedOptionIDs {
+		args = append(args, optionID)
+	}
+	for _, option := range poll.Options {
+		args = append(args, option.ID, option.VoteCount)
+	}
+
+	values, err := syncPollTalliesScript.Run(
+		context.Background(), s.redisClient, []string{fmt.Sprintf("poll:%d:tallies", poll.ID)}, args...,
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	tallies := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		tallies[values[i]] = values[i+1]
+	}
+	return tallies, nil
+}
+
+// GetLinkPreview returns the link preview of a post, nil when none has been stored.
+func (s *PostService) GetLinkPreview(postID int) (*entity.LinkPreview, error) {
+	preview, err := s.postRepo.GetLinkPreviewByPostID(postID)
+	if errors.Is(err, repository.ErrLinkPreviewNotFound) {
+		return nil, nil
+	}
+	return preview, err
+}
+
+// SearchPosts runs a full-text search over visible posts, newest first. Every term must match,
+// "quoted phrases" match exactly and terms ending in * match by prefix.
+func (s *PostService) SearchPosts(filter entity.PostSearchFilter) ([]entity.Post, int, error) {
+	if utf8.RuneCountInString(filter.Query) > maxSearchQueryLength {
+		return nil, 0, fmt.Errorf("%w: longer than %d characters", ErrInvalidSearchQuery, maxSearchQueryLength)
+	}
+	query := booleanSearchQuery(filter.Query)
+	if query == "" {
+		return nil, 0, fmt.Errorf("%w: no search terms", ErrInvalidSearchQuery)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, fmt.Errorf("%w: from must be before to", ErrInvalidSearchQuery)
+	}
+
+	filter.Query = query
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	filter.Limit = min(filter.Limit, maxSearchLimit)
+	return s.postRepo.SearchPosts(filter)
+}
+
+// booleanSearchQuery turns user search text into a MySQL boolean mode expression requiring every
+// term. Operators typed by the user are dropped so they cannot change the meaning of the query.
+func booleanSearchQuery(text string) string {
+	var terms []string
+	for i, part := range strings.Split(text, `"`) {
+		// Odd parts were enclosed in double quotes
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(stripSearchOperators(part)), " "); phrase != "" {
+				terms = append(terms, `+"`+phrase+`"`)
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			words := strings.Fields(stripSearchOperators(word))
+			for j, w := range words {
+				// A trailing * makes the last term of the word a prefix search
+				if j == len(words)-1 && strings.HasSuffix(word, "*") {
+					w += "*"
+				}
+				terms = append(terms, "+"+w)
+			}
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+func stripSearchOperators(text string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if strings.ContainsRune(`+-<>()~*"@`, r) {
+				return ' '
+			}
+			return r
+		}, text,
+	)
+}
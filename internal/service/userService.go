@@ -4,79 +4,264 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base32"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"log"
+	"net/mail"
+	"net/url"
+	"news-feed/internal/apikey"
 	"news-feed/internal/entity"
 	"news-feed/internal/repository"
+	"news-feed/internal/session"
+	"news-feed/internal/storage"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/logger"
+	"news-feed/pkg/mailer"
 	"news-feed/pkg/middleware"
+	"news-feed/pkg/oidc"
+	"news-feed/pkg/password"
+	"news-feed/pkg/totp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // UserServiceInterface defines methods for user-related business logic.
 type UserServiceInterface interface {
-	Login(username, password string) (string, error)
-	Signup(user entity.User) (string, error)
-	EditProfile(user entity.User) error
+	Login(username, password string, device string, userAgent string, clientIP string) (*entity.TokenPair, error)
+	Signup(user entity.User, device string, userAgent string) (*entity.TokenPair, error)
+	RefreshToken(refreshToken string) (*entity.TokenPair, error)
+	Logout(userID int, sessionID string) error
+	ListSessions(userID int, currentSessionID string) ([]entity.Session, error)
+	RevokeSession(userID int, sessionID string) error
+	EditProfile(user entity.User, fields []string) error
 	InitializeBloomFilter() error
 	PeriodicallyRefreshBloomFilter(interval time.Duration)
 	GetUsers(userIDs []int) ([]entity.User, error)
+	SearchUsers(searcherID int, query string, limit int) ([]entity.UserSearchResult, error)
+	GetProfile(viewerID int, userID int) (*entity.UserProfile, error)
+	GetProfileByUsername(viewerID int, username string) (*entity.UserProfile, error)
+	BlockUser(userID int, blockedUserID int) error
+	UnblockUser(userID int, blockedUserID int) error
+	RequestProfileImageUpload(userID int, kind string) (uploadURL string, objectKey string, err error)
+	ConfirmProfileImage(userID int, kind string) (string, error)
+	RequestPasswordReset(email string, clientIP string) error
+	ResetPassword(token string, newPassword string) error
+	VerifyEmail(token string) error
+	ResendVerification(userID int) error
+	IsEmailVerified(userID int) (bool, error)
+	EnrollTOTP(userID int) (otpauthURI string, secret string, err error)
+	ActivateTOTP(userID int, code string) (recoveryCodes []string, err error)
+	VerifyTwoFactor(twoFactorToken string, code string) (*entity.TokenPair, error)
+	StartOIDCLogin(device string, userAgent string) (authorizationURL string, state string, err error)
+	CompleteOIDCLogin(state string, code string) (*entity.TokenPair, error)
+	CreateAPIKey(userID int, name string, scope string, expiresInDays int) (*entity.APIKey, error)
+	ListAPIKeys(userID int) ([]entity.APIKey, error)
+	RevokeAPIKey(userID int, keyID string) error
 }
 
+// ErrInvalidVerificationToken is returned when an email verification token is unknown, expired, already
+// used or was sent to an email the user has since changed.
+var ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+
+// ErrEmailAlreadyVerified is returned when resending verification for a verified email.
+var ErrEmailAlreadyVerified = errors.New("email is already verified")
+
+// ErrVerificationRecentlySent is returned when verification emails are resent too often.
+var ErrVerificationRecentlySent = errors.New("verification email was sent recently")
+
+// ErrEmailNotVerified is returned when an unverified account performs an action restricted to verified ones.
+var ErrEmailNotVerified = errors.New("email is not verified")
+
+// ErrTwoFactorAlreadyEnabled is returned when enrolling or activating TOTP for a user who already has it.
+var ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+// ErrTwoFactorNotEnrolled is returned when activating TOTP before enrolling.
+var ErrTwoFactorNotEnrolled = errors.New("two-factor authentication has not been enrolled")
+
+// ErrInvalidTwoFactorCode is returned when a TOTP or recovery code is wrong or was already used.
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+// ErrInvalidTwoFactorToken is returned when a pending two-factor login is unknown, expired or used up.
+var ErrInvalidTwoFactorToken = errors.New("invalid or expired two-factor token")
+
+// ErrOIDCNotConfigured is returned by OIDC logins when no identity provider is configured.
+var ErrOIDCNotConfigured = errors.New("sign in with an identity provider is not configured")
+
+// ErrInvalidOIDCState is returned when an OIDC login is completed with an unknown, expired or used state.
+var ErrInvalidOIDCState = errors.New("invalid or expired login state")
+
+// ErrOIDCLoginFailed is returned when the identity provider rejects the authorization code or returns an
+// invalid ID token.
+var ErrOIDCLoginFailed = errors.New("sign in with the identity provider failed")
+
+// ErrOIDCEmailNotVerified is returned when a new identity cannot be linked or signed up because the
+// provider did not verify its email.
+var ErrOIDCEmailNotVerified = errors.New("the identity provider did not verify the email")
+
+// ErrOIDCEmailInUse is returned when an account with the identity's email exists but has not verified the
+// email, linking it would let whoever registered the email take over the identity's account.
+var ErrOIDCEmailInUse = errors.New("an account with this email exists, verify its email or sign in with its password first")
+
+// ErrInvalidAPIKeyRequest is returned when an API key is created with an invalid name, scope or lifetime.
+var ErrInvalidAPIKeyRequest = errors.New("invalid API key request")
+
+// ErrTooManyAPIKeys is returned when a user already has the maximum number of active API keys.
+var ErrTooManyAPIKeys = errors.New("too many API keys, revoke one first")
+
+// ErrInvalidResetToken is returned when a password reset token is unknown, expired or already used.
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+// ErrTooManyResetRequests is returned when password resets are requested too often for an email or from
+// an IP address.
+var ErrTooManyResetRequests = errors.New("too many password reset requests, try again later")
+
+// ErrInvalidProfile is returned when a profile update names an unknown field or a value fails validation.
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// ErrCannotBlockSelf is returned when a user tries to block themselves.
+var ErrCannotBlockSelf = errors.New("cannot block yourself")
+
+// ErrInvalidProfileImageKind is returned when a profile image is neither an avatar nor a cover.
+var ErrInvalidProfileImageKind = errors.New("invalid profile image kind")
+
+// ErrInvalidProfileImage is returned when a confirmed profile image upload is not an image or too large.
+var ErrInvalidProfileImage = errors.New("invalid profile image")
+
+// ErrProfileImageNotUploaded is returned when confirming a profile image that has no pending upload
+// or whose upload has not reached object storage.
+var ErrProfileImageNotUploaded = errors.New("profile image has not been uploaded")
+
 const (
 	// Batch size for processing
 	batchSize = 1000
 	// Number of worker goroutines
 	numWorkers = 10
+
+	// Sorted set of "<lowercased name>\x00<user id>" members, all scored 0 so they are ordered
+	// lexicographically for prefix lookups with ZRANGEBYLEX
+	userAutocompleteKey = "users:autocomplete"
+	// Default and maximum number of users returned by a search
+	defaultUserSearchLimit = 10
+	maxUserSearchLimit     = 50
+
+	// How long a presigned profile image upload stays pending, matches the presigned URL expiry
+	profileImageUploadTTL = 15 * time.Minute
+	// Largest avatar or cover image accepted
+	maxProfileImageSize = 10 << 20
+
+	// Window the password reset requests per email and IP address are limited in
+	passwordResetRequestWindow = time.Hour
+
+	// Minimum time between two verification emails to the same user
+	verificationResendInterval = time.Minute
+	// How long whether a user verified their email is cached, it is invalidated when that changes
+	emailVerifiedCacheTTL = time.Hour
+
+	// Codes that can be tried against one pending two-factor login before it is dropped
+	maxTwoFactorAttempts = 5
+	// Recovery codes issued when two-factor authentication is activated
+	recoveryCodeCount = 10
+	// How long a used TOTP code is remembered, covers the periods accepted for clock drift
+	usedTOTPCodeTTL = 2 * time.Minute
+
+	// Longest username generated for accounts signed up through an identity provider, and how often a
+	// random suffix is tried when it is taken
+	maxOIDCUsernameLength   = 30
+	oidcUsernameSuffixTries = 5
+
+	// Active API keys a user may have, and the longest name of one
+	maxAPIKeysPerUser   = 20
+	maxAPIKeyNameLength = 64
 )
 
 // UserService is a concrete implementation of UserServiceInterface.
 type UserService struct {
-	userRepo    repository.UserRepositoryInterface
-	redisClient *redis.Client
+	userRepo     repository.UserRepositoryInterface
+	storage      storage.MinioStorageInterface
+	mailer       mailer.Mailer
+	sessions     session.StoreInterface
+	apiKeys      apikey.StoreInterface
+	loginLimiter LoginLimiterInterface
+	totp         *totp.TOTP
+	oidcProvider *oidc.Provider // Nil unless an identity provider is configured
+	redisClient  *redis.Client
+
+	passwordResetURL string
+	passwordResetTTL time.Duration
+
+	passwordResetMaxPerEmail int
+	passwordResetMaxPerIP    int
+
+	emailVerificationURL string
+	emailVerificationTTL time.Duration
+
+	totpIssuer        string
+	twoFactorTokenTTL time.Duration
+
+	oidcStateTTL time.Duration
+
+	apiKeyDefaultTTL time.Duration
+	apiKeyMaxTTL     time.Duration
 }
 
-func (s *UserService) Signup(user entity.User) (string, error) {
-	// Generate salt
-	salt := generateSalt()
+func (s *UserService) Signup(user entity.User, device string, userAgent string) (*entity.TokenPair, error) {
+	user.Email = strings.TrimSpace(user.Email)
+	if err := validateEmail(user.Email); err != nil {
+		return nil, err
+	}
 
-	// Hash the password with the salt
-	hashedPassword := hashPassword(user.Password, salt)
+	// Hash the password, the salt is part of the encoded hash
+	hashedPassword, err := password.Hash(user.Password)
+	if err != nil {
+		return nil, err
+	}
 
 	user.HashedPassword = hashedPassword
-	user.Salt = salt
+	user.Salt = ""
 
-	err := s.userRepo.CreateUser(user)
+	userID, err := s.userRepo.CreateUser(user)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	user.ID = userID
+
+	s.indexUserForAutocomplete(user)
+
+	// The account starts unverified, a failed email can be sent again with ResendVerification
+	if err := s.sendEmailVerification(user); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to send verification email to user %d: %v", user.ID, err))
 	}
 
 	// Add the user to the Bloom filter
 	err = s.redisClient.BFAdd(context.Background(), "users_bloom", user.Username).Err()
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Error when add user to bloom filter: %s", err.Error()))
-		return "", err
+		return nil, err
 	}
 
-	// Generate JWT
-	jwtToken, err := middleware.GenerateJWT(string(rune(user.ID)))
-	if err != nil {
-		return "", fmt.Errorf("could not generate JWT: %v", err)
-	}
+	return s.createSession(user.ID, user.Username, device, userAgent)
+}
 
-	// Store the JWT in Redis with a TTL (e.g., 24 hours)
-	err = s.redisClient.Set(context.Background(), jwtToken, user.Username, 24*time.Hour).Err()
+// Login signs the user in. Failed attempts are limited per username and client IP address, errors for
+// failed or refused attempts are a *LoginThrottleError.
+func (s *UserService) Login(username, plainPassword string, device string, userAgent string, clientIP string) (*entity.TokenPair, error) {
+	throttle, err := s.loginLimiter.Check(username, clientIP)
 	if err != nil {
-		return "", fmt.Errorf("could not store JWT in Redis: %v", err)
+		logger.LogError(fmt.Sprintf("Error when checking login attempts: %v", err))
+	}
+	if throttle != nil {
+		return nil, throttle
 	}
 
-	return jwtToken, nil
-}
-
-func (s *UserService) Login(username, password string) (string, error) {
 	// Check if the user might exist using the Bloom filter
 	userExists, err := s.redisClient.BFExists(context.Background(), "users_bloom", username).Result()
 	if err != nil {
@@ -86,7 +271,7 @@ func (s *UserService) Login(username, password string) (string, error) {
 	// If the Bloom filter indicates that the user doesn't exist
 	if !userExists && errors.Is(err, redis.Nil) {
 		logger.LogError(fmt.Sprintf("User %s does not exist (Bloom filter)", username))
-		return "", fmt.Errorf("user does not exist")
+		return nil, s.loginFailed(username, clientIP)
 	}
 
 	// Define the Redis key for the user
@@ -101,239 +286,1611 @@ func (s *UserService) Login(username, password string) (string, error) {
 			logger.LogError(fmt.Sprintf("Error when getting user data from Redis: %v", err))
 		}
 		// User not in cache, fetch from DB and cache it
-		localCachedUser, s2, err2 := s.getUserFromDBAndCache(username)
+		localCachedUser, _, err2 := s.getUserFromDBAndCache(username)
+		if errors.Is(err2, repository.ErrUserNotFound) {
+			return nil, s.loginFailed(username, clientIP)
+		}
 		if err2 != nil {
-			return s2, err2
+			return nil, err2
 		}
 		cachedUserData = map[string]string{
+			"id":             strconv.Itoa(localCachedUser.ID),
 			"hashedPassword": localCachedUser.HashedPassword,
 			"salt":           localCachedUser.Salt,
+			"totpEnabled":    strconv.FormatBool(localCachedUser.TOTPEnabled),
 		}
 	}
 
 	// Extract user fields from the cached data
+	userID, idErr := strconv.Atoi(cachedUserData["id"])
 	hashedPassword, passwordExists := cachedUserData["hashedPassword"]
 	salt, saltExists := cachedUserData["salt"]
+	totpEnabled, totpErr := strconv.ParseBool(cachedUserData["totpEnabled"])
 
 	// If some fields are missing, fetch from the database
-	if !passwordExists || !saltExists {
-		localCachedUser, s2, err2 := s.getUserFromDBAndCache(username)
+	if idErr != nil || !passwordExists || !saltExists || totpErr != nil {
+		localCachedUser, _, err2 := s.getUserFromDBAndCache(username)
+		if errors.Is(err2, repository.ErrUserNotFound) {
+			return nil, s.loginFailed(username, clientIP)
+		}
 		if err2 != nil {
-			return s2, err2
+			return nil, err2
 		}
+		userID = localCachedUser.ID
 		hashedPassword = localCachedUser.HashedPassword
 		salt = localCachedUser.Salt
+		totpEnabled = localCachedUser.TOTPEnabled
 	}
 
 	// Verify the password
-	if !verifyPassword(password, hashedPassword, salt) {
+	match, needsRehash, err := password.Verify(plainPassword, hashedPassword, salt)
+	if err != nil || !match {
 		logger.LogError(fmt.Sprintf("Error when verifying password: %v", err))
-		return "", fmt.Errorf("invalid credentials")
+		return nil, s.loginFailed(username, clientIP)
+	}
+	if needsRehash {
+		s.rehashPassword(username, plainPassword, hashedPassword)
 	}
 
-	// Generate JWT
-	jwtToken, err := middleware.GenerateJWT(username)
+	if totpEnabled {
+		// Failures are only reset once the second factor was verified too, see VerifyTwoFactor
+		return s.startTwoFactorLogin(userID, username, device, userAgent, clientIP)
+	}
+	if err := s.loginLimiter.Reset(username); err != nil {
+		logger.LogError(fmt.Sprintf("Error when resetting login attempts: %v", err))
+	}
+	return s.createSession(userID, username, device, userAgent)
+}
+
+// loginFailed records a failed login and returns the error to report, unknown usernames count as failures
+// too so they cannot be probed without limit.
+func (s *UserService) loginFailed(username string, clientIP string) error {
+	throttle, err := s.loginLimiter.RecordFailure(username, clientIP)
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Error when generate JWT: %v", err))
-		return "", fmt.Errorf("could not generate JWT: %v", err)
+		logger.LogError(fmt.Sprintf("Error when recording failed login: %v", err))
+		return &LoginThrottleError{Err: ErrInvalidCredentials}
 	}
-	return jwtToken, nil
+	return throttle
+}
+
+// Logout ends the session the request was made with.
+func (s *UserService) Logout(userID int, sessionID string) error {
+	return s.sessions.Delete(userID, sessionID)
 }
 
-// EditProfile updates a user's profile.
-func (s *UserService) EditProfile(user entity.User) error {
-	existingUser, err := s.userRepo.GetByUserName(user.Username)
+// ListSessions returns the user's active sessions, marking the one the request was made with.
+func (s *UserService) ListSessions(userID int, currentSessionID string) ([]entity.Session, error) {
+	sessions, err := s.sessions.List(userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if (existingUser == entity.User{}) {
-		return fmt.Errorf("user does not exist")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
 	}
-	return s.userRepo.UpdateUser(user)
+	return sessions, nil
 }
 
-// InitializeBloomFilter initializes the Bloom filter using worker and batch processing
-func (s *UserService) InitializeBloomFilter() error {
-	// Get all usernames from the database
-	userNames, err := s.userRepo.GetAllUserNames()
+// RevokeSession ends one of the user's sessions, e.g. on a lost device.
+func (s *UserService) RevokeSession(userID int, sessionID string) error {
+	return s.sessions.Delete(userID, sessionID)
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token pair. Each refresh token can
+// be used once, reusing one revokes its session.
+func (s *UserService) RefreshToken(refreshToken string) (*entity.TokenPair, error) {
+	userSession, err := s.sessions.RotateRefreshToken(refreshToken)
 	if err != nil {
-		log.Printf("Error retrieving all usernames: %v", err)
-		return err
+		if errors.Is(err, session.ErrRefreshTokenReused) {
+			logger.LogError("Refresh token reused, session revoked")
+		}
+		return nil, err
 	}
+	return issueTokenPair(userSession)
+}
 
-	// Create a channel to send batches to workers
-	batchChan := make(chan []string, numWorkers)
-	done := make(chan struct{})
+// createSession starts a session for the user and returns its first token pair.
+func (s *UserService) createSession(userID int, username string, device string, userAgent string) (*entity.TokenPair, error) {
+	userSession, err := s.sessions.Create(userID, username, device, userAgent)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when creating session: %v", err))
+		return nil, err
+	}
+	return issueTokenPair(userSession)
+}
 
-	// Worker function
-	worker := func(id int, batchChan <-chan []string, done chan<- struct{}) {
-		for batch := range batchChan {
-			pipe := s.redisClient.Pipeline()
-			for _, username := range batch {
-				pipe.BFAdd(context.Background(), "users_bloom", username)
-			}
-			_, err := pipe.Exec(context.Background())
-			if err != nil {
-				logger.LogError(fmt.Sprintf("Worker %d: Error adding usernames to Bloom filter: %v", id, err))
-			}
-		}
-		done <- struct{}{}
+// issueTokenPair signs an access token for the session and pairs it with the session's refresh token.
+func issueTokenPair(userSession *entity.Session) (*entity.TokenPair, error) {
+	// Every account has the user role, there are no other roles yet
+	jwtToken, err := middleware.GenerateJWT(
+		auth.Principal{
+			UserID:    userSession.UserID,
+			Username:  userSession.Username,
+			Roles:     []string{auth.RoleUser},
+			SessionID: userSession.ID,
+		},
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when generate JWT: %v", err))
+		return nil, fmt.Errorf("could not generate JWT: %v", err)
 	}
+	return &entity.TokenPair{
+		AccessToken:  jwtToken,
+		RefreshToken: userSession.RefreshToken,
+		ExpiresIn:    int(middleware.AccessTokenTTL.Seconds()),
+	}, nil
+}
 
-	// Start worker goroutines
-	for i := 0; i < numWorkers; i++ {
-		go worker(i, batchChan, done)
+// EditProfile sets the profile fields named in fields. Without fields only the non-empty fields of user
+// are updated, naming a field with an empty value clears it.
+func (s *UserService) EditProfile(user entity.User, fields []string) error {
+	existingUser, err := s.userRepo.GetByUserID(user.ID)
+	if err != nil {
+		return err
 	}
 
-	// Divide usernames into batches and send to workers
-	for i := 0; i < len(userNames); i += batchSize {
-		end := i + batchSize
-		if end > len(userNames) {
-			end = len(userNames)
+	user.FirstName = strings.TrimSpace(user.FirstName)
+	user.LastName = strings.TrimSpace(user.LastName)
+	user.DisplayName = strings.TrimSpace(user.DisplayName)
+	user.Bio = strings.TrimSpace(user.Bio)
+	user.Website = strings.TrimSpace(user.Website)
+	user.Location = strings.TrimSpace(user.Location)
+	user.Email = strings.TrimSpace(user.Email)
+	if len(fields) == 0 {
+		fields = nonEmptyProfileFields(user)
+	}
+	if err := validateProfileUpdate(user, fields); err != nil {
+		return err
+	}
+	if slices.Contains(fields, entity.ProfileFieldPassword) {
+		user.HashedPassword, err = password.Hash(user.Password)
+		if err != nil {
+			return err
 		}
-		batch := userNames[i:end]
-		batchChan <- batch
+		user.Salt = ""
 	}
-	close(batchChan)
 
-	// Wait for all workers to complete
-	for i := 0; i < numWorkers; i++ {
-		<-done
+	err = s.userRepo.UpdateUser(user, fields)
+	if err != nil {
+		return err
 	}
 
-	logger.LogInfo("Successfully initialized Bloom filter with usernames")
+	// Re-index the names that changed
+	updatedUser := existingUser
+	if slices.Contains(fields, entity.ProfileFieldFirstName) {
+		updatedUser.FirstName = user.FirstName
+	}
+	if slices.Contains(fields, entity.ProfileFieldLastName) {
+		updatedUser.LastName = user.LastName
+	}
+	s.removeUserFromAutocomplete(existingUser)
+	s.indexUserForAutocomplete(updatedUser)
+	if slices.Contains(fields, entity.ProfileFieldPassword) {
+		// The login cache holds the old password hash
+		s.redisClient.Del(context.Background(), fmt.Sprintf("user:%s", existingUser.Username))
+	}
+	if slices.Contains(fields, entity.ProfileFieldEmail) && user.Email != existingUser.Email {
+		updatedUser.Email = user.Email
+		if err := s.sendEmailVerification(updatedUser); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to send verification email to user %d: %v", user.ID, err))
+		}
+	}
+	invalidateUserCache(s.redisClient, existingUser.ID)
+	invalidateProfileCache(s.redisClient, existingUser.ID)
 	return nil
 }
 
-func (s *UserService) PeriodicallyRefreshBloomFilter(interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+// nonEmptyProfileFields names the fields of user that have a value.
+func nonEmptyProfileFields(user entity.User) []string {
+	var fields []string
+	values := []struct {
+		field string
+		set   bool
+	}{
+		{entity.ProfileFieldFirstName, user.FirstName != ""},
+		{entity.ProfileFieldLastName, user.LastName != ""},
+		{entity.ProfileFieldBirthday, !user.Birthday.IsZero()},
+		{entity.ProfileFieldPassword, user.Password != ""},
+		{entity.ProfileFieldDisplayName, user.DisplayName != ""},
+		{entity.ProfileFieldBio, user.Bio != ""},
+		{entity.ProfileFieldWebsite, user.Website != ""},
+		{entity.ProfileFieldLocation, user.Location != ""},
+		{entity.ProfileFieldEmail, user.Email != ""},
+	}
+	for _, value := range values {
+		if value.set {
+			fields = append(fields, value.field)
+		}
+	}
+	return fields
+}
 
-	for range ticker.C {
-		err := s.InitializeBloomFilter()
-		if err != nil {
-			logger.LogError(fmt.Sprintf("Error refreshing Bloom filter: %v", err))
+// validateProfileUpdate checks the values of the fields being updated. Names, birthday and password
+// are required and cannot be cleared, the free-form fields can.
+func validateProfileUpdate(user entity.User, fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case entity.ProfileFieldFirstName:
+			if user.FirstName == "" {
+				return fmt.Errorf("%w: first name is required", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldLastName:
+			if user.LastName == "" {
+				return fmt.Errorf("%w: last name is required", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldBirthday:
+			if user.Birthday.IsZero() {
+				return fmt.Errorf("%w: birthday is required", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldPassword:
+			if user.Password == "" {
+				return fmt.Errorf("%w: password is required", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldDisplayName:
+			if utf8.RuneCountInString(user.DisplayName) > entity.MaxDisplayNameLength {
+				return fmt.Errorf("%w: display name longer than %d characters", ErrInvalidProfile, entity.MaxDisplayNameLength)
+			}
+			if strings.ContainsFunc(user.DisplayName, unicode.IsControl) {
+				return fmt.Errorf("%w: display name contains control characters", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldBio:
+			if utf8.RuneCountInString(user.Bio) > entity.MaxBioLength {
+				return fmt.Errorf("%w: bio longer than %d characters", ErrInvalidProfile, entity.MaxBioLength)
+			}
+		case entity.ProfileFieldWebsite:
+			if user.Website == "" {
+				continue
+			}
+			if utf8.RuneCountInString(user.Website) > entity.MaxWebsiteLength {
+				return fmt.Errorf("%w: website longer than %d characters", ErrInvalidProfile, entity.MaxWebsiteLength)
+			}
+			website, err := url.Parse(user.Website)
+			if err != nil || (website.Scheme != "http" && website.Scheme != "https") || website.Host == "" {
+				return fmt.Errorf("%w: website must be an http or https URL", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldLocation:
+			if utf8.RuneCountInString(user.Location) > entity.MaxLocationLength {
+				return fmt.Errorf("%w: location longer than %d characters", ErrInvalidProfile, entity.MaxLocationLength)
+			}
+			if strings.ContainsFunc(user.Location, unicode.IsControl) {
+				return fmt.Errorf("%w: location contains control characters", ErrInvalidProfile)
+			}
+		case entity.ProfileFieldEmail:
+			if err := validateEmail(user.Email); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidProfile, field)
 		}
 	}
+	return nil
 }
 
-func (s *UserService) GetUsers(userIDs []int) ([]entity.User, error) {
+// GetProfile returns the public profile of a user with the viewer's relationship to them.
+func (s *UserService) GetProfile(viewerID int, userID int) (*entity.UserProfile, error) {
 	ctx := context.Background()
-	var users []entity.User
-	var usersToFetchFromDB []int
-	cacheKeyPrefix := "user:" // Cache key prefix for each user
-
-	// Iterate over user IDs and try to fetch from cache
-	for _, userID := range userIDs {
-		userCacheKey := fmt.Sprintf("%s%d", cacheKeyPrefix, userID)
-		cachedUser, err := s.redisClient.HGetAll(ctx, userCacheKey).Result()
+	profileCacheKey := fmt.Sprintf("profile:%d", userID)
 
+	var profile *entity.UserProfile
+	cachedProfile, err := s.redisClient.HGetAll(ctx, profileCacheKey).Result()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get profile %d from cache: %v", userID, err))
+	}
+	if len(cachedProfile) > 0 {
+		profile = &entity.UserProfile{
+			ID:          userID,
+			Username:    cachedProfile["username"],
+			FirstName:   cachedProfile["first_name"],
+			LastName:    cachedProfile["last_name"],
+			DisplayName: cachedProfile["display_name"],
+			Bio:         cachedProfile["bio"],
+			Website:     cachedProfile["website"],
+			Location:    cachedProfile["location"],
+			AvatarURL:   profileImageURL(s.storage, cachedProfile["avatar_key"]),
+			CoverURL:    profileImageURL(s.storage, cachedProfile["cover_key"]),
+		}
+		profile.FollowerCount, _ = strconv.Atoi(cachedProfile["follower_count"])
+		profile.FollowingCount, _ = strconv.Atoi(cachedProfile["following_count"])
+		profile.PostCount, _ = strconv.Atoi(cachedProfile["post_count"])
+	} else {
+		user, err := s.userRepo.GetByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		profile = &entity.UserProfile{
+			ID:          user.ID,
+			Username:    user.Username,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			DisplayName: user.DisplayName,
+			Bio:         user.Bio,
+			Website:     user.Website,
+			Location:    user.Location,
+			AvatarURL:   profileImageURL(s.storage, user.AvatarKey),
+			CoverURL:    profileImageURL(s.storage, user.CoverKey),
+		}
+		profile.FollowerCount, profile.FollowingCount, profile.PostCount, err = s.userRepo.GetProfileCounts(userID)
 		if err != nil {
-			logger.LogError(fmt.Sprintf("Error when fetching user data from Redis: %v", err))
 			return nil, err
 		}
 
-		if len(cachedUser) > 0 {
-			birthdayStr := cachedUser["birthday"]
-			birthday, err := time.Parse(time.RFC3339, birthdayStr)
-			if err != nil {
-				// Handle error (e.g., log it, or set a default value)
-				logger.LogError(fmt.Sprintf("Failed to parse birthday for user %d: %v", userID, err))
-				return nil, err
-			}
-			// If user exists in cache, map it to the entity.User struct
-			user := entity.User{
-				ID:        userID,
-				FirstName: cachedUser["first_name"],
-				LastName:  cachedUser["last_name"],
-				Birthday:  birthday, // You might need to parse the date depending on your date format
-				Email:     cachedUser["email"],
-				Username:  cachedUser["username"],
-			}
-			users = append(users, user)
+		// Only public fields are cached, the viewer relationship is resolved per request
+		err = s.redisClient.HSet(
+			ctx, profileCacheKey, map[string]interface{}{
+				"username":        profile.Username,
+				"first_name":      profile.FirstName,
+				"last_name":       profile.LastName,
+				"avatar_key":      user.AvatarKey,
+				"cover_key":       user.CoverKey,
+				"display_name":    profile.DisplayName,
+				"bio":             profile.Bio,
+				"website":         profile.Website,
+				"location":        profile.Location,
+				"follower_count":  profile.FollowerCount,
+				"following_count": profile.FollowingCount,
+				"post_count":      profile.PostCount,
+			},
+		).Err()
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to cache profile %d: %v", userID, err))
 		} else {
-			// If user is not in cache, add the ID to the list to fetch from DB
-			usersToFetchFromDB = append(usersToFetchFromDB, userID)
+			s.redisClient.Expire(ctx, profileCacheKey, time.Hour)
 		}
 	}
 
-	// If all users are found in cache, return them
-	if len(usersToFetchFromDB) == 0 {
-		return users, nil
+	if viewerID != 0 && viewerID != userID {
+		following, followers, err := s.userRepo.GetFollowRelations(viewerID, []int{userID})
+		if err != nil {
+			return nil, err
+		}
+		profile.Following = following[userID]
+		profile.FollowedBy = followers[userID]
+
+		profile.Blocked, err = s.userRepo.IsBlocked(viewerID, userID)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return profile, nil
+}
 
-	// Fetch missing users from the database
-	dbUsers, err := s.userRepo.GetUsers(usersToFetchFromDB)
+// GetProfileByUsername returns the public profile of the user with the given username.
+func (s *UserService) GetProfileByUsername(viewerID int, username string) (*entity.UserProfile, error) {
+	user, err := s.userRepo.GetByUserName(username)
 	if err != nil {
 		return nil, err
 	}
+	return s.GetProfile(viewerID, user.ID)
+}
 
-	users = append(users, dbUsers...)
-
-	// Update the cache for the users fetched from the database in a goroutine
-	go func(users []entity.User) {
-		for _, user := range dbUsers {
-			userCacheKey := fmt.Sprintf("%s%d", cacheKeyPrefix, user.ID)
-			userMap := map[string]interface{}{
-				"first_name": user.FirstName,
-				"last_name":  user.LastName,
-				"birthday":   user.Birthday.Format(time.RFC3339), // Format the time properly
-				"email":      user.Email,
-				"username":   user.Username,
-			}
-			_, err := s.redisClient.HMSet(ctx, userCacheKey, userMap).Result()
-			if err != nil {
-				logger.LogError(fmt.Sprintf("Failed to cache user %d: %v", user.ID, err))
-			}
-			// Optionally, set expiration for the cached user (e.g., 24 hours)
-			s.redisClient.Expire(ctx, userCacheKey, 24*time.Hour)
-		}
-	}(dbUsers)
-
-	return users, nil
+// BlockUser blocks another user for the user.
+func (s *UserService) BlockUser(userID int, blockedUserID int) error {
+	if userID == blockedUserID {
+		return ErrCannotBlockSelf
+	}
+	if _, err := s.userRepo.GetByUserID(blockedUserID); err != nil {
+		return err
+	}
+	return s.userRepo.BlockUser(userID, blockedUserID)
 }
 
-// - MARK: Privates
+// UnblockUser lifts the user's block of another user.
+func (s *UserService) UnblockUser(userID int, blockedUserID int) error {
+	return s.userRepo.UnblockUser(userID, blockedUserID)
+}
 
-func (s *UserService) getUserFromDBAndCache(username string) (entity.User, string, error) {
-	// Cache miss, fetch user from the database
-	user, err := s.userRepo.GetByUserName(username)
-	if err != nil {
-		logger.LogError(fmt.Sprintf("Error when get user from db %v", err))
-		return entity.User{}, "", err
+// RequestProfileImageUpload returns a presigned URL the user uploads their avatar or cover image to.
+// The image replaces the current one once the upload is confirmed with ConfirmProfileImage.
+func (s *UserService) RequestProfileImageUpload(userID int, kind string) (string, string, error) {
+	if kind != entity.ProfileImageAvatar && kind != entity.ProfileImageCover {
+		return "", "", ErrInvalidProfileImageKind
 	}
 
-	// Cache the user data in Redis using a hash set
-	redisKey := fmt.Sprintf("user:%s", username)
-	userCacheData := map[string]interface{}{
-		"hashedPassword": user.HashedPassword,
-		"salt":           user.Salt,
+	objectKey := fmt.Sprintf("users/%d/%s/%d", userID, kind, time.Now().UnixNano())
+	uploadURL, err := s.storage.GenerateFileURL(objectKey)
+	if err != nil {
+		return "", "", err
 	}
 
-	err = s.redisClient.HSet(context.Background(), redisKey, userCacheData).Err()
+	err = s.redisClient.Set(context.Background(), profileImageUploadKey(userID, kind), objectKey, profileImageUploadTTL).Err()
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Error when caching user data in Redis: %v", err))
-		return user, "Could not cache user data", err
+		logger.LogError(fmt.Sprintf("Failed to store pending %s upload of user %d: %v", kind, userID, err))
+		return "", "", err
 	}
-
-	return user, "", nil
+	return uploadURL, objectKey, nil
 }
 
-func generateSalt() string {
-	salt := make([]byte, 16)
-	_, err := rand.Read(salt)
+// ConfirmProfileImage makes the user's pending upload their avatar or cover image, deletes the image it
+// replaces and returns the URL of the new one.
+func (s *UserService) ConfirmProfileImage(userID int, kind string) (string, error) {
+	if kind != entity.ProfileImageAvatar && kind != entity.ProfileImageCover {
+		return "", ErrInvalidProfileImageKind
+	}
+
+	ctx := context.Background()
+	uploadKey := profileImageUploadKey(userID, kind)
+	objectKey, err := s.redisClient.Get(ctx, uploadKey).Result()
 	if err != nil {
-		log.Fatalf("Failed to generate salt: %v", err)
+		if errors.Is(err, redis.Nil) {
+			return "", ErrProfileImageNotUploaded
+		}
+		return "", err
 	}
-	return base64.StdEncoding.EncodeToString(salt)
-}
 
-func hashPassword(password, salt string) string {
-	hash := sha256.New()
-	hash.Write([]byte(password + salt))
-	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+	fileInfo, err := s.storage.StatFile(objectKey)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		return "", ErrProfileImageNotUploaded
+	}
+	if err != nil {
+		return "", err
+	}
+	// The presigned URL accepts any upload, anything else than a small enough image is thrown away and
+	// has to be uploaded again
+	if !strings.HasPrefix(fileInfo.ContentType, "image/") || fileInfo.Size > maxProfileImageSize {
+		s.redisClient.Del(ctx, uploadKey)
+		if err := s.storage.DeleteFile(objectKey); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to delete rejected %s %s of user %d: %v", kind, objectKey, userID, err))
+		}
+		return "", fmt.Errorf(
+			"%w: got %q of %d bytes, expected an image of at most %d bytes",
+			ErrInvalidProfileImage, fileInfo.ContentType, fileInfo.Size, maxProfileImageSize,
+		)
+	}
+
+	previousKey, err := s.userRepo.UpdateProfileImage(userID, kind, objectKey)
+	if err != nil {
+		return "", err
+	}
+	s.redisClient.Del(ctx, uploadKey)
+	invalidateUserCache(s.redisClient, userID)
+	invalidateProfileCache(s.redisClient, userID)
+
+	if previousKey != "" && previousKey != objectKey {
+		go func() {
+			if err := s.storage.DeleteFile(previousKey); err != nil {
+				logger.LogError(fmt.Sprintf("Failed to delete replaced %s %s of user %d: %v", kind, previousKey, userID, err))
+			}
+		}()
+	}
+	return s.storage.GetFileURL(objectKey), nil
+}
+
+func profileImageUploadKey(userID int, kind string) string {
+	return fmt.Sprintf("profile_image_upload:%d:%s", userID, kind)
+}
+
+// profileImageURL resolves the object key of a profile image, users without one have an empty URL.
+func profileImageURL(fileStorage storage.MinioStorageInterface, objectKey string) string {
+	if objectKey == "" {
+		return ""
+	}
+	return fileStorage.GetFileURL(objectKey)
+}
+
+// resolveProfileImageURLs sets the avatar and cover URLs of the users from their object keys.
+func resolveProfileImageURLs(fileStorage storage.MinioStorageInterface, users []entity.User) {
+	for i := range users {
+		users[i].AvatarURL = profileImageURL(fileStorage, users[i].AvatarKey)
+		users[i].CoverURL = profileImageURL(fileStorage, users[i].CoverKey)
+	}
+}
+
+// invalidateUserCache drops the cached user summaries so they are reloaded with the latest fields.
+func invalidateUserCache(redisClient *redis.Client, userID int) {
+	err := redisClient.Del(
+		context.Background(), fmt.Sprintf("user:%d", userID), fmt.Sprintf("user-data:%d", userID),
+		emailVerifiedKey(userID),
+	).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to invalidate cache of user %d: %v", userID, err))
+	}
+}
+
+// invalidateProfileCache drops cached profiles whose stats or fields changed.
+func invalidateProfileCache(redisClient *redis.Client, userIDs ...int) {
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = fmt.Sprintf("profile:%d", userID)
+	}
+	err := redisClient.Del(context.Background(), keys...).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to invalidate profile cache for users %v: %v", userIDs, err))
+	}
+}
+
+// SearchUsers prefix-matches usernames, first names and last names. Users the searcher follows or
+// who follow the searcher are ranked first.
+func (s *UserService) SearchUsers(searcherID int, query string, limit int) ([]entity.UserSearchResult, error) {
+	prefix := strings.ToLower(strings.TrimSpace(query))
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: no search terms", ErrInvalidSearchQuery)
+	}
+	if utf8.RuneCountInString(prefix) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidSearchQuery, maxSearchQueryLength)
+	}
+	if limit <= 0 {
+		limit = defaultUserSearchLimit
+	}
+	limit = min(limit, maxUserSearchLimit)
+
+	// Fetch extra candidates so followed users further down the index can still be ranked first
+	candidateLimit := limit * 3
+
+	var userIDs []int
+	seen := make(map[int]bool)
+	members, err := s.redisClient.ZRangeByLex(
+		context.Background(), userAutocompleteKey, &redis.ZRangeBy{
+			Min:   "[" + prefix,
+			Max:   "[" + prefix + "\xff",
+			Count: int64(candidateLimit),
+		},
+	).Result()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to search autocomplete index: %v", err))
+	}
+	for _, member := range members {
+		separator := strings.LastIndexByte(member, 0)
+		userID, err := strconv.Atoi(member[separator+1:])
+		if separator < 0 || err != nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	users, err := s.GetUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users missing from the index, e.g. created before it existed, are found in MySQL
+	if len(users) < candidateLimit {
+		dbUsers, err := s.userRepo.SearchUsersByPrefix(prefix, candidateLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range dbUsers {
+			if !seen[user.ID] {
+				seen[user.ID] = true
+				users = append(users, user)
+				s.indexUserForAutocomplete(user)
+			}
+		}
+	}
+
+	candidateIDs := make([]int, len(users))
+	for i, user := range users {
+		candidateIDs[i] = user.ID
+	}
+	following, followers, err := s.userRepo.GetFollowRelations(searcherID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entity.UserSearchResult, len(users))
+	for i, user := range users {
+		results[i] = entity.UserSearchResult{
+			User: entity.User{
+				ID:        user.ID,
+				FirstName: user.FirstName,
+				LastName:  user.LastName,
+				Username:  user.Username,
+				AvatarURL: profileImageURL(s.storage, user.AvatarKey),
+			},
+			Following:  following[user.ID],
+			FollowedBy: followers[user.ID],
+		}
+	}
+	sort.SliceStable(
+		results, func(i, j int) bool {
+			iRelated := results[i].Following || results[i].FollowedBy
+			jRelated := results[j].Following || results[j].FollowedBy
+			if iRelated != jRelated {
+				return iRelated
+			}
+			// An exact username match beats other prefix matches
+			iExact := strings.ToLower(results[i].Username) == prefix
+			jExact := strings.ToLower(results[j].Username) == prefix
+			if iExact != jExact {
+				return iExact
+			}
+			return strings.ToLower(results[i].Username) < strings.ToLower(results[j].Username)
+		},
+	)
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// autocompleteMembers returns the autocomplete index members of a user, one per searchable name.
+func autocompleteMembers(user entity.User) []interface{} {
+	var members []interface{}
+	for _, name := range []string{user.Username, user.FirstName, user.LastName} {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			members = append(members, fmt.Sprintf("%s\x00%d", name, user.ID))
+		}
+	}
+	return members
+}
+
+func (s *UserService) indexUserForAutocomplete(user entity.User) {
+	members := autocompleteMembers(user)
+	if len(members) == 0 {
+		return
+	}
+	zs := make([]redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = redis.Z{Score: 0, Member: member}
+	}
+	err := s.redisClient.ZAdd(context.Background(), userAutocompleteKey, zs...).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to index user %d for autocomplete: %v", user.ID, err))
+	}
+}
+
+func (s *UserService) removeUserFromAutocomplete(user entity.User) {
+	members := autocompleteMembers(user)
+	if len(members) == 0 {
+		return
+	}
+	err := s.redisClient.ZRem(context.Background(), userAutocompleteKey, members...).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to remove user %d from autocomplete: %v", user.ID, err))
+	}
+}
+
+// InitializeBloomFilter initializes the Bloom filter using worker and batch processing
+func (s *UserService) InitializeBloomFilter() error {
+	// Get all usernames from the database
+	userNames, err := s.userRepo.GetAllUserNames()
+	if err != nil {
+		log.Printf("Error retrieving all usernames: %v", err)
+		return err
+	}
+
+	// Create a channel to send batches to workers
+	batchChan := make(chan []string, numWorkers)
+	done := make(chan struct{})
+
+	// Worker function
+	worker := func(id int, batchChan <-chan []string, done chan<- struct{}) {
+		for batch := range batchChan {
+			pipe := s.redisClient.Pipeline()
+			for _, username := range batch {
+				pipe.BFAdd(context.Background(), "users_bloom", username)
+			}
+			_, err := pipe.Exec(context.Background())
+			if err != nil {
+				logger.LogError(fmt.Sprintf("Worker %d: Error adding usernames to Bloom filter: %v", id, err))
+			}
+		}
+		done <- struct{}{}
+	}
+
+	// Start worker goroutines
+	for i := 0; i < numWorkers; i++ {
+		go worker(i, batchChan, done)
+	}
+
+	// Divide usernames into batches and send to workers
+	for i := 0; i < len(userNames); i += batchSize {
+		end := i + batchSize
+		if end > len(userNames) {
+			end = len(userNames)
+		}
+		batch := userNames[i:end]
+		batchChan <- batch
+	}
+	close(batchChan)
+
+	// Wait for all workers to complete
+	for i := 0; i < numWorkers; i++ {
+		<-done
+	}
+
+	logger.LogInfo("Successfully initialized Bloom filter with usernames")
+	return nil
+}
+
+func (s *UserService) PeriodicallyRefreshBloomFilter(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.InitializeBloomFilter()
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error refreshing Bloom filter: %v", err))
+		}
+	}
+}
+
+func (s *UserService) GetUsers(userIDs []int) ([]entity.User, error) {
+	ctx := context.Background()
+	var users []entity.User
+	var usersToFetchFromDB []int
+	cacheKeyPrefix := "user:" // Cache key prefix for each user
+
+	// Iterate over user IDs and try to fetch from cache
+	for _, userID := range userIDs {
+		userCacheKey := fmt.Sprintf("%s%d", cacheKeyPrefix, userID)
+		cachedUser, err := s.redisClient.HGetAll(ctx, userCacheKey).Result()
+
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error when fetching user data from Redis: %v", err))
+			return nil, err
+		}
+
+		if len(cachedUser) > 0 {
+			birthdayStr := cachedUser["birthday"]
+			birthday, err := time.Parse(time.RFC3339, birthdayStr)
+			if err != nil {
+				// Handle error (e.g., log it, or set a default value)
+				logger.LogError(fmt.Sprintf("Failed to parse birthday for user %d: %v", userID, err))
+				return nil, err
+			}
+			// If user exists in cache, map it to the entity.User struct
+			user := entity.User{
+				ID:        userID,
+				FirstName: cachedUser["first_name"],
+				LastName:  cachedUser["last_name"],
+				Birthday:  birthday, // You might need to parse the date depending on your date format
+				Email:     cachedUser["email"],
+				Username:  cachedUser["username"],
+				AvatarKey: cachedUser["avatar_key"],
+				CoverKey:  cachedUser["cover_key"],
+			}
+			users = append(users, user)
+		} else {
+			// If user is not in cache, add the ID to the list to fetch from DB
+			usersToFetchFromDB = append(usersToFetchFromDB, userID)
+		}
+	}
+
+	// If all users are found in cache, return them
+	if len(usersToFetchFromDB) == 0 {
+		resolveProfileImageURLs(s.storage, users)
+		return users, nil
+	}
+
+	// Fetch missing users from the database
+	dbUsers, err := s.userRepo.GetUsers(usersToFetchFromDB)
+	if err != nil {
+		return nil, err
+	}
+
+	users = append(users, dbUsers...)
+	resolveProfileImageURLs(s.storage, users)
+
+	// Update the cache for the users fetched from the database in a goroutine
+	go func(users []entity.User) {
+		for _, user := range dbUsers {
+			userCacheKey := fmt.Sprintf("%s%d", cacheKeyPrefix, user.ID)
+			userMap := map[string]interface{}{
+				"first_name": user.FirstName,
+				"last_name":  user.LastName,
+				"birthday":   user.Birthday.Format(time.RFC3339), // Format the time properly
+				"email":      user.Email,
+				"username":   user.Username,
+				"avatar_key": user.AvatarKey,
+				"cover_key":  user.CoverKey,
+			}
+			_, err := s.redisClient.HMSet(ctx, userCacheKey, userMap).Result()
+			if err != nil {
+				logger.LogError(fmt.Sprintf("Failed to cache user %d: %v", user.ID, err))
+			}
+			// Optionally, set expiration for the cached user (e.g., 24 hours)
+			s.redisClient.Expire(ctx, userCacheKey, 24*time.Hour)
+		}
+	}(dbUsers)
+
+	return users, nil
+}
+
+// RequestPasswordReset emails a single-use reset link to every account registered with the email. The
+// emails are sent in the background and unknown emails are not reported, so neither the response nor its
+// timing tells whether an account exists. Requests are limited per email and client IP address.
+func (s *UserService) RequestPasswordReset(email string, clientIP string) error {
+	email = strings.TrimSpace(email)
+	allowed, err := s.allowPasswordResetRequest(email, clientIP)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when counting password reset requests: %v", err))
+		return err
+	}
+	if !allowed {
+		return ErrTooManyResetRequests
+	}
+
+	go s.sendPasswordResets(email)
+	return nil
+}
+
+// allowPasswordResetRequest counts a reset request for the email and the IP address and reports whether
+// both are within their limit.
+func (s *UserService) allowPasswordResetRequest(email string, clientIP string) (bool, error) {
+	ctx := context.Background()
+	limits := map[string]int{
+		tokenKey("password_reset_requests:email", strings.ToLower(email)): s.passwordResetMaxPerEmail,
+	}
+	if clientIP != "" {
+		limits["password_reset_requests:ip:"+clientIP] = s.passwordResetMaxPerIP
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	counts := map[string]*redis.IntCmd{}
+	for key := range limits {
+		counts[key] = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, passwordResetRequestWindow)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	for key, limit := range limits {
+		if limit > 0 && int(counts[key].Val()) > limit {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sendPasswordResets sends the reset emails of a request, failures are only logged since the request was
+// already answered.
+func (s *UserService) sendPasswordResets(email string) {
+	users, err := s.userRepo.GetAllByEmail(email)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to look up accounts for password reset: %v", err))
+		return
+	}
+	if len(users) == 0 {
+		logger.LogInfo("Password reset requested for an unknown email")
+		return
+	}
+	for _, user := range users {
+		if err := s.sendPasswordReset(user); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to send password reset email to user %d: %v", user.ID, err))
+		}
+	}
+}
+
+func (s *UserService) sendPasswordReset(user entity.User) error {
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	// Only the hash of the token is stored, a leaked Redis snapshot cannot be used to reset passwords. The
+	// user's tokens are remembered so the others can be dropped once one of them was used.
+	ctx := context.Background()
+	key := tokenKey("password_reset", token)
+	userResetsKey := passwordResetsKey(user.ID)
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, user.ID, s.passwordResetTTL)
+			pipe.SAdd(ctx, userResetsKey, key)
+			pipe.Expire(ctx, userResetsKey, s.passwordResetTTL)
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not store password reset token: %v", err)
+	}
+
+	return s.mailer.Send(
+		ctx, mailer.Message{
+			To:      user.Email,
+			Subject: "Reset your password",
+			Body: fmt.Sprintf(
+				"Hi %s,\n\n"+
+					"Use the link below to reset the password of your account %s. It expires in %d minutes and can only be used once.\n\n"+
+					"%s?token=%s\n\n"+
+					"If you did not ask to reset your password, you can ignore this email.\n",
+				user.FirstName, user.Username, int(s.passwordResetTTL.Minutes()), s.passwordResetURL, token,
+			),
+		},
+	)
+}
+
+// ResetPassword sets a new password for the user the token was issued to and signs them out everywhere,
+// including their API keys and any other reset links.
+func (s *UserService) ResetPassword(token string, newPassword string) error {
+	if newPassword == "" {
+		return fmt.Errorf("%w: password is required", ErrInvalidProfile)
+	}
+
+	// GETDEL makes the token single-use even when two resets race
+	ctx := context.Background()
+	userID, err := s.redisClient.GetDel(ctx, tokenKey("password_reset", token)).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := password.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	err = s.userRepo.UpdateUser(
+		entity.User{ID: userID, HashedPassword: hashedPassword}, []string{entity.ProfileFieldPassword},
+	)
+	if err != nil {
+		return err
+	}
+
+	// The login cache holds the old password hash
+	s.redisClient.Del(ctx, fmt.Sprintf("user:%s", user.Username))
+	if err := s.deletePasswordResetTokens(userID); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to delete password reset tokens of user %d: %v", userID, err))
+		return err
+	}
+	if err := s.sessions.DeleteAll(userID); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to revoke sessions of user %d: %v", userID, err))
+		return err
+	}
+	if err := s.apiKeys.DeleteAll(userID); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to revoke API keys of user %d: %v", userID, err))
+		return err
+	}
+	return nil
+}
+
+// deletePasswordResetTokens invalidates the reset links the user was sent.
+func (s *UserService) deletePasswordResetTokens(userID int) error {
+	ctx := context.Background()
+	keys, err := s.redisClient.SMembers(ctx, passwordResetsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	return s.redisClient.Del(ctx, append(keys, passwordResetsKey(userID))...).Err()
+}
+
+func passwordResetsKey(userID int) string {
+	return fmt.Sprintf("user_password_resets:%d", userID)
+}
+
+// VerifyEmail marks the email the token was sent to as verified.
+func (s *UserService) VerifyEmail(token string) error {
+	// GETDEL makes the token single-use
+	ctx := context.Background()
+	value, err := s.redisClient.GetDel(ctx, tokenKey("email_verification", token)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrInvalidVerificationToken
+		}
+		return err
+	}
+
+	// The value is "<user id>:<email>" so a token sent before an email change cannot verify the new email
+	userIDStr, email, found := strings.Cut(value, ":")
+	userID, err := strconv.Atoi(userIDStr)
+	if !found || err != nil {
+		return ErrInvalidVerificationToken
+	}
+	verified, err := s.userRepo.MarkEmailVerified(userID, email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return ErrInvalidVerificationToken
+	}
+	invalidateUserCache(s.redisClient, userID)
+	return nil
+}
+
+// ResendVerification sends a new verification email to the user's current email.
+func (s *UserService) ResendVerification(userID int) error {
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	resendKey := fmt.Sprintf("email_verification_resend:%d", userID)
+	allowed, err := s.redisClient.SetNX(context.Background(), resendKey, 1, verificationResendInterval).Result()
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrVerificationRecentlySent
+	}
+	return s.sendEmailVerification(user)
+}
+
+// IsEmailVerified reports whether the user verified their current email. It is checked on every post and
+// comment, so the answer is cached until the email is verified or changed.
+func (s *UserService) IsEmailVerified(userID int) (bool, error) {
+	ctx := context.Background()
+	cached, err := s.redisClient.Get(ctx, emailVerifiedKey(userID)).Result()
+	if err == nil {
+		return cached == "1", nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		logger.LogError(fmt.Sprintf("Error when getting email verification of user %d from Redis: %v", userID, err))
+	}
+
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	value := "0"
+	if user.EmailVerified {
+		value = "1"
+	}
+	if err := s.redisClient.Set(ctx, emailVerifiedKey(userID), value, emailVerifiedCacheTTL).Err(); err != nil {
+		logger.LogError(fmt.Sprintf("Error when caching email verification of user %d: %v", userID, err))
+	}
+	return user.EmailVerified, nil
+}
+
+func emailVerifiedKey(userID int) string {
+	return fmt.Sprintf("email_verified:%d", userID)
+}
+
+func (s *UserService) sendEmailVerification(user entity.User) error {
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	value := fmt.Sprintf("%d:%s", user.ID, user.Email)
+	err = s.redisClient.Set(context.Background(), tokenKey("email_verification", token), value, s.emailVerificationTTL).Err()
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(
+		context.Background(), mailer.Message{
+			To:      user.Email,
+			Subject: "Verify your email",
+			Body: fmt.Sprintf(
+				"Hi %s,\n\n"+
+					"Use the link below to verify your email. It expires in %d hours.\n\n"+
+					"%s?token=%s\n",
+				user.FirstName, int(s.emailVerificationTTL.Hours()), s.emailVerificationURL, token,
+			),
+		},
+	)
+}
+
+func validateEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("%w: invalid email", ErrInvalidProfile)
+	}
+	return nil
+}
+
+// EnrollTOTP starts two-factor enrollment with a new secret and returns the otpauth URI to add it to an
+// authenticator app. Enrolling again replaces a secret that was not activated yet.
+func (s *UserService) EnrollTOTP(userID int) (string, string, error) {
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TOTPEnabled {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.userRepo.SetTOTPSecret(userID, secret); err != nil {
+		return "", "", err
+	}
+	return totp.URI(s.totpIssuer, user.Username, secret), secret, nil
+}
+
+// ActivateTOTP turns on two-factor authentication once the user proves the authenticator app works with a
+// code. It returns the recovery codes, they are only stored hashed and cannot be shown again.
+func (s *UserService) ActivateTOTP(userID int, code string) ([]string, error) {
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	valid, err := s.validateTOTPCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	recoveryCodeHashes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i], err = newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodeHashes[i] = hashRecoveryCode(recoveryCodes[i])
+	}
+
+	enabled, err := s.userRepo.EnableTOTP(userID, recoveryCodeHashes)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	// Logins read whether two-factor authentication is enabled from the login cache
+	s.redisClient.Del(context.Background(), fmt.Sprintf("user:%s", user.Username))
+	return recoveryCodes, nil
+}
+
+// VerifyTwoFactor completes a login of a user with two-factor authentication. The code is a TOTP code or
+// one of the recovery codes, each recovery code works once. Wrong codes count as failed logins of the
+// username and client IP address of the login, so codes cannot be guessed by starting more logins; errors
+// for locked out attempts are a *LoginThrottleError.
+func (s *UserService) VerifyTwoFactor(twoFactorToken string, code string) (*entity.TokenPair, error) {
+	ctx := context.Background()
+	key := tokenKey("two_factor_pending", twoFactorToken)
+	pending, err := s.redisClient.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, ErrInvalidTwoFactorToken
+	}
+	username, clientIP := pending["username"], pending["client_ip"]
+
+	throttle, err := s.loginLimiter.Check(username, clientIP)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when checking login attempts: %v", err))
+	}
+	if throttle != nil {
+		s.redisClient.Del(ctx, key)
+		return nil, throttle
+	}
+
+	attempts, err := s.redisClient.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if attempts > maxTwoFactorAttempts {
+		s.redisClient.Del(ctx, key)
+		return nil, ErrInvalidTwoFactorToken
+	}
+
+	userID, err := strconv.Atoi(pending["user_id"])
+	if err != nil {
+		return nil, ErrInvalidTwoFactorToken
+	}
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var valid bool
+	if isRecoveryCode(code) {
+		valid, err = s.userRepo.UseRecoveryCode(userID, hashRecoveryCode(code))
+	} else {
+		valid, err = s.validateTOTPCode(user, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		throttle, err := s.loginLimiter.RecordFailure(username, clientIP)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error when recording failed two-factor code: %v", err))
+			return nil, ErrInvalidTwoFactorCode
+		}
+		if errors.Is(throttle, ErrLoginLocked) {
+			// The password has to be entered again once the lockout is over
+			s.redisClient.Del(ctx, key)
+			return nil, throttle
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	// The pending login is single use, a concurrent verification of it may have won
+	deleted, err := s.redisClient.Del(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, ErrInvalidTwoFactorToken
+	}
+	if err := s.loginLimiter.Reset(username); err != nil {
+		logger.LogError(fmt.Sprintf("Error when resetting login attempts: %v", err))
+	}
+	return s.createSession(userID, username, pending["device"], pending["user_agent"])
+}
+
+// startTwoFactorLogin remembers a login whose password was verified and returns the token that is
+// exchanged for a session with VerifyTwoFactor.
+func (s *UserService) startTwoFactorLogin(userID int, username string, device string, userAgent string, clientIP string) (*entity.TokenPair, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	key := tokenKey("two_factor_pending", token)
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(
+				ctx, key, map[string]interface{}{
+					"user_id":    userID,
+					"username":   username,
+					"device":     device,
+					"user_agent": userAgent,
+					"client_ip":  clientIP,
+				},
+			)
+			pipe.Expire(ctx, key, s.twoFactorTokenTTL)
+			return nil
+		},
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when storing pending two-factor login: %v", err))
+		return nil, err
+	}
+	return &entity.TokenPair{TwoFactorToken: token}, nil
+}
+
+// validateTOTPCode checks a TOTP code against the user's secret, a code is rejected once it was used.
+func (s *UserService) validateTOTPCode(user entity.User, code string) (bool, error) {
+	valid, counter, err := s.totp.Validate(user.TOTPSecret, code)
+	if err != nil || !valid {
+		return false, err
+	}
+	usedKey := fmt.Sprintf("totp_used:%d:%d", user.ID, counter)
+	return s.redisClient.SetNX(context.Background(), usedKey, 1, usedTOTPCodeTTL).Result()
+}
+
+// newRecoveryCode returns a random recovery code formatted as "xxxx-xxxx".
+func newRecoveryCode() (string, error) {
+	codeBytes := make([]byte, 5)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("could not generate recovery code: %v", err)
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(codeBytes))
+	return code[:4] + "-" + code[4:], nil
+}
+
+// isRecoveryCode tells recovery codes from TOTP codes, which are only digits.
+func isRecoveryCode(code string) bool {
+	return strings.ContainsFunc(code, unicode.IsLetter) || strings.Contains(code, "-")
+}
+
+// hashRecoveryCode returns the hex SHA-256 of a recovery code, ignoring case and separators.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// StartOIDCLogin starts a login with the identity provider and returns the URL to redirect the user to,
+// and the state the browser has to present again when it comes back. The state, nonce and PKCE code
+// verifier of the login are kept in Redis until it is completed.
+func (s *UserService) StartOIDCLogin(device string, userAgent string) (string, string, error) {
+	if s.oidcProvider == nil {
+		return "", "", ErrOIDCNotConfigured
+	}
+	state, err := oidc.NewState()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.NewState()
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	ctx := context.Background()
+	key := tokenKey("oidc_login", state)
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(
+				ctx, key, map[string]interface{}{
+					"nonce":         nonce,
+					"code_verifier": codeVerifier,
+					"device":        device,
+					"user_agent":    userAgent,
+				},
+			)
+			pipe.Expire(ctx, key, s.oidcStateTTL)
+			return nil
+		},
+	)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when storing OIDC login state: %v", err))
+		return "", "", err
+	}
+	return s.oidcProvider.AuthCodeURL(state, nonce, codeVerifier), state, nil
+}
+
+// CompleteOIDCLogin redeems the authorization code the provider redirected back with and signs the user
+// in. An identity seen for the first time is linked to the account with its verified email, or signed up
+// as a new account when there is none. Accounts with two-factor authentication still need a code.
+func (s *UserService) CompleteOIDCLogin(state string, code string) (*entity.TokenPair, error) {
+	if s.oidcProvider == nil {
+		return nil, ErrOIDCNotConfigured
+	}
+
+	// The state is single use, a concurrent completion of the same login may have won
+	ctx := context.Background()
+	key := tokenKey("oidc_login", state)
+	pending, err := s.redisClient.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, ErrInvalidOIDCState
+	}
+	deleted, err := s.redisClient.Del(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, ErrInvalidOIDCState
+	}
+
+	rawIDToken, err := s.oidcProvider.Exchange(ctx, code, pending["code_verifier"])
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when exchanging OIDC authorization code: %v", err))
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+	claims, err := s.oidcProvider.VerifyIDToken(ctx, rawIDToken, pending["nonce"])
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when verifying OIDC ID token: %v", err))
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+
+	userID, err := s.userRepo.GetUserIDByIdentity(s.oidcProvider.Issuer(), claims.Subject)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		userID, err = s.linkOrCreateOIDCUser(claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return s.startTwoFactorLogin(user.ID, user.Username, pending["device"], pending["user_agent"], "")
+	}
+	return s.createSession(user.ID, user.Username, pending["device"], pending["user_agent"])
+}
+
+// linkOrCreateOIDCUser links a new identity to the account registered with its email, or signs it up as a
+// new account with a verified email and an unusable random password.
+func (s *UserService) linkOrCreateOIDCUser(claims *oidc.Claims) (int, error) {
+	issuer := s.oidcProvider.Issuer()
+	if claims.Email == "" || !claims.EmailVerified {
+		return 0, ErrOIDCEmailNotVerified
+	}
+
+	existing, err := s.userRepo.GetByEmail(claims.Email)
+	if err == nil {
+		if !existing.EmailVerified {
+			return 0, ErrOIDCEmailInUse
+		}
+		if err := s.userRepo.LinkIdentity(existing.ID, issuer, claims.Subject); err != nil {
+			return 0, err
+		}
+		return existing.ID, nil
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return 0, err
+	}
+
+	randomPassword, err := newToken()
+	if err != nil {
+		return 0, err
+	}
+	hashedPassword, err := password.Hash(randomPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	user := entity.User{
+		FirstName:      claims.GivenName,
+		LastName:       claims.FamilyName,
+		Email:          claims.Email,
+		EmailVerified:  true,
+		HashedPassword: hashedPassword,
+	}
+	if user.FirstName == "" {
+		user.FirstName = claims.Name
+	}
+
+	// The unique username decides between concurrent sign ups, a taken one is retried with a suffix
+	base := oidcUsernameBase(claims)
+	for i := 0; i <= oidcUsernameSuffixTries; i++ {
+		user.Username, err = oidcUsernameCandidate(base, i)
+		if err != nil {
+			return 0, err
+		}
+		user.ID, err = s.userRepo.CreateUserWithIdentity(user, issuer, claims.Subject)
+		if !errors.Is(err, repository.ErrUsernameTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.indexUserForAutocomplete(user)
+	err = s.redisClient.BFAdd(context.Background(), "users_bloom", user.Username).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when add user to bloom filter: %s", err.Error()))
+	}
+	return user.ID, nil
+}
+
+// oidcUsernameBase derives a username from the identity's preferred username or email.
+func oidcUsernameBase(claims *oidc.Claims) string {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = strings.Map(
+		func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '.' {
+				return r
+			}
+			return -1
+		}, strings.ToLower(base),
+	)
+	if base == "" {
+		base = "user"
+	}
+	return base[:min(len(base), maxOIDCUsernameLength-4)]
+}
+
+// oidcUsernameCandidate returns the username to try for a sign up, the base itself first and then the
+// base with a random number appended.
+func oidcUsernameCandidate(base string, attempt int) (string, error) {
+	if attempt == 0 {
+		return base, nil
+	}
+	suffix := make([]byte, 2)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%04d", base, (int(suffix[0])<<8|int(suffix[1]))%10000), nil
+}
+
+// CreateAPIKey issues a personal API key for tools and bots acting as the user. Keys with the post scope
+// can read too. The key is returned in the APIKey's Key and cannot be shown again.
+func (s *UserService) CreateAPIKey(userID int, name string, scope string, expiresInDays int) (*entity.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidAPIKeyRequest, maxAPIKeyNameLength)
+	}
+	var scopes []string
+	switch scope {
+	case auth.ScopeRead:
+		scopes = []string{auth.ScopeRead}
+	case auth.ScopePost:
+		scopes = []string{auth.ScopeRead, auth.ScopePost}
+	default:
+		return nil, fmt.Errorf("%w: scope must be %q or %q", ErrInvalidAPIKeyRequest, auth.ScopeRead, auth.ScopePost)
+	}
+	ttl := time.Duration(expiresInDays) * 24 * time.Hour
+	if expiresInDays == 0 {
+		ttl = s.apiKeyDefaultTTL
+	}
+	if ttl <= 0 || ttl > s.apiKeyMaxTTL {
+		return nil, fmt.Errorf(
+			"%w: lifetime must be 1 to %d days", ErrInvalidAPIKeyRequest, int(s.apiKeyMaxTTL.Hours()/24),
+		)
+	}
+
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := s.apiKeys.Create(userID, user.Username, name, scopes, time.Now().Add(ttl), maxAPIKeysPerUser)
+	if errors.Is(err, apikey.ErrKeyLimitReached) {
+		return nil, ErrTooManyAPIKeys
+	}
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when creating API key: %v", err))
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+// ListAPIKeys returns the user's active API keys.
+func (s *UserService) ListAPIKeys(userID int) ([]entity.APIKey, error) {
+	return s.apiKeys.List(userID)
+}
+
+// RevokeAPIKey revokes one of the user's API keys, it stops working immediately.
+func (s *UserService) RevokeAPIKey(userID int, keyID string) error {
+	return s.apiKeys.Delete(userID, keyID)
+}
+
+// - MARK: Privates
+
+// newToken returns a random URL-safe token for links sent by email.
+func newToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("could not generate token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// tokenKey is the Redis key of an emailed token, only its hash is stored.
+func tokenKey(prefix string, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return prefix + ":" + hex.EncodeToString(hash[:])
 }
 
-func verifyPassword(password, hashedPassword, salt string) bool {
-	return hashPassword(password, salt) == hashedPassword
+// rehashPassword replaces a legacy or outdated password hash with one using the current algorithm and
+// parameters. Failures are logged, the old hash keeps working until the next login.
+func (s *UserService) rehashPassword(username, plainPassword, oldHashedPassword string) {
+	hashedPassword, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to rehash password of user %s: %v", username, err))
+		return
+	}
+
+	err = s.userRepo.UpdatePasswordHash(username, oldHashedPassword, hashedPassword, "")
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to store rehashed password of user %s: %v", username, err))
+		return
+	}
+
+	err = s.redisClient.HSet(
+		context.Background(), fmt.Sprintf("user:%s", username), map[string]interface{}{
+			"hashedPassword": hashedPassword,
+			"salt":           "",
+		},
+	).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to cache rehashed password of user %s: %v", username, err))
+	}
+}
+
+func (s *UserService) getUserFromDBAndCache(username string) (entity.User, string, error) {
+	// Cache miss, fetch user from the database
+	user, err := s.userRepo.GetByUserName(username)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when get user from db %v", err))
+		return entity.User{}, "", err
+	}
+
+	// Cache the user data in Redis using a hash set
+	redisKey := fmt.Sprintf("user:%s", username)
+	userCacheData := map[string]interface{}{
+		"id":             user.ID,
+		"hashedPassword": user.HashedPassword,
+		"salt":           user.Salt,
+		"totpEnabled":    strconv.FormatBool(user.TOTPEnabled),
+	}
+
+	err = s.redisClient.HSet(context.Background(), redisKey, userCacheData).Err()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Error when caching user data in Redis: %v", err))
+		return user, "Could not cache user data", err
+	}
+
+	return user, "", nil
 }
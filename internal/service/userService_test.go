@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"news-feed/internal/entity"
+	"news-feed/internal/repository"
+	"news-feed/internal/session"
+	"news-feed/pkg/oidc"
+	"news-feed/pkg/totp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryUserRepo keeps users in memory, the methods the tests do not need panic through the nil embedded
+// interface.
+type memoryUserRepo struct {
+	repository.UserRepositoryInterface
+	users         map[int]entity.User
+	recoveryCodes map[int]map[string]bool // Unused recovery code hashes per user
+	identities    map[string]int          // User IDs by issuer and subject
+}
+
+func newMemoryUserRepo(users ...entity.User) *memoryUserRepo {
+	repo := &memoryUserRepo{
+		users:         map[int]entity.User{},
+		recoveryCodes: map[int]map[string]bool{},
+		identities:    map[string]int{},
+	}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+// GetByEmail returns the oldest account with the email, like the MySQL repository.
+func (r *memoryUserRepo) GetByEmail(email string) (entity.User, error) {
+	var found *entity.User
+	for _, user := range r.users {
+		if user.Email == email && (found == nil || user.ID < found.ID) {
+			found = &user
+		}
+	}
+	if found == nil {
+		return entity.User{}, repository.ErrUserNotFound
+	}
+	return *found, nil
+}
+
+func (r *memoryUserRepo) GetUserIDByIdentity(issuer string, subject string) (int, error) {
+	userID, ok := r.identities[issuer+" "+subject]
+	if !ok {
+		return 0, repository.ErrUserNotFound
+	}
+	return userID, nil
+}
+
+func (r *memoryUserRepo) LinkIdentity(userID int, issuer string, subject string) error {
+	r.identities[issuer+" "+subject] = userID
+	return nil
+}
+
+func (r *memoryUserRepo) CreateUserWithIdentity(user entity.User, issuer string, subject string) (int, error) {
+	for _, existing := range r.users {
+		if existing.Username == user.Username {
+			return 0, repository.ErrUsernameTaken
+		}
+	}
+	user.ID = len(r.users) + 1
+	r.users[user.ID] = user
+	r.identities[issuer+" "+subject] = user.ID
+	return user.ID, nil
+}
+
+func (r *memoryUserRepo) GetByUserID(userID int) (entity.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return entity.User{}, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *memoryUserRepo) SetTOTPSecret(userID int, secret string) error {
+	user := r.users[userID]
+	user.TOTPSecret = secret
+	r.users[userID] = user
+	return nil
+}
+
+func (r *memoryUserRepo) EnableTOTP(userID int, recoveryCodeHashes []string) (bool, error) {
+	user := r.users[userID]
+	if user.TOTPEnabled {
+		return false, nil
+	}
+	user.TOTPEnabled = true
+	r.users[userID] = user
+	r.recoveryCodes[userID] = map[string]bool{}
+	for _, hash := range recoveryCodeHashes {
+		r.recoveryCodes[userID][hash] = true
+	}
+	return true, nil
+}
+
+func (r *memoryUserRepo) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	if !r.recoveryCodes[userID][codeHash] {
+		return false, nil
+	}
+	delete(r.recoveryCodes[userID], codeHash)
+	return true, nil
+}
+
+// testClock is a settable time source for TOTP validation.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// newTestUserService returns a UserService backed by the repository and an in-memory Redis. Three wrong
+// logins or codes lock a username out.
+func newTestUserService(t *testing.T, repo repository.UserRepositoryInterface, clock *testClock) *UserService {
+	t.Helper()
+	redisClient := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+	return &UserService{
+		userRepo: repo,
+		sessions: session.NewStore(redisClient, time.Hour),
+		loginLimiter: NewLoginLimiter(
+			redisClient, LoginLimits{
+				MaxFailuresPerUsername: 3,
+				MaxFailuresPerIP:       100,
+				CaptchaAfterFailures:   100,
+				FailureWindow:          time.Hour,
+				BaseLockout:            time.Minute,
+				MaxLockout:             time.Hour,
+			},
+		),
+		totp:              totp.New(clock.Now),
+		redisClient:       redisClient,
+		totpIssuer:        "news-feed",
+		twoFactorTokenTTL: 5 * time.Minute,
+	}
+}
+
+// enableTOTP enrolls and activates two-factor authentication for the user and returns its secret and
+// recovery codes. The clock is moved to the next period so the activation code is not reused.
+func enableTOTP(t *testing.T, s *UserService, clock *testClock, userID int) (string, []string) {
+	t.Helper()
+	_, secret, err := s.EnrollTOTP(userID)
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	recoveryCodes, err := s.ActivateTOTP(userID, totpCode(t, secret, clock.now))
+	if err != nil {
+		t.Fatalf("ActivateTOTP() error = %v", err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	return secret, recoveryCodes
+}
+
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.Code(secret, at)
+	if err != nil {
+		t.Fatalf("totp.Code() error = %v", err)
+	}
+	return code
+}
+
+func startTestLogin(t *testing.T, s *UserService, user entity.User) string {
+	t.Helper()
+	pending, err := s.startTwoFactorLogin(user.ID, user.Username, "test", "go-test", "192.0.2.1")
+	if err != nil {
+		t.Fatalf("startTwoFactorLogin() error = %v", err)
+	}
+	return pending.TwoFactorToken
+}
+
+func TestActivateTOTP(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 10, 0, time.UTC)}
+	user := entity.User{ID: 1, Username: "alice"}
+	s := newTestUserService(t, newMemoryUserRepo(user), clock)
+
+	if _, err := s.ActivateTOTP(user.ID, "123456"); !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		t.Fatalf("ActivateTOTP() before enrolling: error = %v, want %v", err, ErrTwoFactorNotEnrolled)
+	}
+	_, secret, err := s.EnrollTOTP(user.ID)
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	if _, err := s.ActivateTOTP(user.ID, totpCode(t, secret, clock.now.Add(-2*time.Minute))); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("ActivateTOTP() with an old code: error = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+
+	recoveryCodes, err := s.ActivateTOTP(user.ID, totpCode(t, secret, clock.now))
+	if err != nil {
+		t.Fatalf("ActivateTOTP() error = %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Fatalf("got %d recovery codes, want %d", len(recoveryCodes), recoveryCodeCount)
+	}
+	for _, code := range recoveryCodes {
+		if !isRecoveryCode(code) {
+			t.Fatalf("recovery code %q would be taken for a TOTP code", code)
+		}
+	}
+	if _, _, err := s.EnrollTOTP(user.ID); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Fatalf("EnrollTOTP() once enabled: error = %v, want %v", err, ErrTwoFactorAlreadyEnabled)
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 10, 0, time.UTC)}
+	user := entity.User{ID: 1, Username: "alice"}
+	repo := newMemoryUserRepo(user)
+	s := newTestUserService(t, repo, clock)
+	enableTOTP(t, s, clock, user.ID)
+	user = repo.users[user.ID]
+
+	tests := []struct {
+		name      string
+		codeTime  time.Time
+		wantValid bool
+	}{
+		{"current period", clock.now, true},
+		{"current period replayed", clock.now, false},
+		{"previous period for clock drift", clock.now.Add(-30 * time.Second), true},
+		{"previous period replayed", clock.now.Add(-30 * time.Second), false},
+		{"next period for clock drift", clock.now.Add(30 * time.Second), true},
+		{"two periods ago", clock.now.Add(-time.Minute), false},
+		{"two periods ahead", clock.now.Add(time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := s.validateTOTPCode(user, totpCode(t, user.TOTPSecret, tt.codeTime))
+			if err != nil {
+				t.Fatalf("validateTOTPCode() error = %v", err)
+			}
+			if valid != tt.wantValid {
+				t.Fatalf("validateTOTPCode() = %v, want %v", valid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyTwoFactorRejectsReplayedTOTPCode(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 10, 0, time.UTC)}
+	user := entity.User{ID: 1, Username: "alice"}
+	s := newTestUserService(t, newMemoryUserRepo(user), clock)
+	secret, _ := enableTOTP(t, s, clock, user.ID)
+	code := totpCode(t, secret, clock.now)
+
+	tokens, err := s.VerifyTwoFactor(startTestLogin(t, s, user), code)
+	if err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Fatalf("VerifyTwoFactor() = %+v, want a token pair", tokens)
+	}
+
+	// Someone who saw the code starts another login with the password
+	if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("VerifyTwoFactor() with a used code: error = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+}
+
+func TestVerifyTwoFactorRecoveryCodes(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 10, 0, time.UTC)}
+	user := entity.User{ID: 1, Username: "alice"}
+	s := newTestUserService(t, newMemoryUserRepo(user), clock)
+	_, recoveryCodes := enableTOTP(t, s, clock, user.ID)
+
+	// Recovery codes are accepted regardless of case and separator
+	typed := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), typed); err != nil {
+		t.Fatalf("VerifyTwoFactor() with a recovery code: error = %v", err)
+	}
+	if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), recoveryCodes[0]); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("VerifyTwoFactor() with a used recovery code: error = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+	if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), recoveryCodes[1]); err != nil {
+		t.Fatalf("VerifyTwoFactor() with another recovery code: error = %v", err)
+	}
+}
+
+func TestVerifyTwoFactorWrongCodesLockOut(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 10, 0, time.UTC)}
+	user := entity.User{ID: 1, Username: "alice"}
+	s := newTestUserService(t, newMemoryUserRepo(user), clock)
+	secret, _ := enableTOTP(t, s, clock, user.ID)
+
+	// Each wrong code counts as a failed login, even across pending logins
+	for i := 0; i < 2; i++ {
+		if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), "wrong-code"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("wrong code %d: error = %v, want %v", i+1, err, ErrInvalidTwoFactorCode)
+		}
+	}
+	token := startTestLogin(t, s, user)
+	if _, err := s.VerifyTwoFactor(token, "000000"); !errors.Is(err, ErrLoginLocked) {
+		t.Fatalf("third wrong code: error = %v, want %v", err, ErrLoginLocked)
+	}
+
+	// The pending login is dropped and a correct code does not get past the lockout
+	if _, err := s.VerifyTwoFactor(token, totpCode(t, secret, clock.now)); !errors.Is(err, ErrInvalidTwoFactorToken) {
+		t.Fatalf("VerifyTwoFactor() of the dropped login: error = %v, want %v", err, ErrInvalidTwoFactorToken)
+	}
+	if _, err := s.VerifyTwoFactor(startTestLogin(t, s, user), totpCode(t, secret, clock.now)); !errors.Is(err, ErrLoginLocked) {
+		t.Fatalf("VerifyTwoFactor() while locked out: error = %v, want %v", err, ErrLoginLocked)
+	}
+}
+
+const testOIDCIssuer = "https://idp.example.com"
+
+func TestCompleteOIDCLoginState(t *testing.T) {
+	tokenEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+	}))
+	defer tokenEndpoint.Close()
+
+	s := newTestUserService(t, newMemoryUserRepo(), &testClock{now: time.Now()})
+	s.oidcProvider = oidc.NewProvider(
+		oidc.Config{
+			Issuer:                testOIDCIssuer,
+			ClientID:              "news-feed",
+			AuthorizationEndpoint: testOIDCIssuer + "/authorize",
+			TokenEndpoint:         tokenEndpoint.URL,
+		},
+		tokenEndpoint.Client(),
+	)
+	s.oidcStateTTL = 10 * time.Minute
+
+	if _, err := s.CompleteOIDCLogin("unknown-state", "code"); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Fatalf("CompleteOIDCLogin() with an unknown state: error = %v, want %v", err, ErrInvalidOIDCState)
+	}
+
+	authorizationURL, state, err := s.StartOIDCLogin("test", "go-test")
+	if err != nil {
+		t.Fatalf("StartOIDCLogin() error = %v", err)
+	}
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatalf("StartOIDCLogin() URL: %v", err)
+	}
+	query := parsed.Query()
+	pending, err := s.redisClient.HGetAll(context.Background(), tokenKey("oidc_login", state)).Result()
+	if err != nil {
+		t.Fatalf("could not read the pending login: %v", err)
+	}
+	if query.Get("state") != state || query.Get("nonce") != pending["nonce"] || query.Get("nonce") == "" {
+		t.Fatalf("authorization URL %s does not carry the state and nonce of the login", authorizationURL)
+	}
+	if query.Get("code_challenge") != oidc.CodeChallenge(pending["code_verifier"]) || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization URL %s does not carry the challenge of the stored code verifier", authorizationURL)
+	}
+
+	// A login fails with the provider's refusal once, its state cannot be tried again
+	if _, err := s.CompleteOIDCLogin(state, "code"); !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Fatalf("CompleteOIDCLogin() error = %v, want %v", err, ErrOIDCLoginFailed)
+	}
+	if _, err := s.CompleteOIDCLogin(state, "code"); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Fatalf("CompleteOIDCLogin() with a used state: error = %v, want %v", err, ErrInvalidOIDCState)
+	}
+}
+
+func TestLinkOrCreateOIDCUser(t *testing.T) {
+	verified := entity.User{ID: 1, Username: "alice", Email: "alice@example.com", EmailVerified: true}
+	unverified := entity.User{ID: 2, Username: "bob", Email: "bob@example.com"}
+	// Accounts created before email verification existed were never verified
+	legacy := entity.User{ID: 3, Username: "carol", Email: "carol@example.com"}
+	legacyReregistered := entity.User{ID: 4, Username: "carol2", Email: "carol@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name        string
+		claims      oidc.Claims
+		wantErr     error
+		wantUserID  int  // Zero for a new account
+		wantCreated bool // Whether a new account is signed up
+	}{
+		{
+			name:    "email not verified by the provider",
+			claims:  oidc.Claims{Subject: "s1", Email: "alice@example.com"},
+			wantErr: ErrOIDCEmailNotVerified,
+		},
+		{
+			name:    "no email",
+			claims:  oidc.Claims{Subject: "s1", EmailVerified: true},
+			wantErr: ErrOIDCEmailNotVerified,
+		},
+		{
+			name:       "verified account is linked",
+			claims:     oidc.Claims{Subject: "s1", Email: "alice@example.com", EmailVerified: true},
+			wantUserID: verified.ID,
+		},
+		{
+			name:    "unverified account is not linked",
+			claims:  oidc.Claims{Subject: "s1", Email: "bob@example.com", EmailVerified: true},
+			wantErr: ErrOIDCEmailInUse,
+		},
+		{
+			name:    "legacy account is not linked, even next to a verified one",
+			claims:  oidc.Claims{Subject: "s1", Email: "carol@example.com", EmailVerified: true},
+			wantErr: ErrOIDCEmailInUse,
+		},
+		{
+			name:        "new email signs up",
+			claims:      oidc.Claims{Subject: "s1", Email: "dave@example.com", EmailVerified: true, GivenName: "Dave"},
+			wantCreated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryUserRepo(verified, unverified, legacy, legacyReregistered)
+			s := newTestUserService(t, repo, &testClock{now: time.Now()})
+			s.oidcProvider = oidc.NewProvider(oidc.Config{Issuer: testOIDCIssuer}, nil)
+
+			userID, err := s.linkOrCreateOIDCUser(&tt.claims)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("linkOrCreateOIDCUser() error = %v, want %v", err, tt.wantErr)
+			}
+			linkedID, linkErr := repo.GetUserIDByIdentity(testOIDCIssuer, tt.claims.Subject)
+			if tt.wantErr != nil {
+				if linkErr == nil {
+					t.Fatalf("identity was linked to user %d", linkedID)
+				}
+				return
+			}
+			if linkErr != nil || linkedID != userID {
+				t.Fatalf("identity linked to %d (%v), want %d", linkedID, linkErr, userID)
+			}
+			if !tt.wantCreated {
+				if userID != tt.wantUserID {
+					t.Fatalf("linkOrCreateOIDCUser() = %d, want %d", userID, tt.wantUserID)
+				}
+				return
+			}
+			created := repo.users[userID]
+			if len(repo.users) != 5 || created.Email != tt.claims.Email || !created.EmailVerified || created.HashedPassword == "" {
+				t.Fatalf("signed up %+v", created)
+			}
+		})
+	}
+}
+
+func TestLinkOrCreateOIDCUserRetriesTakenUsername(t *testing.T) {
+	repo := newMemoryUserRepo(entity.User{ID: 1, Username: "alice", Email: "alice@old.example.com"})
+	s := newTestUserService(t, repo, &testClock{now: time.Now()})
+	s.oidcProvider = oidc.NewProvider(oidc.Config{Issuer: testOIDCIssuer}, nil)
+
+	userID, err := s.linkOrCreateOIDCUser(
+		&oidc.Claims{Subject: "s1", Email: "alice@example.com", EmailVerified: true, PreferredUsername: "Alice"},
+	)
+	if err != nil {
+		t.Fatalf("linkOrCreateOIDCUser() error = %v", err)
+	}
+	username := repo.users[userID].Username
+	if userID == 1 || !strings.HasPrefix(username, "alice") || len(username) != len("alice")+4 {
+		t.Fatalf("signed up user %d as %q, want alice with a number", userID, username)
+	}
+}
@@ -0,0 +1,284 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"news-feed/internal/entity"
+	"strconv"
+	"time"
+)
+
+// ErrSessionNotFound is returned when a session does not exist, has expired or belongs to another user.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrInvalidRefreshToken is returned when a refresh token is unknown or its session has ended.
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already rotated is used again. The
+// session is revoked since the token may have been stolen.
+var ErrRefreshTokenReused = errors.New("refresh token reused, session revoked")
+
+// rotateRefreshTokenScript swaps the session's refresh token hash only if the presented token is the
+// current one. It returns -1 when the session does not exist and 0 when the token is not current.
+var rotateRefreshTokenScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'refresh_token_hash')
+if not current then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'refresh_token_hash', ARGV[2])
+return 1
+`)
+
+type StoreInterface interface {
+	Create(userID int, username string, device string, userAgent string) (*entity.Session, error)
+	RotateRefreshToken(refreshToken string) (*entity.Session, error)
+	Get(sessionID string) (*entity.Session, error)
+	Exists(sessionID string) (bool, error)
+	List(userID int) ([]entity.Session, error)
+	Delete(userID int, sessionID string) error
+	DeleteAll(userID int) error
+}
+
+// Store keeps sessions in Redis. Each session is a hash at "session:<id>" expiring with the session, and
+// the IDs of a user's sessions are kept in the sorted set "user_sessions:<user id>" scored by expiry.
+// Every refresh token ever issued for a session is kept at "refresh_token:<sha256>" until the session
+// expires, while the session hash holds the hash of the current one, so reuse of a rotated token is detected.
+type Store struct {
+	redisClient *redis.Client
+	ttl         time.Duration
+}
+
+func NewStore(redisClient *redis.Client, ttl time.Duration) *Store {
+	return &Store{redisClient: redisClient, ttl: ttl}
+}
+
+// Create starts a new session for the user and issues its first refresh token.
+func (s *Store) Create(userID int, username string, device string, userAgent string) (*entity.Session, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("could not generate session id: %v", err)
+	}
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		ID:        hex.EncodeToString(idBytes),
+		UserID:    userID,
+		Username:  username,
+		Device:    device,
+		UserAgent: userAgent,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+
+		RefreshToken: refreshToken,
+	}
+
+	ctx := context.Background()
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(
+				ctx, sessionKey(session.ID), map[string]interface{}{
+					"user_id":            session.UserID,
+					"username":           session.Username,
+					"device":             session.Device,
+					"user_agent":         session.UserAgent,
+					"created_at":         session.CreatedAt.Format(time.RFC3339),
+					"expires_at":         session.ExpiresAt.Format(time.RFC3339),
+					"refresh_token_hash": hashRefreshToken(refreshToken),
+				},
+			)
+			pipe.ExpireAt(ctx, sessionKey(session.ID), session.ExpiresAt)
+			pipe.Set(ctx, refreshTokenKey(refreshToken), session.ID, 0)
+			pipe.ExpireAt(ctx, refreshTokenKey(refreshToken), session.ExpiresAt)
+			pipe.ZAdd(ctx, userSessionsKey(userID), redis.Z{Score: float64(session.ExpiresAt.Unix()), Member: session.ID})
+			pipe.ExpireAt(ctx, userSessionsKey(userID), session.ExpiresAt)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not store session: %v", err)
+	}
+	return session, nil
+}
+
+// RotateRefreshToken exchanges the session's current refresh token for a new one, returned in the
+// session's RefreshToken. Presenting a token that was already rotated revokes the session. Sessions keep
+// the expiry they were created with, so a session cannot be extended forever by refreshing.
+func (s *Store) RotateRefreshToken(refreshToken string) (*entity.Session, error) {
+	ctx := context.Background()
+	sessionID, err := s.redisClient.Get(ctx, refreshTokenKey(refreshToken)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.Get(sessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	// Register the new token before it becomes current so it always resolves to its session
+	if err := s.redisClient.Set(ctx, refreshTokenKey(newToken), sessionID, 0).Err(); err != nil {
+		return nil, err
+	}
+	s.redisClient.ExpireAt(ctx, refreshTokenKey(newToken), session.ExpiresAt)
+
+	result, err := rotateRefreshTokenScript.Run(
+		ctx, s.redisClient, []string{sessionKey(sessionID)}, hashRefreshToken(refreshToken), hashRefreshToken(newToken),
+	).Int()
+	if err != nil {
+		return nil, err
+	}
+	switch result {
+	case -1:
+		return nil, ErrInvalidRefreshToken
+	case 0:
+		// The token was already rotated, whoever holds it now may not be the user so end the whole family
+		if err := s.Delete(session.UserID, sessionID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	session.RefreshToken = newToken
+	return session, nil
+}
+
+// Get returns an active session.
+func (s *Store) Get(sessionID string) (*entity.Session, error) {
+	data, err := s.redisClient.HGetAll(context.Background(), sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return sessionFromHash(sessionID, data), nil
+}
+
+// Exists reports whether the session is active, it is cheaper than Get for per-request checks.
+func (s *Store) Exists(sessionID string) (bool, error) {
+	exists, err := s.redisClient.Exists(context.Background(), sessionKey(sessionID)).Result()
+	return exists > 0, err
+}
+
+// List returns the user's active sessions, newest first.
+func (s *Store) List(userID int) ([]entity.Session, error) {
+	ctx := context.Background()
+	// Drop sessions that already expired
+	s.redisClient.ZRemRangeByScore(ctx, userSessionsKey(userID), "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+
+	sessionIDs, err := s.redisClient.ZRevRange(ctx, userSessionsKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []entity.Session
+	for _, sessionID := range sessionIDs {
+		session, err := s.Get(sessionID)
+		if errors.Is(err, ErrSessionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
+}
+
+// Delete ends one of the user's sessions.
+func (s *Store) Delete(userID int, sessionID string) error {
+	session, err := s.Get(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	ctx := context.Background()
+	_, err = s.redisClient.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, sessionKey(sessionID))
+			pipe.ZRem(ctx, userSessionsKey(userID), sessionID)
+			return nil
+		},
+	)
+	return err
+}
+
+// DeleteAll ends every session of the user.
+func (s *Store) DeleteAll(userID int) error {
+	ctx := context.Background()
+	sessionIDs, err := s.redisClient.ZRange(ctx, userSessionsKey(userID), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userSessionsKey(userID)}
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+	return s.redisClient.Del(ctx, keys...).Err()
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func userSessionsKey(userID int) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+func refreshTokenKey(refreshToken string) string {
+	return "refresh_token:" + hashRefreshToken(refreshToken)
+}
+
+// hashRefreshToken returns the hex SHA-256 of a refresh token, tokens are never stored in plain text.
+func hashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func newRefreshToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("could not generate refresh token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+func sessionFromHash(sessionID string, data map[string]string) *entity.Session {
+	session := &entity.Session{
+		ID:        sessionID,
+		Username:  data["username"],
+		Device:    data["device"],
+		UserAgent: data["user_agent"],
+	}
+	session.UserID, _ = strconv.Atoi(data["user_id"])
+	session.CreatedAt, _ = time.Parse(time.RFC3339, data["created_at"])
+	session.ExpiresAt, _ = time.Parse(time.RFC3339, data["expires_at"])
+	return session
+}
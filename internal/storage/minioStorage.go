@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -15,6 +16,17 @@ type MinioStorageInterface interface {
 	UploadFile(fileName string, file io.Reader) (string, error)
 	GenerateFileURL(fileName string) (string, error)
 	GetFileURL(fileName string) string
+	StatFile(fileName string) (*FileInfo, error)
+	DeleteFile(fileName string) error
+}
+
+// ErrFileNotFound is returned when no object has been uploaded under a file name.
+var ErrFileNotFound = errors.New("file not found")
+
+// FileInfo describes an uploaded object.
+type FileInfo struct {
+	Size        int64
+	ContentType string // As sent by the uploader, not checked against the content
 }
 
 type MinioStorage struct {
@@ -80,3 +92,23 @@ func (s *MinioStorage) GetFileURL(fileName string) string {
 	objectURL := fmt.Sprintf("%s/%s/%s", s.client.EndpointURL(), s.bucket, fileName)
 	return objectURL
 }
+
+// StatFile returns the size and content type of the object uploaded under the file name.
+func (s *MinioStorage) StatFile(fileName string) (*FileInfo, error) {
+	objectInfo, err := s.client.StatObject(context.Background(), s.bucket, fileName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("could not stat file: %w", err)
+	}
+	return &FileInfo{Size: objectInfo.Size, ContentType: objectInfo.ContentType}, nil
+}
+
+func (s *MinioStorage) DeleteFile(fileName string) error {
+	err := s.client.RemoveObject(context.Background(), s.bucket, fileName, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("could not delete file: %w", err)
+	}
+	return nil
+}
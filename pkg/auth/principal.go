@@ -0,0 +1,193 @@
+// Package auth carries the authenticated user through HTTP handlers and gRPC calls.
+//
+// The webapp authenticates requests and puts a Principal in the request context. Its gRPC client
+// interceptor forwards the principal as metadata and the server interceptor of the backend services
+// restores it, so handlers on both sides read the caller with FromContext. The backend services only
+// trust this metadata from calls carrying the internal service token shared with the webapp, and listen
+// on the loopback interface unless configured otherwise.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// RoleUser is the role of every signed-in account.
+const RoleUser = "user"
+
+// Scopes of API keys. Keys with ScopePost also have ScopeRead.
+const (
+	ScopeRead = "read" // Read-only requests
+	ScopePost = "post" // Creating posts
+)
+
+// Metadata keys the principal is forwarded with.
+const (
+	metadataUserID    = "x-user-id"
+	metadataUsername  = "x-username"
+	metadataRoles     = "x-user-roles"
+	metadataSessionID = "x-session-id"
+	metadataScopes    = "x-api-key-scopes"
+
+	metadataServiceToken = "x-service-token" // Authenticates the webapp to the backend services
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID    int
+	Username  string
+	Roles     []string
+	SessionID string // Empty when the caller did not authenticate with a session, e.g. in load tests
+	// Scopes limit callers that authenticated with an API key, they are nil for sessions which may do
+	// anything the user may.
+	Scopes []string
+}
+
+// HasScope reports whether the principal may make requests of the scope.
+func (p *Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the principal has the role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal carried by ctx.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// UserID returns the ID of the principal carried by ctx.
+func UserID(ctx context.Context) (int, bool) {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return principal.UserID, true
+}
+
+// UnaryClientInterceptor forwards the principal of the call's context as metadata, together with the
+// service token the backend services authenticate the webapp with.
+func UnaryClientInterceptor(serviceToken string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if serviceToken != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, metadataServiceToken, serviceToken)
+		}
+		if principal, ok := FromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(
+				ctx,
+				metadataUserID, strconv.Itoa(principal.UserID),
+				metadataUsername, principal.Username,
+				metadataRoles, strings.Join(principal.Roles, ","),
+				metadataSessionID, principal.SessionID,
+			)
+			if principal.Scopes != nil {
+				ctx = metadata.AppendToOutgoingContext(ctx, metadataScopes, strings.Join(principal.Scopes, ","))
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor restores the principal forwarded by UnaryClientInterceptor into the context of
+// the call. Calls without one are passed on unauthenticated. When serviceToken is set, calls that do not
+// carry it are rejected before any metadata is trusted.
+func UnaryServerInterceptor(serviceToken string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if serviceToken != "" && !hasServiceToken(ctx, serviceToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid service token")
+		}
+		if principal, ok := principalFromMetadata(ctx); ok {
+			ctx = NewContext(ctx, principal)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// IsLoopbackHost reports whether a listen host only accepts connections from the same machine. Backend
+// services listening on any other host must require the service token.
+func IsLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func hasServiceToken(ctx context.Context, serviceToken string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(firstValue(md, metadataServiceToken)), []byte(serviceToken)) == 1
+}
+
+func principalFromMetadata(ctx context.Context) (*Principal, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	userID, err := strconv.Atoi(firstValue(md, metadataUserID))
+	if err != nil {
+		return nil, false
+	}
+
+	principal := &Principal{
+		UserID:    userID,
+		Username:  firstValue(md, metadataUsername),
+		SessionID: firstValue(md, metadataSessionID),
+	}
+	if roles := firstValue(md, metadataRoles); roles != "" {
+		principal.Roles = strings.Split(roles, ",")
+	}
+	if scopes := md.Get(metadataScopes); len(scopes) > 0 {
+		// An API key without scopes must stay limited, so it is not restored as nil
+		principal.Scopes = []string{}
+		if scopes[0] != "" {
+			principal.Scopes = strings.Split(scopes[0], ",")
+		}
+	}
+	return principal, true
+}
+
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
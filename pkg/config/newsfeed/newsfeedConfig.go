@@ -17,6 +17,12 @@ type NewsfeedConfig struct {
 	RedisPort     string
 	RedisPassword string
 	JWTSecret     string
+
+	// The gRPC server listens on AppHost, only the webapp should reach it. Calls without the
+	// InternalServiceToken shared with the webapp are rejected, the token is required when AppHost is not
+	// a loopback address.
+	AppHost              string
+	InternalServiceToken string
 }
 
 var config *NewsfeedConfig
@@ -40,6 +46,9 @@ func LoadNewsfeedConfig() *NewsfeedConfig {
 			RedisPort:     getEnv("REDIS_PORT", "6379"),
 			RedisPassword: getEnv("REDIS_PASSWORD", ""),
 			JWTSecret:     getEnv("JWT_SECRET", ""),
+
+			AppHost:              getEnv("APP_HOST", "127.0.0.1"),
+			InternalServiceToken: getEnv("INTERNAL_SERVICE_TOKEN", ""),
 		}
 	}
 
@@ -17,6 +17,64 @@ type UserPostFriendsConfig struct {
 	RedisPort     string
 	RedisPassword string
 	JWTSecret     string
+
+	// The gRPC server listens on AppHost, only the webapp should reach it. Calls without the
+	// InternalServiceToken shared with the webapp are rejected, the token is required when AppHost is not
+	// a loopback address.
+	AppHost              string
+	InternalServiceToken string
+
+	CommentMaxDepth int
+
+	LinkPreviewTimeoutSeconds int
+	LinkPreviewMaxBytes       int
+	LinkPreviewWorkers        int
+	LinkPreviewQueueSize      int
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+
+	PasswordResetURL        string
+	PasswordResetTTLMinutes int
+	// Reset emails that can be requested per email and per client IP address within an hour
+	PasswordResetMaxRequestsPerEmail int
+	PasswordResetMaxRequestsPerIP    int
+
+	EmailVerificationURL      string
+	EmailVerificationTTLHours int
+	// RestrictUnverifiedAccounts blocks posting and commenting until the user verifies their email.
+	RestrictUnverifiedAccounts bool
+
+	// Failed logins lock out the username or IP address at their limit, the lockout starts at
+	// LoginBaseLockoutSeconds and doubles with every further failure up to LoginMaxLockoutMinutes.
+	LoginMaxFailuresPerUsername int
+	LoginMaxFailuresPerIP       int
+	LoginCaptchaAfterFailures   int
+	LoginFailureWindowMinutes   int
+	LoginBaseLockoutSeconds     int
+	LoginMaxLockoutMinutes      int
+
+	TOTPIssuer               string // Account issuer shown by authenticator apps
+	TwoFactorTokenTTLMinutes int
+
+	// Sign in with an OpenID Connect provider, disabled when OIDCClientID is empty. The endpoints are not
+	// discovered so a local mock provider can be used in tests.
+	OIDCIssuer                string
+	OIDCClientID              string
+	OIDCClientSecret          string
+	OIDCRedirectURL           string
+	OIDCAuthorizationEndpoint string
+	OIDCTokenEndpoint         string
+	OIDCJWKSURI               string
+	OIDCScopes                string // Space separated
+	OIDCStateTTLMinutes       int
+
+	// Lifetime of personal API keys created without one, and the longest lifetime that can be chosen
+	APIKeyDefaultTTLDays int
+	APIKeyMaxTTLDays     int
 }
 
 var config *UserPostFriendsConfig
@@ -40,6 +98,55 @@ func LoadUserPostFriendsConfig() *UserPostFriendsConfig {
 			RedisPort:     getEnv("REDIS_PORT", "6379"),
 			RedisPassword: getEnv("REDIS_PASSWORD", ""),
 			JWTSecret:     getEnv("JWT_SECRET", ""),
+
+			AppHost:              getEnv("APP_HOST", "127.0.0.1"),
+			InternalServiceToken: getEnv("INTERNAL_SERVICE_TOKEN", ""),
+
+			CommentMaxDepth: getEnvInt("COMMENT_MAX_DEPTH", 3),
+
+			LinkPreviewTimeoutSeconds: getEnvInt("LINK_PREVIEW_TIMEOUT_SECONDS", 5),
+			LinkPreviewMaxBytes:       getEnvInt("LINK_PREVIEW_MAX_BYTES", 1<<20),
+			LinkPreviewWorkers:        getEnvInt("LINK_PREVIEW_WORKERS", 2),
+			LinkPreviewQueueSize:      getEnvInt("LINK_PREVIEW_QUEUE_SIZE", 100),
+
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			MailFrom:     getEnv("MAIL_FROM", "no-reply@localhost"),
+
+			PasswordResetURL:        getEnv("PASSWORD_RESET_URL", "http://localhost:8080/reset-password"),
+			PasswordResetTTLMinutes: getEnvInt("PASSWORD_RESET_TTL_MINUTES", 30),
+
+			PasswordResetMaxRequestsPerEmail: getEnvInt("PASSWORD_RESET_MAX_REQUESTS_PER_EMAIL", 3),
+			PasswordResetMaxRequestsPerIP:    getEnvInt("PASSWORD_RESET_MAX_REQUESTS_PER_IP", 20),
+
+			EmailVerificationURL:       getEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/verify-email"),
+			EmailVerificationTTLHours:  getEnvInt("EMAIL_VERIFICATION_TTL_HOURS", 24),
+			RestrictUnverifiedAccounts: getEnvBool("RESTRICT_UNVERIFIED_ACCOUNTS", false),
+
+			LoginMaxFailuresPerUsername: getEnvInt("LOGIN_MAX_FAILURES_PER_USERNAME", 5),
+			LoginMaxFailuresPerIP:       getEnvInt("LOGIN_MAX_FAILURES_PER_IP", 50),
+			LoginCaptchaAfterFailures:   getEnvInt("LOGIN_CAPTCHA_AFTER_FAILURES", 3),
+			LoginFailureWindowMinutes:   getEnvInt("LOGIN_FAILURE_WINDOW_MINUTES", 60),
+			LoginBaseLockoutSeconds:     getEnvInt("LOGIN_BASE_LOCKOUT_SECONDS", 30),
+			LoginMaxLockoutMinutes:      getEnvInt("LOGIN_MAX_LOCKOUT_MINUTES", 60),
+
+			TOTPIssuer:               getEnv("TOTP_ISSUER", "News Feed"),
+			TwoFactorTokenTTLMinutes: getEnvInt("TWO_FACTOR_TOKEN_TTL_MINUTES", 5),
+
+			OIDCIssuer:                getEnv("OIDC_ISSUER", ""),
+			OIDCClientID:              getEnv("OIDC_CLIENT_ID", ""),
+			OIDCClientSecret:          getEnv("OIDC_CLIENT_SECRET", ""),
+			OIDCRedirectURL:           getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/v1/auth/oidc/callback"),
+			OIDCAuthorizationEndpoint: getEnv("OIDC_AUTHORIZATION_ENDPOINT", ""),
+			OIDCTokenEndpoint:         getEnv("OIDC_TOKEN_ENDPOINT", ""),
+			OIDCJWKSURI:               getEnv("OIDC_JWKS_URI", ""),
+			OIDCScopes:                getEnv("OIDC_SCOPES", "openid email profile"),
+			OIDCStateTTLMinutes:       getEnvInt("OIDC_STATE_TTL_MINUTES", 10),
+
+			APIKeyDefaultTTLDays: getEnvInt("API_KEY_DEFAULT_TTL_DAYS", 90),
+			APIKeyMaxTTLDays:     getEnvInt("API_KEY_MAX_TTL_DAYS", 365),
 		}
 	}
 
@@ -52,3 +159,17 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if viper.IsSet(key) {
+		return viper.GetInt(key)
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if viper.IsSet(key) {
+		return viper.GetBool(key)
+	}
+	return defaultValue
+}
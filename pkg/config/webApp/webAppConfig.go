@@ -6,22 +6,33 @@ import (
 )
 
 type WebAppConfig struct {
-	AppName             string
-	AppPort             string
-	NewsfeedAppPort     string
-	PostUserFriendsPort string
-	JWTSecret           string
-	DBHost              string
-	DBPort              string
-	DBUser              string
-	DBPassword          string
-	DBName              string
-	RedisHost           string
-	RedisPort           string
-	MinIOEndpoint       string
-	MinIOAccessKey      string
-	MinIOSecretKey      string
-	MinIOBucket         string
+	AppName                string
+	AppEnv                 string // "loadtest" enables the load test identity provider, no other value changes behavior
+	AppPort                string
+	NewsfeedAppPort        string
+	PostUserFriendsPort    string
+	InternalServiceToken   string // Authenticates the webapp to the backend services, see their config
+	JWTSecret              string // HS256 secret, signs and verifies when there is no asymmetric key
+	JWTHMACAcceptUntil     string // RFC 3339 time until which HS256 tokens stay valid next to asymmetric keys
+	JWTSigningKeyFile      string // PEM RSA or Ed25519 private key, tokens are signed with RS256 or EdDSA when set
+	JWTVerificationKeysDir string // PEM public keys accepted in addition to the signing key
+	DBHost                 string
+	DBPort                 string
+	DBUser                 string
+	DBPassword             string
+	DBName                 string
+	RedisHost              string
+	RedisPort              string
+	MinIOEndpoint          string
+	MinIOAccessKey         string
+	MinIOSecretKey         string
+	MinIOBucket            string
+
+	// LoadTestAuthEnabled accepts the stressTest scripts' Authorization header as a random user in
+	// [LoadTestMinUserID, LoadTestMaxUserID]. It is refused unless AppEnv is "loadtest".
+	LoadTestAuthEnabled bool
+	LoadTestMinUserID   int
+	LoadTestMaxUserID   int
 }
 
 var config *WebAppConfig
@@ -35,22 +46,30 @@ func LoadConfig() *WebAppConfig {
 	}
 	if config == nil {
 		config = &WebAppConfig{
-			AppName:             getEnv("APP_NAME", "WebApp"),
-			AppPort:             getEnv("APP_PORT", "8080"),
-			NewsfeedAppPort:     getEnv("NEWSFEED_APP_PORT", "8081"),
-			PostUserFriendsPort: getEnv("POST_USER_FRIENDS_PORT", "8082"),
-			JWTSecret:           getEnv("JWTSecret", ""),
-			DBHost:              getEnv("DB_HOST", "localhost"),
-			DBPort:              getEnv("DB_PORT", "5432"),
-			DBUser:              getEnv("DB_USER", ""),
-			DBPassword:          getEnv("DB_PASSWORD", ""),
-			DBName:              getEnv("DB_NAME", ""),
-			RedisHost:           getEnv("REDIS_HOST", "localhost"),
-			RedisPort:           getEnv("REDIS_PORT", "6379"),
-			MinIOEndpoint:       getEnv("MINIO_ENDPOINT", ""),
-			MinIOAccessKey:      getEnv("MINIO_ACCESS_KEY", ""),
-			MinIOSecretKey:      getEnv("MINIO_SECRET_KEY", ""),
-			MinIOBucket:         getEnv("MINIO_BUCKET", ""),
+			AppName:                getEnv("APP_NAME", "WebApp"),
+			AppEnv:                 getEnv("APP_ENV", "development"),
+			AppPort:                getEnv("APP_PORT", "8080"),
+			NewsfeedAppPort:        getEnv("NEWSFEED_APP_PORT", "8081"),
+			PostUserFriendsPort:    getEnv("POST_USER_FRIENDS_PORT", "8082"),
+			InternalServiceToken:   getEnv("INTERNAL_SERVICE_TOKEN", ""),
+			JWTSecret:              getEnv("JWTSecret", ""),
+			JWTHMACAcceptUntil:     getEnv("JWT_HMAC_ACCEPT_UNTIL", ""),
+			JWTSigningKeyFile:      getEnv("JWT_SIGNING_KEY_FILE", ""),
+			JWTVerificationKeysDir: getEnv("JWT_VERIFICATION_KEYS_DIR", ""),
+			DBHost:                 getEnv("DB_HOST", "localhost"),
+			DBPort:                 getEnv("DB_PORT", "5432"),
+			DBUser:                 getEnv("DB_USER", ""),
+			DBPassword:             getEnv("DB_PASSWORD", ""),
+			DBName:                 getEnv("DB_NAME", ""),
+			RedisHost:              getEnv("REDIS_HOST", "localhost"),
+			RedisPort:              getEnv("REDIS_PORT", "6379"),
+			MinIOEndpoint:          getEnv("MINIO_ENDPOINT", ""),
+			MinIOAccessKey:         getEnv("MINIO_ACCESS_KEY", ""),
+			MinIOSecretKey:         getEnv("MINIO_SECRET_KEY", ""),
+			MinIOBucket:            getEnv("MINIO_BUCKET", ""),
+			LoadTestAuthEnabled:    getEnvBool("LOAD_TEST_AUTH_ENABLED", false),
+			LoadTestMinUserID:      getEnvInt("LOAD_TEST_MIN_USER_ID", 0),
+			LoadTestMaxUserID:      getEnvInt("LOAD_TEST_MAX_USER_ID", 0),
 		}
 	}
 
@@ -64,3 +83,17 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if viper.IsSet(key) {
+		return viper.GetInt(key)
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if viper.IsSet(key) {
+		return viper.GetBool(key)
+	}
+	return defaultValue
+}
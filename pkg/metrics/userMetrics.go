@@ -24,3 +24,30 @@ var LoginLatency = promauto.NewSummaryVec(
 func RecordLoginLatency(status string, duration float64) {
 	LoginLatency.WithLabelValues("login", status).Observe(duration)
 }
+
+var LoginAttempts = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "newsfeed",
+		Subsystem: "webapp",
+		Name:      "login_attempts_total",
+		Help:      "Login attempts by result: success, two_factor, failure, locked or error",
+	},
+	[]string{"result"},
+)
+
+var LoginCaptchaRequired = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "newsfeed",
+		Subsystem: "webapp",
+		Name:      "login_captcha_required_total",
+		Help:      "Failed logins answered with a CAPTCHA requirement",
+	},
+)
+
+// RecordLoginAttempt counts a login attempt by its result
+func RecordLoginAttempt(result string, captchaRequired bool) {
+	LoginAttempts.WithLabelValues(result).Inc()
+	if captchaRequired {
+		LoginCaptchaRequired.Inc()
+	}
+}
@@ -1,37 +1,86 @@
 package middleware
 
 import (
-	"context"
 	"errors"
 	"github.com/golang-jwt/jwt"
-	"math/rand"
+	"log"
 	"net/http"
+	"news-feed/internal/apikey"
 	"news-feed/internal/cache"
+	"news-feed/internal/session"
+	"news-feed/pkg/auth"
 	"news-feed/pkg/config/webApp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-var jwtKey = webApp.LoadConfig().JWTSecret
-var redisClient = cache.GetRedisClient()
+// AccessTokenTTL is how long a JWT stays valid, clients use their refresh token to get a new one.
+const AccessTokenTTL = 15 * time.Minute
 
+// RefreshTokenTTL is how long a session and the refresh tokens rotated for it stay valid.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// The keys and stores are loaded on first use, or by Init, so importing the package has no side effects.
+var keys = sync.OnceValue(loadKeys)
+var loadTestIdentities = sync.OnceValue(loadLoadTestIdentityProvider)
+var sessionStore = sync.OnceValue(
+	func() *session.Store {
+		return session.NewStore(cache.GetRedisClient(), RefreshTokenTTL)
+	},
+)
+var apiKeyStore = sync.OnceValue(
+	func() *apikey.Store {
+		return apikey.NewStore(cache.GetRedisClient())
+	},
+)
+
+// Init loads the JWT keys, the load test identity provider and the session and API key stores. Services
+// call it at startup so a bad configuration exits right away rather than on the first request.
+func Init() {
+	keys()
+	loadTestIdentities()
+	sessionStore()
+	apiKeyStore()
+}
+
+// APIKeyHeader is the header tools and bots send their personal API key in, instead of a JWT in the
+// Authorization header.
+const APIKeyHeader = "X-API-Key"
+
+// Claims are the claims of the JWTs we issue, the subject is the user ID.
 type Claims struct {
-	Subject string `json:"sub"`
+	SessionID string   `json:"sid"`
+	Username  string   `json:"username"`
+	Roles     []string `json:"roles"`
 	jwt.StandardClaims
 }
 
+// JWTAuthMiddleware authenticates requests with a JWT. API keys are refused, routes they may call opt in
+// with APIKeyScopeMiddleware so account management stays out of their reach.
 func JWTAuthMiddleware(next http.Handler) http.Handler {
-	// Create a new random source and generator
-	randSource := rand.NewSource(time.Now().UnixNano())
-	randGen := rand.New(randSource)
-	minUserID, maxUserID := 11878102, 21878101
+	return authMiddleware(next, "")
+}
+
+// APIKeyScopeMiddleware is JWTAuthMiddleware for routes API keys with the scope may call too, with the key
+// in the X-API-Key header.
+func APIKeyScopeMiddleware(scope string, next http.Handler) http.Handler {
+	return authMiddleware(next, scope)
+}
+
+func authMiddleware(next http.Handler, apiKeyScope string) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get(APIKeyHeader); key != "" {
+				authenticateAPIKey(w, r, next, key, apiKeyScope)
+				return
+			}
+
 			authHeader := r.Header.Get("Authorization")
-			if authHeader == "wrk-stress-test" {
-				// Generate a random user ID between 1 and 10,000,000
-				randomUserID := randGen.Intn(maxUserID-minUserID+1) + minUserID // Set the random user ID in the context
-				ctx := context.WithValue(r.Context(), "userID", randomUserID)
+			if identities := loadTestIdentities(); identities != nil && authHeader == LoadTestToken {
+				// Load test request, authenticate as a random user of the configured range
+				ctx := auth.NewContext(r.Context(), identities.Principal())
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
@@ -50,30 +99,62 @@ func JWTAuthMiddleware(next http.Handler) http.Handler {
 				return
 			}
 
-			// Check if the token exists in Redis
-			exists, err := redisClient.Exists(context.Background(), tokenString).Result()
-			if err != nil || exists == 0 {
+			userID, err := strconv.Atoi(claims.Subject)
+			if err != nil || claims.SessionID == "" {
+				http.Error(w, "Invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			// Check the session has not been logged out or revoked, and is the subject's
+			userSession, err := sessionStore().Get(claims.SessionID)
+			if err != nil || userSession.UserID != userID {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
-			// Set user ID in context for use in handlers
-			ctx := context.WithValue(r.Context(), "userID", claims.Subject)
+			// Set the principal in context for use in handlers
+			ctx := auth.NewContext(
+				r.Context(), &auth.Principal{
+					UserID:    userID,
+					Username:  claims.Username,
+					Roles:     claims.Roles,
+					SessionID: claims.SessionID,
+				},
+			)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		},
 	)
 }
 
+// authenticateAPIKey serves a request made with an API key as the key's user, limited to the key's scopes.
+func authenticateAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, key string, apiKeyScope string) {
+	apiKey, err := apiKeyStore().Authenticate(key)
+	if err != nil {
+		http.Error(w, "Invalid API key", http.StatusUnauthorized)
+		return
+	}
+
+	principal := &auth.Principal{
+		UserID:   apiKey.UserID,
+		Username: apiKey.Username,
+		Roles:    []string{auth.RoleUser},
+		// Never nil, a nil scope list would allow everything
+		Scopes: append([]string{}, apiKey.Scopes...),
+	}
+	if apiKeyScope == "" {
+		http.Error(w, "API keys cannot make this request", http.StatusForbidden)
+		return
+	}
+	if !principal.HasScope(apiKeyScope) {
+		http.Error(w, "API key lacks the "+apiKeyScope+" scope", http.StatusForbidden)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+}
+
 func ValidateJWT(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(
-		tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate the token's signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("invalid signing method")
-			}
-			return jwtKey, nil
-		},
-	)
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys().Keyfunc)
 	if err != nil {
 		return nil, err
 	}
@@ -86,19 +167,50 @@ func ValidateJWT(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// GenerateJWT generates a new JWT for a given user ID.
-func GenerateJWT(userName string) (string, error) {
-	claims := jwt.StandardClaims{
-		Subject:   userName,
-		ExpiresAt: time.Now().Add(24 * time.Hour).Unix(), // Token valid for 24 hours
+// GenerateJWT generates a new JWT for the principal, it must have a session.
+func GenerateJWT(principal auth.Principal) (string, error) {
+	claims := Claims{
+		SessionID: principal.SessionID,
+		Username:  principal.Username,
+		Roles:     principal.Roles,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   strconv.Itoa(principal.UserID),
+			ExpiresAt: time.Now().Add(AccessTokenTTL).Unix(),
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	jwtSecret := webApp.LoadConfig().JWTSecret
-	tokenStr, err := token.SignedString([]byte(jwtSecret))
+	tokenStr, err := keys().Sign(claims)
 	if err != nil {
 		return "", err
 	}
 
 	return tokenStr, nil
 }
+
+// JWKS returns the public keys tokens are verified with.
+func JWKS() JWKSet {
+	return keys().JWKS()
+}
+
+// PeriodicallyReloadKeys reloads the signing and verification keys at every interval so rotated keys are
+// picked up without a restart.
+func PeriodicallyReloadKeys(interval time.Duration) {
+	keys().PeriodicallyReload(interval)
+}
+
+func loadKeys() *KeySet {
+	cfg := webApp.LoadConfig()
+	var hmacAcceptUntil time.Time
+	if cfg.JWTHMACAcceptUntil != "" {
+		var err error
+		hmacAcceptUntil, err = time.Parse(time.RFC3339, cfg.JWTHMACAcceptUntil)
+		if err != nil {
+			log.Fatalf("Error parsing JWT_HMAC_ACCEPT_UNTIL: %v", err)
+		}
+	}
+	keySet, err := NewKeySet(cfg.JWTSigningKeyFile, cfg.JWTVerificationKeysDir, cfg.JWTSecret, hmacAcceptUntil)
+	if err != nil {
+		log.Fatalf("Error loading JWT keys: %v", err)
+	}
+	return keySet
+}
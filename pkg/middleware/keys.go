@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"news-feed/pkg/logger"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownKeyID is returned when a token is signed with a key that is not in the verification keys.
+var ErrUnknownKeyID = errors.New("unknown key id")
+
+// JWK is a public key in the JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // Ed25519 curve
+	X   string `json:"x,omitempty"`   // Ed25519 public key
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet holds the key JWTs are signed with and the keys they are verified with.
+//
+// The signing key is an RSA or Ed25519 private key in a PEM file, tokens are signed with RS256 or EdDSA
+// and carry the key's ID in the "kid" header. Verification keys are the PEM public keys in a directory
+// plus the public half of the signing key. Key IDs are the RFC 7638 thumbprints of the keys, so files can
+// be named freely. Without a signing key tokens are signed with HS256 and the shared secret. Once any
+// asymmetric key is loaded HS256 tokens are rejected, anyone who knows the shared secret could forge them,
+// unless hmacAcceptUntil is set to keep accepting them while services are migrated one at a time.
+//
+// Keys are rotated without downtime by adding the new public key to the verification directory of every
+// service, replacing the signing key once they reloaded it, and removing the old public key after
+// AccessTokenTTL.
+type KeySet struct {
+	signingKeyFile      string
+	verificationKeysDir string
+	hmacSecret          []byte
+	hmacAcceptUntil     time.Time // Zero to reject HS256 tokens as soon as an asymmetric key is loaded
+
+	mu               sync.RWMutex
+	signingKeyID     string
+	signingMethod    jwt.SigningMethod
+	signingKey       crypto.PrivateKey
+	verificationKeys map[string]crypto.PublicKey
+}
+
+// NewKeySet creates a key set and loads its keys. Any of the arguments may be empty, a service that only
+// verifies tokens needs no signing key. HS256 tokens are accepted next to asymmetric keys until
+// hmacAcceptUntil, which is meant for a migration and should be zero otherwise.
+func NewKeySet(signingKeyFile string, verificationKeysDir string, hmacSecret string, hmacAcceptUntil time.Time) (*KeySet, error) {
+	keySet := &KeySet{
+		signingKeyFile:      signingKeyFile,
+		verificationKeysDir: verificationKeysDir,
+		hmacSecret:          []byte(hmacSecret),
+		hmacAcceptUntil:     hmacAcceptUntil,
+	}
+	if err := keySet.Reload(); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// Reload reads the keys from disk again. On error the keys loaded before are kept.
+func (k *KeySet) Reload() error {
+	var signingKeyID string
+	var signingMethod jwt.SigningMethod
+	var signingKey crypto.PrivateKey
+	verificationKeys := map[string]crypto.PublicKey{}
+
+	if k.signingKeyFile != "" {
+		data, err := os.ReadFile(k.signingKeyFile)
+		if err != nil {
+			return fmt.Errorf("could not read signing key: %v", err)
+		}
+		signingKey, err = parsePrivateKey(data)
+		if err != nil {
+			return fmt.Errorf("could not parse signing key %s: %v", k.signingKeyFile, err)
+		}
+		publicKey := signingKey.(crypto.Signer).Public()
+		signingKeyID = keyID(publicKey)
+		signingMethod = signingMethodFor(publicKey)
+		verificationKeys[signingKeyID] = publicKey
+	}
+
+	if k.verificationKeysDir != "" {
+		files, err := filepath.Glob(filepath.Join(k.verificationKeysDir, "*.pem"))
+		if err != nil {
+			return fmt.Errorf("could not list verification keys: %v", err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("could not read verification key: %v", err)
+			}
+			publicKey, err := parsePublicKey(data)
+			if err != nil {
+				return fmt.Errorf("could not parse verification key %s: %v", file, err)
+			}
+			verificationKeys[keyID(publicKey)] = publicKey
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.signingKeyID = signingKeyID
+	k.signingMethod = signingMethod
+	k.signingKey = signingKey
+	k.verificationKeys = verificationKeys
+	return nil
+}
+
+// PeriodicallyReload reloads the keys at every interval so rotated keys are picked up.
+func (k *KeySet) PeriodicallyReload(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := k.Reload()
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Error reloading JWT keys: %v", err))
+		}
+	}
+}
+
+// Sign signs the claims with the signing key, or with the shared secret when there is none.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.signingKey == nil {
+		if len(k.hmacSecret) == 0 {
+			return "", errors.New("no signing key or JWT secret configured")
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.hmacSecret)
+	}
+	token := jwt.NewWithClaims(k.signingMethod, claims)
+	token.Header["kid"] = k.signingKeyID
+	return token.SignedString(k.signingKey)
+}
+
+// Keyfunc returns the key to verify a token with, it is passed to jwt.ParseWithClaims.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	k.mu.RLock()
+	hasAsymmetricKeys := len(k.verificationKeys) > 0
+	kid, _ := token.Header["kid"].(string)
+	publicKey, ok := k.verificationKeys[kid]
+	k.mu.RUnlock()
+
+	if _, isHMAC := token.Method.(*jwt.SigningMethodHMAC); isHMAC {
+		if len(k.hmacSecret) == 0 || (hasAsymmetricKeys && !time.Now().Before(k.hmacAcceptUntil)) {
+			return nil, errors.New("invalid signing method")
+		}
+		return k.hmacSecret, nil
+	}
+
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	// The algorithm must be the one of the key, never the one the token claims
+	if token.Method != signingMethodFor(publicKey) {
+		return nil, errors.New("invalid signing method")
+	}
+	return publicKey, nil
+}
+
+// JWKS returns the verification keys, sorted by key ID.
+func (k *KeySet) JWKS() JWKSet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	keys := make([]JWK, 0, len(k.verificationKeys))
+	for kid, publicKey := range k.verificationKeys {
+		jwk := publicJWK(publicKey)
+		jwk.Kid = kid
+		jwk.Use = "sig"
+		jwk.Alg = signingMethodFor(publicKey).Alg()
+		keys = append(keys, jwk)
+	}
+	sort.Slice(
+		keys, func(i, j int) bool {
+			return keys[i].Kid < keys[j].Kid
+		},
+	)
+	return JWKSet{Keys: keys}
+}
+
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return rsaKey, nil
+	}
+	edKey, err := jwt.ParseEdPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, errors.New("not an RSA or Ed25519 private key")
+	}
+	return edKey, nil
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return rsaKey, nil
+	}
+	edKey, err := jwt.ParseEdPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, errors.New("not an RSA or Ed25519 public key")
+	}
+	return edKey, nil
+}
+
+func signingMethodFor(publicKey crypto.PublicKey) jwt.SigningMethod {
+	if _, ok := publicKey.(*rsa.PublicKey); ok {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
+
+// publicJWK returns the members of the JWK that identify the key.
+func publicJWK(publicKey crypto.PublicKey) JWK {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	}
+	return JWK{}
+}
+
+// keyID returns the RFC 7638 thumbprint of the key.
+func keyID(publicKey crypto.PublicKey) string {
+	jwk := publicJWK(publicKey)
+	// The required members in lexicographic order, encoding/json sorts map keys
+	members := map[string]string{"kty": jwk.Kty}
+	if jwk.Kty == "RSA" {
+		members["n"] = jwk.N
+		members["e"] = jwk.E
+	} else {
+		members["crv"] = jwk.Crv
+		members["x"] = jwk.X
+	}
+	data, _ := json.Marshal(members)
+	hash := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,299 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"github.com/golang-jwt/jwt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testHMACSecret = "test-secret"
+
+// errAny is the wanted error of cases that only need the token to be rejected.
+var errAny = errors.New("any error")
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate RSA key: %v", err)
+	}
+	return key
+}
+
+func generateEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate Ed25519 key: %v", err)
+	}
+	return key
+}
+
+func writePrivateKey(t *testing.T, path string, key crypto.Signer) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("could not marshal private key: %v", err)
+	}
+	writePEM(t, path, "PRIVATE KEY", der)
+}
+
+func writePublicKey(t *testing.T, path string, key crypto.PublicKey) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("could not marshal public key: %v", err)
+	}
+	writePEM(t, path, "PUBLIC KEY", der)
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, jwt.StandardClaims{Subject: "1", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("could not sign token: %v", err)
+	}
+	return signed
+}
+
+// parseError returns the error of Keyfunc behind the one jwt.Parse wraps it in.
+func parseError(err error) error {
+	var validationErr *jwt.ValidationError
+	if errors.As(err, &validationErr) && validationErr.Inner != nil {
+		return validationErr.Inner
+	}
+	return err
+}
+
+func TestKeySetKeyfunc(t *testing.T) {
+	dir := t.TempDir()
+	signingKey := generateRSAKey(t)
+	signingKeyFile := filepath.Join(dir, "signing.pem")
+	writePrivateKey(t, signingKeyFile, signingKey)
+	verificationDir := filepath.Join(dir, "verification")
+	if err := os.Mkdir(verificationDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	edKey := generateEd25519Key(t)
+	writePublicKey(t, filepath.Join(verificationDir, "ed25519.pem"), edKey.Public())
+	publicPEM, err := os.ReadFile(filepath.Join(verificationDir, "ed25519.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingKID := keyID(signingKey.Public())
+	edKID := keyID(edKey.Public())
+	unknownKey := generateRSAKey(t)
+
+	tests := []struct {
+		name            string
+		withKeys        bool
+		hmacAcceptUntil time.Time
+		token           string
+		wantErr         error // nil when the token must verify, errAny for any error
+	}{
+		{
+			name:     "RS256 with the signing key",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodRS256, signingKID, signingKey),
+		},
+		{
+			name:     "EdDSA with a verification key",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodEdDSA, edKID, edKey),
+		},
+		{
+			name:     "unknown kid",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodRS256, keyID(unknownKey.Public()), unknownKey),
+			wantErr:  ErrUnknownKeyID,
+		},
+		{
+			name:     "missing kid",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodRS256, "", signingKey),
+			wantErr:  ErrUnknownKeyID,
+		},
+		{
+			name:     "algorithm of another key type",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodRS256, edKID, unknownKey),
+			wantErr:  errAny,
+		},
+		{
+			name:     "HS256 signed with a public key as secret",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodHS256, edKID, publicPEM),
+			wantErr:  errAny,
+		},
+		{
+			name:  "HS256 without asymmetric keys",
+			token: signToken(t, jwt.SigningMethodHS256, "", []byte(testHMACSecret)),
+		},
+		{
+			name:     "HS256 once asymmetric keys are loaded",
+			withKeys: true,
+			token:    signToken(t, jwt.SigningMethodHS256, "", []byte(testHMACSecret)),
+			wantErr:  errAny,
+		},
+		{
+			name:            "HS256 before the cutoff",
+			withKeys:        true,
+			hmacAcceptUntil: time.Now().Add(time.Hour),
+			token:           signToken(t, jwt.SigningMethodHS256, "", []byte(testHMACSecret)),
+		},
+		{
+			name:            "HS256 after the cutoff",
+			withKeys:        true,
+			hmacAcceptUntil: time.Now().Add(-time.Second),
+			token:           signToken(t, jwt.SigningMethodHS256, "", []byte(testHMACSecret)),
+			wantErr:         errAny,
+		},
+		{
+			name:    "HS256 with the wrong secret",
+			token:   signToken(t, jwt.SigningMethodHS256, "", []byte("other-secret")),
+			wantErr: errAny,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keySet *KeySet
+			var err error
+			if tt.withKeys {
+				keySet, err = NewKeySet(signingKeyFile, verificationDir, testHMACSecret, tt.hmacAcceptUntil)
+			} else {
+				keySet, err = NewKeySet("", "", testHMACSecret, tt.hmacAcceptUntil)
+			}
+			if err != nil {
+				t.Fatalf("NewKeySet() error = %v", err)
+			}
+
+			_, err = jwt.Parse(tt.token, keySet.Keyfunc)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Parse() error = %v, want a valid token", err)
+			case tt.wantErr == errAny && err == nil:
+				t.Fatal("Parse() accepted the token")
+			case tt.wantErr != nil && tt.wantErr != errAny && !errors.Is(parseError(err), tt.wantErr):
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeySetSign(t *testing.T) {
+	dir := t.TempDir()
+	edKey := generateEd25519Key(t)
+	signingKeyFile := filepath.Join(dir, "signing.pem")
+	writePrivateKey(t, signingKeyFile, edKey)
+
+	keySet, err := NewKeySet(signingKeyFile, "", testHMACSecret, time.Time{})
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	signed, err := keySet.Sign(jwt.StandardClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	token, err := jwt.Parse(signed, keySet.Keyfunc)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if token.Method != jwt.SigningMethodEdDSA || token.Header["kid"] != keyID(edKey.Public()) {
+		t.Fatalf("token signed with %s and kid %v", token.Method.Alg(), token.Header["kid"])
+	}
+
+	if _, err := (&KeySet{}).Sign(jwt.StandardClaims{}); err == nil {
+		t.Fatal("Sign() without keys succeeded")
+	}
+}
+
+func TestKeySetReload(t *testing.T) {
+	dir := t.TempDir()
+	signingKeyFile := filepath.Join(dir, "signing.pem")
+	verificationDir := filepath.Join(dir, "verification")
+	if err := os.Mkdir(verificationDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	oldKey := generateRSAKey(t)
+	writePrivateKey(t, signingKeyFile, oldKey)
+
+	keySet, err := NewKeySet(signingKeyFile, verificationDir, "", time.Time{})
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	oldToken, err := keySet.Sign(jwt.StandardClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// Rotate: the old public key stays for verification while tokens are signed with the new key
+	newKey := generateEd25519Key(t)
+	oldPublicKeyFile := filepath.Join(verificationDir, "old.pem")
+	writePublicKey(t, oldPublicKeyFile, oldKey.Public())
+	writePrivateKey(t, signingKeyFile, newKey)
+	if err := keySet.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if _, err := jwt.Parse(oldToken, keySet.Keyfunc); err != nil {
+		t.Fatalf("token of the old key rejected after rotation: %v", err)
+	}
+	newToken, err := keySet.Sign(jwt.StandardClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	token, err := jwt.Parse(newToken, keySet.Keyfunc)
+	if err != nil {
+		t.Fatalf("token of the new key rejected: %v", err)
+	}
+	if token.Header["kid"] != keyID(newKey.Public()) {
+		t.Fatalf("new token kid = %v, want the new key", token.Header["kid"])
+	}
+	if got := len(keySet.JWKS().Keys); got != 2 {
+		t.Fatalf("JWKS has %d keys, want 2", got)
+	}
+
+	// A broken key file keeps the keys loaded before
+	if err := os.WriteFile(signingKeyFile, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := keySet.Reload(); err == nil {
+		t.Fatal("Reload() of a broken key succeeded")
+	}
+	if _, err := jwt.Parse(newToken, keySet.Keyfunc); err != nil {
+		t.Fatalf("keys lost after a failed reload: %v", err)
+	}
+
+	// Once the old public key is removed its tokens are rejected
+	writePrivateKey(t, signingKeyFile, newKey)
+	if err := os.Remove(oldPublicKeyFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := keySet.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if _, err := jwt.Parse(oldToken, keySet.Keyfunc); !errors.Is(parseError(err), ErrUnknownKeyID) {
+		t.Fatalf("token of the removed key: error = %v, want %v", err, ErrUnknownKeyID)
+	}
+}
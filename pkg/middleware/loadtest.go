@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"news-feed/pkg/auth"
+	"news-feed/pkg/config/webApp"
+)
+
+// LoadTestToken is the Authorization header value sent by the stressTest scripts.
+const LoadTestToken = "wrk-stress-test"
+
+// LoadTestIdentityProvider authenticates load test requests as random users, so load tests can run
+// without signing in millions of fake accounts. It is disabled unless LOAD_TEST_AUTH_ENABLED is set in
+// an environment listed in loadTestEnvironments.
+type LoadTestIdentityProvider struct {
+	minUserID int
+	maxUserID int
+}
+
+// NewLoadTestIdentityProvider creates a provider handing out user IDs in [minUserID, maxUserID].
+func NewLoadTestIdentityProvider(minUserID int, maxUserID int) (*LoadTestIdentityProvider, error) {
+	if minUserID <= 0 || maxUserID < minUserID {
+		return nil, fmt.Errorf("invalid load test user ID range [%d, %d]", minUserID, maxUserID)
+	}
+	return &LoadTestIdentityProvider{minUserID: minUserID, maxUserID: maxUserID}, nil
+}
+
+// Principal returns a random user of the range. It has no session, so session endpoints reject it.
+func (p *LoadTestIdentityProvider) Principal() *auth.Principal {
+	return &auth.Principal{
+		UserID: rand.Intn(p.maxUserID-p.minUserID+1) + p.minUserID,
+		Roles:  []string{auth.RoleUser},
+	}
+}
+
+// loadTestEnvironments are the values of APP_ENV load test authentication may be enabled in. Any other
+// environment, including a missing or misspelled APP_ENV, refuses it.
+var loadTestEnvironments = map[string]bool{"loadtest": true}
+
+// loadLoadTestIdentityProvider returns the configured provider, or nil when load test authentication is
+// disabled. It exits when load test authentication is enabled outside loadTestEnvironments.
+func loadLoadTestIdentityProvider() *LoadTestIdentityProvider {
+	cfg := webApp.LoadConfig()
+	if !cfg.LoadTestAuthEnabled {
+		return nil
+	}
+	if !loadTestEnvironments[cfg.AppEnv] {
+		log.Fatalf("Refusing to start: LOAD_TEST_AUTH_ENABLED may only be set when APP_ENV is loadtest, not %q", cfg.AppEnv)
+	}
+
+	provider, err := NewLoadTestIdentityProvider(cfg.LoadTestMinUserID, cfg.LoadTestMaxUserID)
+	if err != nil {
+		log.Fatalf("Error loading load test identity provider: %v", err)
+	}
+	log.Printf(
+		"WARNING: LOAD TEST AUTHENTICATION IS ENABLED. Any request with \"Authorization: %s\" is "+
+			"authenticated as a random user with an ID in [%d, %d]. Disable LOAD_TEST_AUTH_ENABLED outside load tests.",
+		LoadTestToken, cfg.LoadTestMinUserID, cfg.LoadTestMaxUserID,
+	)
+	return provider
+}
@@ -0,0 +1,321 @@
+// Package oidc signs users in with an external OpenID Connect provider using the authorization code flow
+// with PKCE (RFC 7636). The provider's endpoints are configured explicitly instead of discovered, so a
+// local mock provider can stand in for the real one.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidIDToken is returned when an ID token is malformed, expired, not signed by the provider or not
+// issued for this client and login.
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+// Minimum time between two fetches of the provider's keys for tokens signed with an unknown key
+const minKeyRefreshInterval = time.Minute
+
+// Config configures a Provider.
+type Config struct {
+	Issuer                string // Must match the "iss" claim of ID tokens
+	ClientID              string
+	ClientSecret          string // Sent with HTTP basic authentication, may be empty for public clients
+	RedirectURL           string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	JWKSURI               string
+	Scopes                []string
+}
+
+// Claims are the claims of a verified ID token used to sign the user in.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	GivenName         string
+	FamilyName        string
+	PreferredUsername string
+}
+
+// Provider is an OpenID Connect provider the service is registered with as a client.
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewProvider creates a provider, its keys are fetched when the first ID token is verified.
+func NewProvider(config Config, httpClient *http.Client) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{config: config, httpClient: httpClient}
+}
+
+// Issuer returns the issuer identifier of the provider, accounts are linked to (issuer, subject) pairs.
+func (p *Provider) Issuer() string {
+	return p.config.Issuer
+}
+
+// NewCodeVerifier returns a random PKCE code verifier.
+func NewCodeVerifier() (string, error) {
+	return randomString()
+}
+
+// NewState returns a random value for the state or nonce parameter.
+func NewState() (string, error) {
+	return randomString()
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// AuthCodeURL returns the URL of the provider's authorization endpoint the user is redirected to.
+func (p *Provider) AuthCodeURL(state string, nonce string, codeVerifier string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.config.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.config.AuthorizationEndpoint + separator + query.Encode()
+}
+
+// Exchange redeems an authorization code at the token endpoint and returns the raw ID token.
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not reach token endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("could not read token response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("could not decode token response: %v", err)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", errors.New("token response has no ID token")
+	}
+	return tokenResponse.IDToken, nil
+}
+
+// VerifyIDToken checks the ID token's signature against the provider's keys, its issuer, audience,
+// expiry and nonce, and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+			return p.verificationKey(ctx, token)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if !claims.VerifyIssuer(p.config.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidIDToken)
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidIDToken)
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.config.ClientID {
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidIDToken)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: no expiry", ErrInvalidIDToken)
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+	result := &Claims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.GivenName, _ = claims["given_name"].(string)
+	result.FamilyName, _ = claims["family_name"].(string)
+	result.PreferredUsername, _ = claims["preferred_username"].(string)
+	// Some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+	return result, nil
+}
+
+// verificationKey returns the provider key the token is signed with, fetching the provider's keys again
+// when the key is unknown so rotated keys are picked up.
+func (p *Provider) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	publicKey, ok := p.keys[kid]
+	if !ok && time.Since(p.keysFetchedAt) >= minKeyRefreshInterval {
+		keys, err := p.fetchKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.keys = keys
+		p.keysFetchedAt = time.Now()
+		publicKey, ok = p.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	// The algorithm must be the one of the key, never the one the token claims. HMAC is never accepted.
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, errors.New("invalid signing method")
+		}
+	case *ecdsa.PublicKey:
+		if token.Method != jwt.SigningMethodES256 {
+			return nil, errors.New("invalid signing method")
+		}
+	case ed25519.PublicKey:
+		if token.Method != jwt.SigningMethodEdDSA {
+			return nil, errors.New("invalid signing method")
+		}
+	}
+	return publicKey, nil
+}
+
+// fetchKeys downloads the provider's JWKS. Keys of types that cannot sign ID tokens are skipped.
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch provider keys: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("provider keys endpoint returned %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("could not decode provider keys: %v", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var publicKey crypto.PublicKey
+		switch {
+		case jwk.Kty == "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			publicKey = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case jwk.Kty == "EC" && jwk.Crv == "P-256":
+			x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			publicKey = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+			x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+			if err != nil || len(x) != ed25519.PublicKeySize {
+				continue
+			}
+			publicKey = ed25519.PublicKey(x)
+		default:
+			continue
+		}
+		keys[jwk.Kid] = publicKey
+	}
+	return keys, nil
+}
+
+func randomString() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("could not generate random value: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
@@ -0,0 +1,322 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testClientID = "news-feed"
+	testKeyID    = "test-key"
+	testCode     = "authorization-code"
+)
+
+// testProvider is a mock identity provider. Its token endpoint redeems testCode only with the verifier
+// of the challenge the authorization request was made with.
+type testProvider struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	codeChallenge string
+	idToken       string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate RSA key: %v", err)
+	}
+	p := &testProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/token", func(w http.ResponseWriter, r *http.Request) {
+			if r.PostFormValue("code") != testCode || CodeChallenge(r.PostFormValue("code_verifier")) != p.codeChallenge {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id_token": p.idToken})
+		},
+	)
+	mux.HandleFunc(
+		"/jwks", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(
+				map[string]interface{}{
+					"keys": []map[string]string{
+						{
+							"kty": "RSA",
+							"kid": testKeyID,
+							"use": "sig",
+							"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+							"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+						},
+					},
+				},
+			)
+		},
+	)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *testProvider) client() *Provider {
+	return NewProvider(
+		Config{
+			Issuer:                testIssuer,
+			ClientID:              testClientID,
+			RedirectURL:           "https://news-feed.example.com/v1/auth/oidc/callback",
+			AuthorizationEndpoint: p.server.URL + "/authorize",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/jwks",
+		},
+		p.server.Client(),
+	)
+}
+
+func (p *testProvider) sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("could not sign ID token: %v", err)
+	}
+	return signed
+}
+
+func validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            testIssuer,
+		"aud":            testClientID,
+		"sub":            "subject-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "alice@example.com",
+		"email_verified": true,
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B
+	if got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Fatalf("CodeChallenge() = %s", got)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	p := NewProvider(
+		Config{
+			ClientID:              testClientID,
+			RedirectURL:           "https://news-feed.example.com/callback",
+			AuthorizationEndpoint: "https://idp.example.com/authorize?tenant=1",
+		}, nil,
+	)
+	authURL, err := url.Parse(p.AuthCodeURL("the-state", "the-nonce", "the-verifier"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() is not a URL: %v", err)
+	}
+	want := map[string]string{
+		"tenant":                "1",
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          "https://news-feed.example.com/callback",
+		"scope":                 "openid email profile",
+		"state":                 "the-state",
+		"nonce":                 "the-nonce",
+		"code_challenge":        CodeChallenge("the-verifier"),
+		"code_challenge_method": "S256",
+	}
+	query := authURL.Query()
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if query.Has("code_verifier") {
+		t.Error("the code verifier was sent to the authorization endpoint")
+	}
+}
+
+func TestNewStateIsRandom(t *testing.T) {
+	first, err := NewState()
+	if err != nil {
+		t.Fatalf("NewState() error = %v", err)
+	}
+	second, err := NewState()
+	if err != nil {
+		t.Fatalf("NewState() error = %v", err)
+	}
+	if first == second || len(first) < 43 {
+		t.Fatalf("NewState() = %q, %q, want distinct 256 bit values", first, second)
+	}
+}
+
+func TestExchangeRequiresCodeVerifier(t *testing.T) {
+	provider := newTestProvider(t)
+	client := provider.client()
+	codeVerifier, err := NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("NewCodeVerifier() error = %v", err)
+	}
+	authURL, _ := url.Parse(client.AuthCodeURL("state", "nonce", codeVerifier))
+	provider.codeChallenge = authURL.Query().Get("code_challenge")
+	provider.idToken = "the-id-token"
+
+	otherVerifier, _ := NewCodeVerifier()
+	if _, err := client.Exchange(context.Background(), testCode, otherVerifier); err == nil {
+		t.Fatal("Exchange() with another code verifier succeeded")
+	}
+	idToken, err := client.Exchange(context.Background(), testCode, codeVerifier)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if idToken != "the-id-token" {
+		t.Fatalf("Exchange() = %q", idToken)
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	provider := newTestProvider(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const nonce = "login-nonce"
+
+	with := func(changes jwt.MapClaims) jwt.MapClaims {
+		claims := validClaims(nonce)
+		for key, value := range changes {
+			if value == nil {
+				delete(claims, key)
+			} else {
+				claims[key] = value
+			}
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: provider.sign(t, jwt.SigningMethodRS256, provider.key, validClaims(nonce)),
+		},
+		{
+			name:  "audience list with the client",
+			token: provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"aud": []string{"other", testClientID}, "azp": testClientID})),
+		},
+		{
+			name:    "nonce of another login",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"nonce": "other-nonce"})),
+			wantErr: true,
+		},
+		{
+			name:    "no nonce",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"nonce": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "another issuer",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"iss": "https://evil.example.com"})),
+			wantErr: true,
+		},
+		{
+			name:    "another audience",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"aud": "other-client"})),
+			wantErr: true,
+		},
+		{
+			name:    "authorized party is another client",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"azp": "other-client"})),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})),
+			wantErr: true,
+		},
+		{
+			name:    "no expiry",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"exp": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "no subject",
+			token:   provider.sign(t, jwt.SigningMethodRS256, provider.key, with(jwt.MapClaims{"sub": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key",
+			token:   provider.sign(t, jwt.SigningMethodRS256, otherKey, validClaims(nonce)),
+			wantErr: true,
+		},
+		{
+			name:    "HS256 with the public key as secret",
+			token:   provider.sign(t, jwt.SigningMethodHS256, provider.key.N.Bytes(), validClaims(nonce)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.client().VerifyIDToken(context.Background(), tt.token, nonce)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidIDToken) {
+					t.Fatalf("VerifyIDToken() error = %v, want %v", err, ErrInvalidIDToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyIDToken() error = %v", err)
+			}
+			if claims.Subject != "subject-1" || claims.Email != "alice@example.com" || !claims.EmailVerified {
+				t.Fatalf("VerifyIDToken() = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenEmailVerified(t *testing.T) {
+	provider := newTestProvider(t)
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{
+		{true, true},
+		{false, false},
+		{"true", true},
+		{"false", false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		claims := validClaims("nonce")
+		if tt.value == nil {
+			delete(claims, "email_verified")
+		} else {
+			claims["email_verified"] = tt.value
+		}
+		token := provider.sign(t, jwt.SigningMethodRS256, provider.key, claims)
+		got, err := provider.client().VerifyIDToken(context.Background(), token, "nonce")
+		if err != nil {
+			t.Fatalf("VerifyIDToken() error = %v", err)
+		}
+		if got.EmailVerified != tt.want {
+			t.Errorf("email_verified %v: EmailVerified = %v, want %v", tt.value, got.EmailVerified, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package password hashes and verifies user passwords.
+//
+// Hashes are argon2id in the PHC string format, so the algorithm and cost parameters are stored alongside
+// the hash:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<base64 salt>$<base64 key>
+//
+// Hashes created before argon2id was introduced are a base64 SHA-256 of the password followed by a salt
+// that is stored separately. They still verify and are reported as needing a rehash.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const algorithmArgon2id = "argon2id"
+
+// ErrInvalidHash is returned when a stored hash cannot be parsed.
+var ErrInvalidHash = errors.New("invalid password hash")
+
+// Params are the argon2id cost parameters.
+type Params struct {
+	Memory      uint32 // Memory in KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams are used for new hashes. Hashes with other parameters are reported as needing a rehash.
+var DefaultParams = Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Hash returns the argon2id hash of the password with DefaultParams.
+func Hash(password string) (string, error) {
+	return HashWithParams(password, DefaultParams)
+}
+
+// HashWithParams returns the argon2id hash of the password with the given parameters.
+func HashWithParams(password string, params Params) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("could not generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		algorithmArgon2id, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether the password matches the stored hash. legacySalt is only used for legacy SHA-256
+// hashes. needsRehash is true when the password matches but the hash should be replaced with Hash.
+func Verify(password, storedHash, legacySalt string) (match bool, needsRehash bool, err error) {
+	if !strings.HasPrefix(storedHash, "$") {
+		match = verifyLegacy(password, storedHash, legacySalt)
+		return match, match, nil
+	}
+
+	params, salt, key, err := decode(storedHash)
+	if err != nil {
+		return false, false, err
+	}
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return false, false, nil
+	}
+	return true, params != DefaultParams, nil
+}
+
+func verifyLegacy(password, storedHash, salt string) bool {
+	hash := sha256.Sum256([]byte(password + salt))
+	legacyHash := base64.StdEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(legacyHash), []byte(storedHash)) == 1
+}
+
+func decode(storedHash string) (Params, []byte, []byte, error) {
+	// "", algorithm, version, parameters, salt, key
+	parts := strings.Split(storedHash, "$")
+	if len(parts) != 6 || parts[1] != algorithmArgon2id {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var params Params
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
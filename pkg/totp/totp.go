@@ -0,0 +1,117 @@
+// Package totp generates and validates time-based one-time passwords (RFC 6238) as used by
+// authenticator apps: HMAC-SHA1, 6 digits and a 30 second period.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	digits     = 6
+	period     = 30 * time.Second
+	secretSize = 20
+)
+
+// ErrInvalidSecret is returned when a secret is not valid base32.
+var ErrInvalidSecret = errors.New("invalid TOTP secret")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP validates codes against the current time. Now is injectable so validation can be deterministic.
+type TOTP struct {
+	now  func() time.Time
+	skew int // Periods before and after the current one that are accepted, for clock drift
+}
+
+// New creates a TOTP using now as its time source, accepting codes of the adjacent periods.
+func New(now func() time.Time) *TOTP {
+	return &TOTP{now: now, skew: 1}
+}
+
+// GenerateSecret returns a random base32 secret.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("could not generate TOTP secret: %w", err)
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI returns the otpauth URI authenticator apps enroll the secret with, usually shown as a QR code.
+func URI(issuer string, accountName string, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(int(period.Seconds())))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Code returns the code of the secret for the period containing t.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, counterAt(t)), nil
+}
+
+// Validate reports whether the code is valid for the secret now. It also returns the counter of the
+// period the code belongs to, callers reject a counter that was already used to prevent replays.
+func (t *TOTP) Validate(secret string, userCode string) (bool, int64, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return false, 0, err
+	}
+	userCode = strings.TrimSpace(userCode)
+	if len(userCode) != digits {
+		return false, 0, nil
+	}
+
+	current := counterAt(t.now())
+	for offset := -t.skew; offset <= t.skew; offset++ {
+		counter := current + int64(offset)
+		if subtle.ConstantTimeCompare([]byte(code(key, counter)), []byte(userCode)) == 1 {
+			return true, counter, nil
+		}
+	}
+	return false, 0, nil
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+func counterAt(t time.Time) int64 {
+	return t.Unix() / int64(period.Seconds())
+}
+
+// code is the HOTP value (RFC 4226) of the counter.
+func code(key []byte, counter int64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", digits, value%1000000)
+}
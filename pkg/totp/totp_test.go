@@ -0,0 +1,100 @@
+package totp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors, "12345678901234567890", in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeMatchesRFC6238(t *testing.T) {
+	// The last six digits of the eight digit RFC 6238 SHA1 vectors
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d) error = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAcceptsAdjacentPeriods(t *testing.T) {
+	now := time.Unix(1700000015, 0)
+	validator := New(func() time.Time { return now })
+	current := counterAt(now)
+
+	tests := []struct {
+		name        string
+		codeTime    time.Time
+		wantValid   bool
+		wantCounter int64
+	}{
+		{"current period", now, true, current},
+		{"previous period", now.Add(-period), true, current - 1},
+		{"next period", now.Add(period), true, current + 1},
+		{"two periods ago", now.Add(-2 * period), false, 0},
+		{"two periods ahead", now.Add(2 * period), false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Code(rfcSecret, tt.codeTime)
+			if err != nil {
+				t.Fatalf("Code() error = %v", err)
+			}
+			valid, counter, err := validator.Validate(rfcSecret, code)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if valid != tt.wantValid || counter != tt.wantCounter {
+				t.Fatalf("Validate() = %v, %d, want %v, %d", valid, counter, tt.wantValid, tt.wantCounter)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(1700000015, 0)
+	validator := New(func() time.Time { return now })
+	code, err := Code(rfcSecret, now)
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+
+	for _, userCode := range []string{"", code[:5], code + "0", "abcdef"} {
+		if valid, _, err := validator.Validate(rfcSecret, userCode); valid || err != nil {
+			t.Errorf("Validate(%q) = %v, %v, want false", userCode, valid, err)
+		}
+	}
+	if valid, _, _ := validator.Validate(rfcSecret, " "+code+" "); !valid {
+		t.Error("Validate() rejected the code surrounded by spaces")
+	}
+	if _, _, err := validator.Validate("not base32!", code); !errors.Is(err, ErrInvalidSecret) {
+		t.Errorf("Validate() of an invalid secret: error = %v, want %v", err, ErrInvalidSecret)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	key, err := decodeSecret(secret)
+	if err != nil || len(key) != secretSize {
+		t.Fatalf("secret %q decodes to %d bytes, %v", secret, len(key), err)
+	}
+}
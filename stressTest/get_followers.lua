@@ -4,6 +4,7 @@
 --- DateTime: 24/9/24 15:45
 ---
 
+-- Requires LOAD_TEST_AUTH_ENABLED=true and APP_ENV=loadtest on the webapp, requests run as random users of the configured ID range
 wrk.headers["Authorization"] = "wrk-stress-test"
 wrk.headers["Content-Type"] = "application/json"
 
@@ -1,6 +1,7 @@
 local json = require "json"
 
 wrk.method = "POST"
+-- Requires LOAD_TEST_AUTH_ENABLED=true and APP_ENV=loadtest on the webapp, requests run as random users of the configured ID range
 wrk.headers["Authorization"] = "wrk-stress-test"
 wrk.headers["Content-Type"] = "application/json"
 